@@ -27,12 +27,14 @@ const (
 	sendMultipleTransactionsEndpoint = "/transaction/send-multiple"
 	getTransactionEndpoint           = "/transaction/:hash"
 	getScrsByTxHashEndpoint          = "/transaction/scrs-by-tx-hash/:txhash"
+	getTransactionDetailsEndpoint    = "/transaction/details/:txhash"
 	sendTransactionPath              = "/send"
 	simulateTransactionPath          = "/simulate"
 	costPath                         = "/cost"
 	sendMultiplePath                 = "/send-multiple"
 	getTransactionPath               = "/:txhash"
 	getScrsByTxHashPath              = "/scrs-by-tx-hash/:txhash"
+	getTransactionDetailsPath        = "/details/:txhash"
 	getTransactionsPool              = "/pool"
 
 	queryParamWithResults    = "withResults"
@@ -42,6 +44,7 @@ const (
 	queryParamLastNonce      = "last-nonce"
 	queryParamNonceGaps      = "nonce-gaps"
 	queryParameterScrHash    = "scrHash"
+	queryParamOriginalSender = "originalSender"
 )
 
 // transactionFacadeHandler defines the methods to be implemented by a facade for transaction requests
@@ -53,6 +56,7 @@ type transactionFacadeHandler interface {
 	SimulateTransactionExecution(tx *transaction.Transaction) (*txSimData.SimulationResultsWithVMOutput, error)
 	GetTransaction(hash string, withResults bool) (*transaction.ApiTransactionResult, error)
 	GetSCRsByTxHash(txHash string, scrHash string) ([]*transaction.ApiSmartContractResult, error)
+	GetTransactionDetails(txHash string, originalSender string) (*common.ApiTransactionDetails, error)
 	GetTransactionsPool(fields string) (*common.TransactionsPoolAPIResponse, error)
 	GetTransactionsPoolForSender(sender, fields string) (*common.TransactionsPoolForSenderApiResponse, error)
 	GetLastPoolNonceForSender(sender string) (uint64, error)
@@ -152,6 +156,17 @@ func NewTransactionGroup(facade transactionFacadeHandler) (*transactionGroup, er
 				},
 			},
 		},
+		{
+			Path:    getTransactionDetailsPath,
+			Method:  http.MethodGet,
+			Handler: tg.getTransactionDetails,
+			AdditionalMiddlewares: []shared.AdditionalMiddleware{
+				{
+					Middleware: middleware.CreateEndpointThrottlerFromFacade(getTransactionDetailsEndpoint, facade),
+					Position:   shared.Before,
+				},
+			},
+		},
 	}
 	tg.endpoints = endpoints
 
@@ -439,6 +454,49 @@ func (tg *transactionGroup) getScrsByTxHash(c *gin.Context) {
 	)
 }
 
+// getTransactionDetails returns the transaction with the given txhash, together with the details of its smart contract results.
+// The smart contract results can be optionally restricted to the ones having the provided original sender
+func (tg *transactionGroup) getTransactionDetails(c *gin.Context) {
+	txhash := c.Param("txhash")
+	if txhash == "" {
+		c.JSON(
+			http.StatusBadRequest,
+			shared.GenericAPIResponse{
+				Data:  nil,
+				Error: fmt.Sprintf("%s: %s", errors.ErrValidation.Error(), errors.ErrValidationEmptyTxHash.Error()),
+				Code:  shared.ReturnCodeRequestError,
+			},
+		)
+		return
+	}
+
+	originalSender := c.Request.URL.Query().Get(queryParamOriginalSender)
+
+	start := time.Now()
+	txDetails, err := tg.getFacade().GetTransactionDetails(txhash, originalSender)
+	if err != nil {
+		c.JSON(
+			http.StatusInternalServerError,
+			shared.GenericAPIResponse{
+				Data:  nil,
+				Error: fmt.Sprintf("%s: %s", errors.ErrGetTransaction.Error(), err.Error()),
+				Code:  shared.ReturnCodeInternalError,
+			},
+		)
+		return
+	}
+	logging.LogAPIActionDurationIfNeeded(start, "API call: GetTransactionDetails")
+
+	c.JSON(
+		http.StatusOK,
+		shared.GenericAPIResponse{
+			Data:  gin.H{"transactionDetails": txDetails},
+			Error: "",
+			Code:  shared.ReturnCodeSuccess,
+		},
+	)
+}
+
 // getTransaction returns transaction details for a given txhash
 func (tg *transactionGroup) getTransaction(c *gin.Context) {
 	txhash := c.Param("txhash")
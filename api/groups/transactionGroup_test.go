@@ -129,6 +129,16 @@ type txPoolNonceGapsForSenderResponse struct {
 	Code  string                               `json:"code"`
 }
 
+type transactionDetailsResponseData struct {
+	TransactionDetails common.ApiTransactionDetails `json:"transactionDetails"`
+}
+
+type transactionDetailsResponse struct {
+	Data  transactionDetailsResponseData `json:"data"`
+	Error string                         `json:"error"`
+	Code  string                         `json:"code"`
+}
+
 var (
 	sender      = "sender"
 	receiver    = "receiver"
@@ -412,6 +422,98 @@ func TestTransactionsGroup_getSCRsByTxHash(t *testing.T) {
 	})
 }
 
+func TestTransactionsGroup_getTransactionDetails(t *testing.T) {
+	t.Parallel()
+
+	t.Run("facade error should error", func(t *testing.T) {
+		localErr := fmt.Errorf("error")
+		facade := &mock.FacadeStub{
+			GetTransactionDetailsCalled: func(txHash string, originalSender string) (*common.ApiTransactionDetails, error) {
+				return nil, localErr
+			},
+		}
+
+		transactionGroup, err := groups.NewTransactionGroup(facade)
+		require.NoError(t, err)
+
+		ws := startWebServer(transactionGroup, "transaction", getTransactionRoutesConfig())
+
+		req, _ := http.NewRequest(http.MethodGet, "/transaction/details/txhash", bytes.NewBuffer([]byte{}))
+		resp := httptest.NewRecorder()
+		ws.ServeHTTP(resp, req)
+
+		txResp := shared.GenericAPIResponse{}
+		loadResponse(resp.Body, &txResp)
+
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+		assert.True(t, strings.Contains(txResp.Error, apiErrors.ErrGetTransaction.Error()))
+		assert.True(t, strings.Contains(txResp.Error, localErr.Error()))
+		assert.Empty(t, txResp.Data)
+	})
+	t.Run("should pass the original sender", func(t *testing.T) {
+		providedOriginalSender := "erd1original"
+		facade := &mock.FacadeStub{
+			GetTransactionDetailsCalled: func(txHash string, originalSender string) (*common.ApiTransactionDetails, error) {
+				assert.Equal(t, providedOriginalSender, originalSender)
+
+				return &common.ApiTransactionDetails{}, nil
+			},
+		}
+
+		transactionGroup, err := groups.NewTransactionGroup(facade)
+		require.NoError(t, err)
+
+		ws := startWebServer(transactionGroup, "transaction", getTransactionRoutesConfig())
+
+		req, _ := http.NewRequest(http.MethodGet, "/transaction/details/txhash?originalSender="+providedOriginalSender, bytes.NewBuffer([]byte{}))
+		resp := httptest.NewRecorder()
+		ws.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+	})
+	t.Run("should work", func(t *testing.T) {
+		providedTxHash := "txhash"
+		facade := &mock.FacadeStub{
+			GetTransactionDetailsCalled: func(txHash string, originalSender string) (*common.ApiTransactionDetails, error) {
+				assert.Equal(t, providedTxHash, txHash)
+				assert.Empty(t, originalSender)
+
+				return &common.ApiTransactionDetails{
+					Transaction: &dataTx.ApiTransactionResult{Hash: providedTxHash, Nonce: 7},
+					SmartContractResults: []*common.ApiSmartContractResultDetails{
+						{
+							Hash:                "scrhash",
+							OriginalTxNonce:     7,
+							OriginalSenderShard: 1,
+						},
+					},
+				}, nil
+			},
+		}
+
+		transactionGroup, err := groups.NewTransactionGroup(facade)
+		require.NoError(t, err)
+
+		ws := startWebServer(transactionGroup, "transaction", getTransactionRoutesConfig())
+
+		req, _ := http.NewRequest(http.MethodGet, "/transaction/details/"+providedTxHash, bytes.NewBuffer([]byte{}))
+		resp := httptest.NewRecorder()
+		ws.ServeHTTP(resp, req)
+
+		txDetailsResp := transactionDetailsResponse{}
+		loadResponse(resp.Body, &txDetailsResp)
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Empty(t, txDetailsResp.Error)
+		assert.Equal(t, providedTxHash, txDetailsResp.Data.TransactionDetails.Transaction.Hash)
+		require.Len(t, txDetailsResp.Data.TransactionDetails.SmartContractResults, 1)
+		scrDetails := txDetailsResp.Data.TransactionDetails.SmartContractResults[0]
+		assert.Equal(t, "scrhash", scrDetails.Hash)
+		assert.Equal(t, uint64(7), scrDetails.OriginalTxNonce)
+		assert.Equal(t, uint32(1), scrDetails.OriginalSenderShard)
+	})
+}
+
 func TestTransactionGroup_sendMultipleTransactions(t *testing.T) {
 	t.Parallel()
 
@@ -1193,6 +1295,7 @@ func getTransactionRoutesConfig() config.ApiRoutesConfig {
 					{Name: "/:txhash/status", Open: true},
 					{Name: "/simulate", Open: true},
 					{Name: "/scrs-by-tx-hash/:txhash", Open: true},
+					{Name: "/details/:txhash", Open: true},
 				},
 			},
 		},
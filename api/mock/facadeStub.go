@@ -98,6 +98,16 @@ type FacadeStub struct {
 	P2PPrometheusMetricsEnabledCalled           func() bool
 	AuctionListHandler                          func() ([]*common.AuctionListValidatorAPIResponse, error)
 	GetSCRsByTxHashCalled                       func(txHash string, scrHash string) ([]*transaction.ApiSmartContractResult, error)
+	GetTransactionDetailsCalled                 func(txHash string, originalSender string) (*common.ApiTransactionDetails, error)
+}
+
+// GetTransactionDetails -
+func (f *FacadeStub) GetTransactionDetails(txHash string, originalSender string) (*common.ApiTransactionDetails, error) {
+	if f.GetTransactionDetailsCalled != nil {
+		return f.GetTransactionDetailsCalled(txHash, originalSender)
+	}
+
+	return nil, nil
 }
 
 // GetSCRsByTxHash -
@@ -136,6 +136,7 @@ type FacadeHandler interface {
 	GetWaitingManagedKeys() ([]string, error)
 	GetWaitingEpochsLeftForPublicKey(publicKey string) (uint32, error)
 	GetSCRsByTxHash(txHash string, scrHash string) ([]*transaction.ApiSmartContractResult, error)
+	GetTransactionDetails(txHash string, originalSender string) (*common.ApiTransactionDetails, error)
 	P2PPrometheusMetricsEnabled() bool
 	IsInterfaceNil() bool
 }
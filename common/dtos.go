@@ -2,6 +2,7 @@ package common
 
 import (
 	"github.com/multiversx/mx-chain-core-go/data/alteredAccount"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
 )
 
 // GetProofResponse is a struct that stores the response of a GetProof API request
@@ -91,3 +92,52 @@ type AuctionListValidatorAPIResponse struct {
 	QualifiedTopUp string         `json:"qualifiedTopUp"`
 	Nodes          []*AuctionNode `json:"nodes"`
 }
+
+// ApiTransactionDetails holds a transaction together with the details of its smart contract results that are not
+// available on the API transaction structures
+type ApiTransactionDetails struct {
+	Transaction          *transaction.ApiTransactionResult `json:"transaction"`
+	SmartContractResults []*ApiSmartContractResultDetails  `json:"smartContractResults"`
+	TotalRefund          string                            `json:"totalRefund"`
+	NumRefunds           int                               `json:"numRefunds"`
+	StatusSummary        *TransactionStatusSummary         `json:"statusSummary"`
+	Logs                 *ApiLogsDetails                   `json:"logs,omitempty"`
+}
+
+// TransactionStatusSummary holds a concise description of the outcome of a transaction
+type TransactionStatusSummary struct {
+	Success      bool   `json:"success"`
+	FailedReason string `json:"failedReason,omitempty"`
+	HadRefund    bool   `json:"hadRefund"`
+	NumSCRs      int    `json:"numSCRs"`
+}
+
+// ApiSmartContractResultDetails holds the details of a smart contract result, to be returned on API calls. The depth is
+// the position in the call tree of the original transaction, -1 meaning that it could not be computed
+type ApiSmartContractResultDetails struct {
+	Hash                   string                            `json:"hash"`
+	OriginalTxNonce        uint64                            `json:"originalTxNonce"`
+	OriginalSenderShard    uint32                            `json:"originalSenderShard"`
+	Receipt                *transaction.ApiReceipt           `json:"receipt,omitempty"`
+	NotarizedAtDestination bool                              `json:"notarizedAtDestination"`
+	Timestamp              int64                             `json:"timestamp"`
+	Epoch                  uint32                            `json:"epoch"`
+	Depth                  int                               `json:"depth"`
+	IsGasRefund            bool                              `json:"isGasRefund"`
+	IsValueReturn          bool                              `json:"isValueReturn"`
+	Category               string                            `json:"category"`
+	InnerTransaction       *transaction.ApiTransactionResult `json:"innerTransaction,omitempty"`
+	Logs                   *ApiLogsDetails                   `json:"logs,omitempty"`
+}
+
+// ApiLogsDetails holds the details of a transaction log that are not available on the API logs structure. The tx hash is
+// the hex encoded hash of the transaction or smart contract result which generated the log. The number of events counts
+// all the events of the log, including the ones dropped when the events are truncated. The decoded events data is aligned
+// with the returned events, the events without a registered decoder having a nil entry
+type ApiLogsDetails struct {
+	TxHash            string                   `json:"txHash"`
+	NumEvents         int                      `json:"numEvents"`
+	EventsTruncated   bool                     `json:"eventsTruncated"`
+	TopicsTruncated   bool                     `json:"topicsTruncated"`
+	DecodedEventsData []map[string]interface{} `json:"decodedEventsData,omitempty"`
+}
@@ -2,26 +2,61 @@ package forking
 
 import (
 	"sync"
+	"time"
 
 	"github.com/multiversx/mx-chain-core-go/core/check"
 	"github.com/multiversx/mx-chain-core-go/data"
 	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
 )
 
+// RoundEvent holds a round confirmed by the round notifier
+type RoundEvent struct {
+	Round       uint64
+	Timestamp   uint64
+	ConfirmedAt time.Time
+}
+
+// SubscriberStats holds the round notifier subscribers counters, over the notifier's lifetime
+type SubscriberStats struct {
+	Current           int
+	Peak              int
+	TotalRegistered   uint64
+	TotalUnregistered uint64
+}
+
 type genericRoundNotifier struct {
 	mutData          sync.RWMutex
 	wasInitialized   bool
 	currentRound     uint64
 	currentTimestamp uint64
+	history          []RoundEvent
+	historyCapacity  int
+	historyStart     int
 	mutHandler       sync.RWMutex
 	handlers         []vmcommon.RoundSubscriberHandler
+
+	peakHandlers      int
+	totalRegistered   uint64
+	totalUnregistered uint64
 }
 
 // NewGenericRoundNotifier creates a new instance of a genericRoundNotifier component
 func NewGenericRoundNotifier() *genericRoundNotifier {
+	return NewGenericRoundNotifierWithHistory(0)
+}
+
+// NewGenericRoundNotifierWithHistory creates a new instance of a genericRoundNotifier component that keeps the last
+// historyCapacity confirmed rounds, retrievable with History. A zero or negative capacity disables the history
+func NewGenericRoundNotifierWithHistory(historyCapacity int) *genericRoundNotifier {
+	if historyCapacity < 0 {
+		historyCapacity = 0
+	}
+
 	return &genericRoundNotifier{
-		wasInitialized: false,
-		handlers:       make([]vmcommon.RoundSubscriberHandler, 0),
+		wasInitialized:  false,
+		history:         make([]RoundEvent, 0, historyCapacity),
+		historyCapacity: historyCapacity,
+		handlers:        make([]vmcommon.RoundSubscriberHandler, 0),
 	}
 }
 
@@ -44,6 +79,7 @@ func (grn *genericRoundNotifier) CheckRound(header data.HeaderHandler) {
 	grn.wasInitialized = true
 	grn.currentRound = round
 	grn.currentTimestamp = timestamp
+	grn.addToHistory(round, timestamp)
 	grn.mutData.Unlock()
 
 	grn.mutHandler.RLock()
@@ -62,6 +98,39 @@ func (grn *genericRoundNotifier) CheckRound(header data.HeaderHandler) {
 	}
 }
 
+// addToHistory records the confirmed round, overwriting the oldest record when the history is full. Should be called
+// under mutData
+func (grn *genericRoundNotifier) addToHistory(round uint64, timestamp uint64) {
+	if grn.historyCapacity == 0 {
+		return
+	}
+
+	event := RoundEvent{
+		Round:       round,
+		Timestamp:   timestamp,
+		ConfirmedAt: time.Now(),
+	}
+	if len(grn.history) < grn.historyCapacity {
+		grn.history = append(grn.history, event)
+		return
+	}
+
+	grn.history[grn.historyStart] = event
+	grn.historyStart = (grn.historyStart + 1) % grn.historyCapacity
+}
+
+// History returns the last confirmed rounds, from the oldest to the newest. It is empty if the history is disabled
+func (grn *genericRoundNotifier) History() []RoundEvent {
+	grn.mutData.RLock()
+	defer grn.mutData.RUnlock()
+
+	history := make([]RoundEvent, 0, len(grn.history))
+	history = append(history, grn.history[grn.historyStart:]...)
+	history = append(history, grn.history[:grn.historyStart]...)
+
+	return history
+}
+
 // RegisterNotifyHandler will register the provided handler to be called whenever a new Round has changed
 func (grn *genericRoundNotifier) RegisterNotifyHandler(handler vmcommon.RoundSubscriberHandler) {
 	if check.IfNil(handler) {
@@ -70,6 +139,10 @@ func (grn *genericRoundNotifier) RegisterNotifyHandler(handler vmcommon.RoundSub
 
 	grn.mutHandler.Lock()
 	grn.handlers = append(grn.handlers, handler)
+	grn.totalRegistered++
+	if len(grn.handlers) > grn.peakHandlers {
+		grn.peakHandlers = len(grn.handlers)
+	}
 	grn.mutHandler.Unlock()
 
 	round, timestamp := grn.getRoundTimestamp()
@@ -94,10 +167,33 @@ func (grn *genericRoundNotifier) CurrentRound() uint64 {
 // UnRegisterAll removes all registered handlers queue
 func (grn *genericRoundNotifier) UnRegisterAll() {
 	grn.mutHandler.Lock()
+	grn.totalUnregistered += uint64(len(grn.handlers))
 	grn.handlers = make([]vmcommon.RoundSubscriberHandler, 0)
 	grn.mutHandler.Unlock()
 }
 
+// NumRegisteredHandlers returns the number of currently registered handlers
+func (grn *genericRoundNotifier) NumRegisteredHandlers() int {
+	grn.mutHandler.RLock()
+	defer grn.mutHandler.RUnlock()
+
+	return len(grn.handlers)
+}
+
+// SubscriberStats returns the current and the peak number of registered handlers, together with the total number of
+// registrations and unregistrations since the notifier was created. Useful for detecting subscribers leaks
+func (grn *genericRoundNotifier) SubscriberStats() SubscriberStats {
+	grn.mutHandler.RLock()
+	defer grn.mutHandler.RUnlock()
+
+	return SubscriberStats{
+		Current:           len(grn.handlers),
+		Peak:              grn.peakHandlers,
+		TotalRegistered:   grn.totalRegistered,
+		TotalUnregistered: grn.totalUnregistered,
+	}
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (grn *genericRoundNotifier) IsInterfaceNil() bool {
 	return grn == nil
@@ -60,6 +60,79 @@ func TestGenericRoundNotifier_UnregisterAllShouldWork(t *testing.T) {
 	assert.Equal(t, 0, len(grp.Handlers()))
 }
 
+func TestGenericRoundNotifier_NumRegisteredHandlers(t *testing.T) {
+	t.Parallel()
+
+	grp := NewGenericRoundNotifier()
+	assert.Equal(t, 0, grp.NumRegisteredHandlers())
+
+	grp.RegisterNotifyHandler(nil)
+	assert.Equal(t, 0, grp.NumRegisteredHandlers())
+
+	grp.RegisterNotifyHandler(&mock.RoundSubscriberHandlerStub{})
+	grp.RegisterNotifyHandler(&mock.RoundSubscriberHandlerStub{})
+	assert.Equal(t, 2, grp.NumRegisteredHandlers())
+
+	grp.UnRegisterAll()
+	assert.Equal(t, 0, grp.NumRegisteredHandlers())
+}
+
+func TestGenericRoundNotifier_SubscriberStats(t *testing.T) {
+	t.Parallel()
+
+	grp := NewGenericRoundNotifier()
+	assert.Equal(t, SubscriberStats{}, grp.SubscriberStats())
+
+	grp.RegisterNotifyHandler(nil)
+	assert.Equal(t, SubscriberStats{}, grp.SubscriberStats())
+
+	grp.RegisterNotifyHandler(&mock.RoundSubscriberHandlerStub{})
+	grp.RegisterNotifyHandler(&mock.RoundSubscriberHandlerStub{})
+	grp.RegisterNotifyHandler(&mock.RoundSubscriberHandlerStub{})
+	expectedStats := SubscriberStats{
+		Current:           3,
+		Peak:              3,
+		TotalRegistered:   3,
+		TotalUnregistered: 0,
+	}
+	assert.Equal(t, expectedStats, grp.SubscriberStats())
+
+	grp.UnRegisterAll()
+	expectedStats = SubscriberStats{
+		Current:           0,
+		Peak:              3,
+		TotalRegistered:   3,
+		TotalUnregistered: 3,
+	}
+	assert.Equal(t, expectedStats, grp.SubscriberStats())
+
+	// a smaller cycle should not change the peak
+	grp.RegisterNotifyHandler(&mock.RoundSubscriberHandlerStub{})
+	grp.RegisterNotifyHandler(&mock.RoundSubscriberHandlerStub{})
+	grp.UnRegisterAll()
+	grp.UnRegisterAll()
+	expectedStats = SubscriberStats{
+		Current:           0,
+		Peak:              3,
+		TotalRegistered:   5,
+		TotalUnregistered: 5,
+	}
+	assert.Equal(t, expectedStats, grp.SubscriberStats())
+
+	// a larger cycle should raise the peak
+	for i := 0; i < 4; i++ {
+		grp.RegisterNotifyHandler(&mock.RoundSubscriberHandlerStub{})
+	}
+	expectedStats = SubscriberStats{
+		Current:           4,
+		Peak:              4,
+		TotalRegistered:   9,
+		TotalUnregistered: 5,
+	}
+	assert.Equal(t, expectedStats, grp.SubscriberStats())
+	assert.Equal(t, grp.NumRegisteredHandlers(), grp.SubscriberStats().Current)
+}
+
 func TestGenericRoundNotifier_CheckRoundNilHeaderNotCall(t *testing.T) {
 	t.Parallel()
 
@@ -152,3 +225,55 @@ func TestGenericRoundNotifier_CheckRoundInSyncShouldWork(t *testing.T) {
 	assert.Equal(t, uint32(2), atomic.LoadUint32(&numCalls))
 	assert.True(t, end.Sub(start) >= handlerWait)
 }
+
+func TestGenericRoundNotifier_History(t *testing.T) {
+	t.Parallel()
+
+	t.Run("history disabled by default", func(t *testing.T) {
+		t.Parallel()
+
+		grp := NewGenericRoundNotifier()
+		grp.CheckRound(&testscommon.HeaderHandlerStub{RoundField: 1, TimestampField: 10})
+
+		assert.Empty(t, grp.History())
+	})
+	t.Run("negative capacity should disable the history", func(t *testing.T) {
+		t.Parallel()
+
+		grp := NewGenericRoundNotifierWithHistory(-1)
+		grp.CheckRound(&testscommon.HeaderHandlerStub{RoundField: 1, TimestampField: 10})
+
+		assert.Empty(t, grp.History())
+	})
+	t.Run("should keep the most recent events", func(t *testing.T) {
+		t.Parallel()
+
+		grp := NewGenericRoundNotifierWithHistory(3)
+		grp.CheckRound(&testscommon.HeaderHandlerStub{RoundField: 1, TimestampField: 10})
+		grp.CheckRound(&testscommon.HeaderHandlerStub{RoundField: 1, TimestampField: 10}) // same round, not recorded
+		grp.CheckRound(&testscommon.HeaderHandlerStub{RoundField: 2, TimestampField: 20})
+
+		history := grp.History()
+		assert.Equal(t, 2, len(history))
+		assert.Equal(t, uint64(1), history[0].Round)
+		assert.Equal(t, uint64(10), history[0].Timestamp)
+		assert.False(t, history[0].ConfirmedAt.IsZero())
+		assert.Equal(t, uint64(2), history[1].Round)
+
+		for round := uint64(3); round <= 7; round++ {
+			grp.CheckRound(&testscommon.HeaderHandlerStub{RoundField: round, TimestampField: round * 10})
+		}
+
+		history = grp.History()
+		assert.Equal(t, 3, len(history))
+		for i, event := range history {
+			expectedRound := uint64(5 + i)
+			assert.Equal(t, expectedRound, event.Round)
+			assert.Equal(t, expectedRound*10, event.Timestamp)
+		}
+
+		// the returned history is a copy
+		history[0].Round = 100
+		assert.Equal(t, uint64(5), grp.History()[0].Round)
+	})
+}
@@ -0,0 +1,113 @@
+package metachain
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-go/common"
+	errorsCommon "github.com/multiversx/mx-chain-go/errors"
+)
+
+// OwnerAuctionAPIData holds the auction data of an owner, as computed in the last selection process, ready to be
+// serialized in API responses. The owner is bech32 encoded, the nodes BLS keys are hex encoded and the top up values are
+// provided as base 10 strings
+type OwnerAuctionAPIData struct {
+	Owner                    string                `json:"owner"`
+	NumStakedNodes           int64                 `json:"numStakedNodes"`
+	NumActiveNodes           int64                 `json:"numActiveNodes"`
+	NumAuctionNodes          int64                 `json:"numAuctionNodes"`
+	NumQualifiedAuctionNodes int64                 `json:"numQualifiedAuctionNodes"`
+	TotalTopUp               string                `json:"totalTopUp"`
+	QualifiedTopUpPerNode    string                `json:"qualifiedTopUpPerNode"`
+	Nodes                    []*common.AuctionNode `json:"nodes"`
+}
+
+// OwnersAuctionAPIData returns the auction data of each owner from the last selection process, sorted by the encoded
+// owner address. Each auction node is flagged as qualified if it was selected. It uses the pub key converters provided
+// on construction and errors if any of them was not provided
+func (als *auctionListSelector) OwnersAuctionAPIData() ([]*OwnerAuctionAPIData, error) {
+	if check.IfNil(als.addressPubKeyConverter) {
+		return nil, errorsCommon.ErrNilAddressPublicKeyConverter
+	}
+	if check.IfNil(als.validatorPubKeyConverter) {
+		return nil, errorsCommon.ErrNilValidatorPublicKeyConverter
+	}
+
+	als.mutSelectedNodes.RLock()
+	defer als.mutSelectedNodes.RUnlock()
+
+	selectedKeys := make(map[string]struct{}, len(als.selectedNodes))
+	for _, node := range als.selectedNodes {
+		selectedKeys[string(node.validator.GetPublicKey())] = struct{}{}
+	}
+
+	ownersAPIData := make([]*OwnerAuctionAPIData, 0, len(als.ownersData))
+	for ownerPubKey, owner := range als.ownersData {
+		ownersAPIData = append(ownersAPIData, als.createOwnerAuctionAPIData(ownerPubKey, owner, selectedKeys))
+	}
+
+	sort.SliceStable(ownersAPIData, func(i, j int) bool {
+		return ownersAPIData[i].Owner < ownersAPIData[j].Owner
+	})
+
+	return ownersAPIData, nil
+}
+
+func (als *auctionListSelector) createOwnerAuctionAPIData(
+	ownerPubKey string,
+	owner *OwnerAuctionData,
+	selectedKeys map[string]struct{},
+) *OwnerAuctionAPIData {
+	nodes := make([]*common.AuctionNode, 0, len(owner.auctionList))
+	for _, node := range owner.auctionList {
+		_, isSelected := selectedKeys[string(node.GetPublicKey())]
+		nodes = append(nodes, &common.AuctionNode{
+			BlsKey:    als.validatorPubKeyConverter.SilentEncode(node.GetPublicKey(), log),
+			Qualified: isSelected,
+		})
+	}
+
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return nodes[i].BlsKey < nodes[j].BlsKey
+	})
+
+	return &OwnerAuctionAPIData{
+		Owner:                    als.addressPubKeyConverter.SilentEncode([]byte(ownerPubKey), log),
+		NumStakedNodes:           owner.numStakedNodes,
+		NumActiveNodes:           owner.numActiveNodes,
+		NumAuctionNodes:          owner.numAuctionNodes,
+		NumQualifiedAuctionNodes: owner.numQualifiedAuctionNodes,
+		TotalTopUp:               bigIntToString(owner.totalTopUp),
+		QualifiedTopUpPerNode:    bigIntToString(owner.qualifiedTopUpPerNode),
+		Nodes:                    nodes,
+	}
+}
+
+// setOwnersData stores the auction data of all the owners, updated with the qualified nodes and top up computed by the
+// soft auction. The owners which did not qualify at all will have zero qualified nodes
+func (als *auctionListSelector) setOwnersData(ownersData map[string]*OwnerAuctionData, qualifiedOwnersData map[string]*OwnerAuctionData) {
+	ownersDataCopy := copyOwnersData(ownersData)
+	for ownerPubKey, owner := range ownersDataCopy {
+		qualifiedOwner, isQualified := qualifiedOwnersData[ownerPubKey]
+		if !isQualified {
+			owner.numQualifiedAuctionNodes = 0
+			continue
+		}
+
+		owner.numQualifiedAuctionNodes = qualifiedOwner.numQualifiedAuctionNodes
+		owner.qualifiedTopUpPerNode = qualifiedOwner.qualifiedTopUpPerNode
+	}
+
+	als.mutSelectedNodes.Lock()
+	als.ownersData = ownersDataCopy
+	als.mutSelectedNodes.Unlock()
+}
+
+func bigIntToString(value *big.Int) string {
+	if value == nil {
+		return "0"
+	}
+
+	return value.String()
+}
@@ -0,0 +1,144 @@
+package metachain
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core/pubkeyConverter"
+	"github.com/multiversx/mx-chain-go/common"
+	errorsCommon "github.com/multiversx/mx-chain-go/errors"
+	"github.com/multiversx/mx-chain-go/state"
+	"github.com/multiversx/mx-chain-go/testscommon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuctionListSelector_OwnersAuctionAPIData(t *testing.T) {
+	t.Parallel()
+
+	validatorPubKeyConverter, _ := pubkeyConverter.NewHexPubkeyConverter(4)
+
+	t.Run("nil address pub key converter should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createAuctionListSelectorArgs(nil)
+		args.ValidatorPubKeyConverter = validatorPubKeyConverter
+		als, _ := NewAuctionListSelector(args)
+
+		ownersData, err := als.OwnersAuctionAPIData()
+		require.Equal(t, errorsCommon.ErrNilAddressPublicKeyConverter, err)
+		require.Nil(t, ownersData)
+	})
+
+	t.Run("nil validator pub key converter should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createAuctionListSelectorArgs(nil)
+		args.AddressPubKeyConverter = testscommon.RealWorldBech32PubkeyConverter
+		als, _ := NewAuctionListSelector(args)
+
+		ownersData, err := als.OwnersAuctionAPIData()
+		require.Equal(t, errorsCommon.ErrNilValidatorPublicKeyConverter, err)
+		require.Nil(t, ownersData)
+	})
+
+	t.Run("should return the encoded owners data", func(t *testing.T) {
+		t.Parallel()
+
+		args := createAuctionListSelectorArgs(nil)
+		args.AddressPubKeyConverter = testscommon.RealWorldBech32PubkeyConverter
+		args.ValidatorPubKeyConverter = validatorPubKeyConverter
+		als, _ := NewAuctionListSelector(args)
+
+		ownersAPIData, err := als.OwnersAuctionAPIData()
+		require.Nil(t, err)
+		require.Empty(t, ownersAPIData)
+
+		v1 := &state.ValidatorInfo{PublicKey: []byte("pk01")}
+		v2 := &state.ValidatorInfo{PublicKey: []byte("pk02")}
+		v3 := &state.ValidatorInfo{PublicKey: []byte("pk03")}
+
+		ownerBob := string(testscommon.TestPubKeyBob)
+		ownerAlice := string(testscommon.TestPubKeyAlice)
+		ownersData := map[string]*OwnerAuctionData{
+			ownerBob: {
+				numStakedNodes:           3,
+				numActiveNodes:           1,
+				numAuctionNodes:          2,
+				numQualifiedAuctionNodes: 2,
+				totalTopUp:               big.NewInt(3000),
+				topUpPerNode:             big.NewInt(1000),
+				qualifiedTopUpPerNode:    big.NewInt(1000),
+				auctionList:              []state.ValidatorInfoHandler{v2, v1},
+			},
+			ownerAlice: {
+				numStakedNodes:           1,
+				numActiveNodes:           0,
+				numAuctionNodes:          1,
+				numQualifiedAuctionNodes: 1,
+				totalTopUp:               big.NewInt(500),
+				topUpPerNode:             big.NewInt(500),
+				qualifiedTopUpPerNode:    big.NewInt(500),
+				auctionList:              []state.ValidatorInfoHandler{v3},
+			},
+		}
+		softAuctionNodesConfig := map[string]*OwnerAuctionData{
+			ownerBob: {
+				numStakedNodes:           3,
+				numActiveNodes:           1,
+				numAuctionNodes:          2,
+				numQualifiedAuctionNodes: 1,
+				totalTopUp:               big.NewInt(3000),
+				topUpPerNode:             big.NewInt(1000),
+				qualifiedTopUpPerNode:    big.NewInt(1500),
+				auctionList:              []state.ValidatorInfoHandler{v2, v1},
+			},
+		}
+		als.setOwnersData(ownersData, softAuctionNodesConfig)
+		als.setSelectedNodes([]state.ValidatorInfoHandler{v1}, softAuctionNodesConfig, map[string]*big.Int{"pk01": big.NewInt(1500)})
+
+		ownersAPIData, err = als.OwnersAuctionAPIData()
+		require.Nil(t, err)
+
+		expectedOwnersAPIData := []*OwnerAuctionAPIData{
+			{
+				Owner:                    testscommon.TestAddressAlice,
+				NumStakedNodes:           1,
+				NumActiveNodes:           0,
+				NumAuctionNodes:          1,
+				NumQualifiedAuctionNodes: 0,
+				TotalTopUp:               "500",
+				QualifiedTopUpPerNode:    "500",
+				Nodes: []*common.AuctionNode{
+					{BlsKey: "706b3033", Qualified: false},
+				},
+			},
+			{
+				Owner:                    testscommon.TestAddressBob,
+				NumStakedNodes:           3,
+				NumActiveNodes:           1,
+				NumAuctionNodes:          2,
+				NumQualifiedAuctionNodes: 1,
+				TotalTopUp:               "3000",
+				QualifiedTopUpPerNode:    "1500",
+				Nodes: []*common.AuctionNode{
+					{BlsKey: "706b3031", Qualified: true},
+					{BlsKey: "706b3032", Qualified: false},
+				},
+			},
+		}
+		require.Equal(t, expectedOwnersAPIData, ownersAPIData)
+
+		// the stored data should not be altered by the provided maps
+		ownersData[ownerAlice].numQualifiedAuctionNodes = 1
+		ownersAPIData, _ = als.OwnersAuctionAPIData()
+		require.Equal(t, expectedOwnersAPIData, ownersAPIData)
+
+		buff, err := json.Marshal(ownersAPIData[1])
+		require.Nil(t, err)
+		expectedJSON := `{"owner":"` + testscommon.TestAddressBob + `","numStakedNodes":3,"numActiveNodes":1,"numAuctionNodes":2,` +
+			`"numQualifiedAuctionNodes":1,"totalTopUp":"3000","qualifiedTopUpPerNode":"1500",` +
+			`"nodes":[{"blsKey":"706b3031","qualified":true},{"blsKey":"706b3032","qualified":false}]}`
+		require.Equal(t, expectedJSON, string(buff))
+	})
+}
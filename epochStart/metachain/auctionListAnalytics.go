@@ -0,0 +1,68 @@
+package metachain
+
+import (
+	"math/big"
+
+	"github.com/multiversx/mx-chain-go/state"
+)
+
+// ComputeOverCap returns, for each owner, the number of auction nodes exceeding the provided per owner cap. Owners
+// which are below or at the cap will have a zero excess. This is a read-only analysis over the provided owners data.
+func ComputeOverCap(ownersData map[string]*OwnerAuctionData, maxNodesPerOwner uint32) map[string]uint32 {
+	overCap := make(map[string]uint32, len(ownersData))
+	for ownerPubKey, owner := range ownersData {
+		excess := uint32(0)
+		if owner.numAuctionNodes > int64(maxNodesPerOwner) {
+			excess = uint32(owner.numAuctionNodes - int64(maxNodesPerOwner))
+		}
+
+		overCap[ownerPubKey] = excess
+	}
+
+	return overCap
+}
+
+// AuctionNodeTotals returns the total number of auction nodes, along with how many of them are qualified and how many
+// are not, across all the provided owners
+func AuctionNodeTotals(ownersData map[string]*OwnerAuctionData) (numAuctionNodes int64, numQualified int64, numUnqualified int64) {
+	for _, owner := range ownersData {
+		numAuctionNodes += owner.numAuctionNodes
+		numQualified += owner.numQualifiedAuctionNodes
+	}
+
+	return numAuctionNodes, numQualified, numAuctionNodes - numQualified
+}
+
+// NodeShardResolver returns the shard to which the top up of the provided owner's auction node should be attributed
+type NodeShardResolver func(ownerPubKey string, node state.ValidatorInfoHandler) uint32
+
+// ComputeTopUpPerShard returns the total top up of the provided owners, grouped by shard. Each auction node of an owner
+// contributes with the owner's top up per node to the shard returned by the provided resolver. If no resolver is
+// provided, the shard the node is currently assigned to (as found in its validator info) is used
+func ComputeTopUpPerShard(ownersData map[string]*OwnerAuctionData, resolveShard NodeShardResolver) map[uint32]*big.Int {
+	if resolveShard == nil {
+		resolveShard = func(_ string, node state.ValidatorInfoHandler) uint32 {
+			return node.GetShardId()
+		}
+	}
+
+	topUpPerShard := make(map[uint32]*big.Int)
+	for ownerPubKey, owner := range ownersData {
+		if owner.topUpPerNode == nil {
+			continue
+		}
+
+		for _, node := range owner.auctionList {
+			shardID := resolveShard(ownerPubKey, node)
+			totalTopUp, found := topUpPerShard[shardID]
+			if !found {
+				totalTopUp = big.NewInt(0)
+				topUpPerShard[shardID] = totalTopUp
+			}
+
+			totalTopUp.Add(totalTopUp, owner.topUpPerNode)
+		}
+	}
+
+	return topUpPerShard
+}
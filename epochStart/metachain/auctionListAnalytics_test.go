@@ -0,0 +1,136 @@
+package metachain
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-go/state"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeOverCap(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty owners data", func(t *testing.T) {
+		t.Parallel()
+
+		require.Empty(t, ComputeOverCap(make(map[string]*OwnerAuctionData), 2))
+	})
+	t.Run("owners above and below the cap", func(t *testing.T) {
+		t.Parallel()
+
+		ownersData := map[string]*OwnerAuctionData{
+			"owner1": {numAuctionNodes: 5},
+			"owner2": {numAuctionNodes: 3},
+			"owner3": {numAuctionNodes: 1},
+			"owner4": {numAuctionNodes: 0},
+		}
+
+		expected := map[string]uint32{
+			"owner1": 2,
+			"owner2": 0,
+			"owner3": 0,
+			"owner4": 0,
+		}
+		require.Equal(t, expected, ComputeOverCap(ownersData, 3))
+	})
+	t.Run("zero cap should report all auction nodes", func(t *testing.T) {
+		t.Parallel()
+
+		ownersData := map[string]*OwnerAuctionData{
+			"owner1": {numAuctionNodes: 4},
+		}
+		require.Equal(t, map[string]uint32{"owner1": 4}, ComputeOverCap(ownersData, 0))
+	})
+}
+
+func TestAuctionNodeTotals(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty owners data", func(t *testing.T) {
+		t.Parallel()
+
+		total, qualified, unqualified := AuctionNodeTotals(make(map[string]*OwnerAuctionData))
+		require.Zero(t, total)
+		require.Zero(t, qualified)
+		require.Zero(t, unqualified)
+	})
+	t.Run("owners with qualified and unqualified nodes", func(t *testing.T) {
+		t.Parallel()
+
+		ownersData := map[string]*OwnerAuctionData{
+			"owner1": {numAuctionNodes: 5, numQualifiedAuctionNodes: 5},
+			"owner2": {numAuctionNodes: 3, numQualifiedAuctionNodes: 1},
+			"owner3": {numAuctionNodes: 2, numQualifiedAuctionNodes: 0},
+			"owner4": {numAuctionNodes: 0, numQualifiedAuctionNodes: 0},
+		}
+
+		total, qualified, unqualified := AuctionNodeTotals(ownersData)
+		require.Equal(t, int64(10), total)
+		require.Equal(t, int64(6), qualified)
+		require.Equal(t, int64(4), unqualified)
+	})
+}
+
+func TestComputeTopUpPerShard(t *testing.T) {
+	t.Parallel()
+
+	createOwnersData := func() map[string]*OwnerAuctionData {
+		return map[string]*OwnerAuctionData{
+			"owner1": {
+				topUpPerNode: big.NewInt(100),
+				auctionList: []state.ValidatorInfoHandler{
+					&state.ValidatorInfo{PublicKey: []byte("pubKey1"), ShardId: 0},
+					&state.ValidatorInfo{PublicKey: []byte("pubKey2"), ShardId: 1},
+				},
+			},
+			"owner2": {
+				topUpPerNode: big.NewInt(50),
+				auctionList: []state.ValidatorInfoHandler{
+					&state.ValidatorInfo{PublicKey: []byte("pubKey3"), ShardId: 1},
+					&state.ValidatorInfo{PublicKey: []byte("pubKey4"), ShardId: core.MetachainShardId},
+				},
+			},
+			"owner3": {
+				auctionList: []state.ValidatorInfoHandler{
+					&state.ValidatorInfo{PublicKey: []byte("pubKey5"), ShardId: 0},
+				},
+			},
+		}
+	}
+
+	t.Run("empty owners data", func(t *testing.T) {
+		t.Parallel()
+
+		require.Empty(t, ComputeTopUpPerShard(make(map[string]*OwnerAuctionData), nil))
+	})
+	t.Run("nil resolver should use the nodes shards", func(t *testing.T) {
+		t.Parallel()
+
+		expected := map[uint32]*big.Int{
+			0:                     big.NewInt(100),
+			1:                     big.NewInt(150),
+			core.MetachainShardId: big.NewInt(50),
+		}
+		require.Equal(t, expected, ComputeTopUpPerShard(createOwnersData(), nil))
+	})
+	t.Run("custom resolver should group by the resolved shards", func(t *testing.T) {
+		t.Parallel()
+
+		ownersShards := map[string]uint32{
+			"owner1": 2,
+			"owner2": 0,
+			"owner3": 0,
+		}
+		resolveShard := func(ownerPubKey string, _ state.ValidatorInfoHandler) uint32 {
+			return ownersShards[ownerPubKey]
+		}
+
+		expected := map[uint32]*big.Int{
+			0: big.NewInt(100),
+			2: big.NewInt(200),
+		}
+		require.Equal(t, expected, ComputeTopUpPerShard(createOwnersData(), resolveShard))
+	})
+}
@@ -100,6 +100,19 @@ func (ald *auctionListDisplayer) DisplayOwnersData(ownersData map[string]*OwnerA
 	ald.tableDisplayer.DisplayTable(tableHeader, lines, "Initial nodes config in auction list")
 }
 
+// DisplayOwnerData will display the initial auction data only for the provided owner. Nothing is displayed if the
+// owner is not found in the provided owners data
+func (ald *auctionListDisplayer) DisplayOwnerData(ownerPubKey []byte, ownersData map[string]*OwnerAuctionData) {
+	owner, found := ownersData[string(ownerPubKey)]
+	if !found || owner == nil {
+		return
+	}
+
+	ald.DisplayOwnersData(map[string]*OwnerAuctionData{
+		string(ownerPubKey): owner,
+	})
+}
+
 func getPrettyValue(val *big.Int, denominator *big.Int) string {
 	first := big.NewInt(0).Div(val, denominator).String()
 	decimals := big.NewInt(0).Mod(val, denominator).String()
@@ -130,8 +143,14 @@ func (ald *auctionListDisplayer) getShortDisplayableBlsKeys(list []state.Validat
 	return pubKeys
 }
 
+// getShortKey returns the encoded public key, truncated to maxPubKeyDisplayableLen characters. The full key is returned
+// when the log level is Trace
 func (ald *auctionListDisplayer) getShortKey(pubKey []byte) string {
 	pubKeyHex := ald.validatorPubKeyConverter.SilentEncode(pubKey, log)
+	if log.GetLevel() == logger.LogTrace {
+		return pubKeyHex
+	}
+
 	displayablePubKey := pubKeyHex
 
 	pubKeyLen := len(displayablePubKey)
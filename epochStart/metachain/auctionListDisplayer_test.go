@@ -1,8 +1,10 @@
 package metachain
 
 import (
+	"bytes"
 	"math"
 	"math/big"
+	"strings"
 	"testing"
 
 	"github.com/multiversx/mx-chain-core-go/core"
@@ -106,6 +108,98 @@ func TestAuctionListDisplayer_DisplayOwnersData(t *testing.T) {
 	require.True(t, wasDisplayCalled)
 }
 
+func TestAuctionListDisplayer_DisplayOwnersDataInWriter(t *testing.T) {
+	_ = logger.SetLogLevel("*:DEBUG")
+	defer func() {
+		_ = logger.SetLogLevel("*:INFO")
+	}()
+
+	buff := &bytes.Buffer{}
+	args := createDisplayerArgs()
+	args.TableDisplayHandler, _ = NewTableDisplayerWithWriter(buff)
+	ald, _ := NewAuctionListDisplayer(args)
+
+	ownersData := map[string]*OwnerAuctionData{
+		"owner": {
+			numStakedNodes:  4,
+			numActiveNodes:  4,
+			numAuctionNodes: 1,
+			totalTopUp:      big.NewInt(100),
+			topUpPerNode:    big.NewInt(25),
+			auctionList:     []state.ValidatorInfoHandler{&state.ValidatorInfo{PublicKey: []byte("pubKey")}},
+		},
+	}
+
+	ald.DisplayOwnersData(ownersData)
+
+	output := buff.String()
+	require.True(t, strings.HasPrefix(output, "Initial nodes config in auction list\n"))
+	require.Contains(t, output, "Num auction nodes")
+	require.Contains(t, output, "100.0")
+	require.Contains(t, output, "25.0")
+}
+
+func TestAuctionListDisplayer_DisplayOwnerData(t *testing.T) {
+	_ = logger.SetLogLevel("*:DEBUG")
+	defer func() {
+		_ = logger.SetLogLevel("*:INFO")
+	}()
+
+	ownersData := map[string]*OwnerAuctionData{
+		"owner1": {
+			numStakedNodes:  4,
+			numActiveNodes:  4,
+			numAuctionNodes: 1,
+			totalTopUp:      big.NewInt(100),
+			topUpPerNode:    big.NewInt(25),
+			auctionList:     []state.ValidatorInfoHandler{&state.ValidatorInfo{PublicKey: []byte("pubKey1")}},
+		},
+		"owner2": {
+			numStakedNodes:  2,
+			numActiveNodes:  1,
+			numAuctionNodes: 1,
+			totalTopUp:      big.NewInt(50),
+			topUpPerNode:    big.NewInt(25),
+			auctionList:     []state.ValidatorInfoHandler{&state.ValidatorInfo{PublicKey: []byte("pubKey2")}},
+		},
+	}
+
+	t.Run("known owner should display only its row", func(t *testing.T) {
+		var displayedLines []*display.LineData
+		args := createDisplayerArgs()
+		args.AddressPubKeyConverter = &testscommon.PubkeyConverterStub{
+			SilentEncodeCalled: func(pkBytes []byte, log core.Logger) string {
+				return string(pkBytes) + "Encoded"
+			},
+		}
+		args.TableDisplayHandler = &testscommon.TableDisplayerMock{
+			DisplayTableCalled: func(tableHeader []string, lines []*display.LineData, message string) {
+				displayedLines = lines
+			},
+		}
+		ald, _ := NewAuctionListDisplayer(args)
+
+		ald.DisplayOwnerData([]byte("owner2"), ownersData)
+		require.Len(t, displayedLines, 1)
+		require.Equal(t, "owner2Encoded", displayedLines[0].Values[0])
+		require.Equal(t, "2", displayedLines[0].Values[1])
+	})
+	t.Run("unknown owner should not display anything", func(t *testing.T) {
+		args := createDisplayerArgs()
+		args.TableDisplayHandler = &testscommon.TableDisplayerMock{
+			DisplayTableCalled: func(tableHeader []string, lines []*display.LineData, message string) {
+				require.Fail(t, "should have not displayed the table")
+			},
+		}
+		ald, _ := NewAuctionListDisplayer(args)
+
+		require.NotPanics(t, func() {
+			ald.DisplayOwnerData([]byte("unknown owner"), ownersData)
+			ald.DisplayOwnerData([]byte("unknown owner"), nil)
+		})
+	})
+}
+
 func TestAuctionListDisplayer_DisplayOwnersSelectedNodes(t *testing.T) {
 	_ = logger.SetLogLevel("*:DEBUG")
 	defer func() {
@@ -286,3 +380,83 @@ func TestGetPrettyValue(t *testing.T) {
 	require.Equal(t, "1.00000", getPrettyValue(big.NewInt(0).Add(oneEGLD, big.NewInt(2222200000000)), denominationEGLD))
 	require.Equal(t, "1.00000", getPrettyValue(big.NewInt(0).Add(oneEGLD, big.NewInt(222220000000)), denominationEGLD))
 }
+
+func TestAuctionListDisplayer_LogLevelGating(t *testing.T) {
+	defer func() {
+		_ = logger.SetLogLevel("*:INFO")
+	}()
+
+	longPubKey := strings.Repeat("a", 2*maxPubKeyDisplayableLen)
+	createOwnersData := func() map[string]*OwnerAuctionData {
+		return map[string]*OwnerAuctionData{
+			"owner": {
+				numStakedNodes:           1,
+				numActiveNodes:           0,
+				numAuctionNodes:          1,
+				numQualifiedAuctionNodes: 1,
+				totalTopUp:               big.NewInt(100),
+				topUpPerNode:             big.NewInt(100),
+				qualifiedTopUpPerNode:    big.NewInt(100),
+				auctionList:              []state.ValidatorInfoHandler{&state.ValidatorInfo{PublicKey: []byte(longPubKey)}},
+			},
+		}
+	}
+	createDisplayer := func(displayedLines *[][]*display.LineData, numEncodeCalls *int) *auctionListDisplayer {
+		args := createDisplayerArgs()
+		args.ValidatorPubKeyConverter = &testscommon.PubkeyConverterStub{
+			SilentEncodeCalled: func(pkBytes []byte, log core.Logger) string {
+				*numEncodeCalls++
+				return string(pkBytes)
+			},
+		}
+		args.TableDisplayHandler = &testscommon.TableDisplayerMock{
+			DisplayTableCalled: func(tableHeader []string, lines []*display.LineData, message string) {
+				*displayedLines = append(*displayedLines, lines)
+			},
+		}
+		ald, _ := NewAuctionListDisplayer(args)
+
+		return ald
+	}
+	displayAll := func(ald *auctionListDisplayer) {
+		ownersData := createOwnersData()
+		ald.DisplayOwnersData(ownersData)
+		ald.DisplayOwnersSelectedNodes(ownersData)
+		ald.DisplayAuctionList(ownersData["owner"].auctionList, ownersData, 1)
+	}
+
+	t.Run("above debug should skip the display", func(t *testing.T) {
+		_ = logger.SetLogLevel("*:INFO")
+
+		displayedLines := make([][]*display.LineData, 0)
+		numEncodeCalls := 0
+		displayAll(createDisplayer(&displayedLines, &numEncodeCalls))
+
+		require.Empty(t, displayedLines)
+		require.Zero(t, numEncodeCalls)
+	})
+	t.Run("debug should display truncated keys", func(t *testing.T) {
+		_ = logger.SetLogLevel("*:DEBUG")
+
+		displayedLines := make([][]*display.LineData, 0)
+		numEncodeCalls := 0
+		displayAll(createDisplayer(&displayedLines, &numEncodeCalls))
+
+		require.Len(t, displayedLines, 3)
+		shortKey := longPubKey[:maxPubKeyDisplayableLen/2] + "..." + longPubKey[len(longPubKey)-maxPubKeyDisplayableLen/2:]
+		require.Equal(t, shortKey, displayedLines[0][0].Values[6])
+		require.Equal(t, shortKey, displayedLines[1][0].Values[8])
+	})
+	t.Run("trace should display full keys", func(t *testing.T) {
+		_ = logger.SetLogLevel("*:TRACE")
+
+		displayedLines := make([][]*display.LineData, 0)
+		numEncodeCalls := 0
+		displayAll(createDisplayer(&displayedLines, &numEncodeCalls))
+
+		require.Len(t, displayedLines, 3)
+		require.Equal(t, longPubKey, displayedLines[0][0].Values[6])
+		require.Equal(t, longPubKey, displayedLines[1][0].Values[8])
+		require.Equal(t, longPubKey, displayedLines[2][0].Values[1])
+	})
+}
@@ -0,0 +1,65 @@
+package metachain
+
+import (
+	"math/big"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data/batch"
+	"github.com/multiversx/mx-chain-core-go/hashing"
+	"github.com/multiversx/mx-chain-core-go/marshal"
+	"github.com/multiversx/mx-chain-go/epochStart"
+)
+
+// SelectedNodesHash returns a canonical hash of the nodes selected from the auction list in the last selection process,
+// which can be used to check that different nodes computed the same auction selection. It uses the marshaller and the
+// hasher provided on construction and errors if any of them was not provided
+func (als *auctionListSelector) SelectedNodesHash() ([]byte, error) {
+	als.mutSelectedNodes.RLock()
+	selectedNodes := make([]*selectedAuctionNode, len(als.selectedNodes))
+	copy(selectedNodes, als.selectedNodes)
+	als.mutSelectedNodes.RUnlock()
+
+	return computeSelectedAuctionNodesHash(selectedNodes, als.marshaller, als.hasher)
+}
+
+// computeSelectedAuctionNodesHash hashes the owner, public key and qualified top up of each provided node. The nodes
+// are sorted before hashing, so the same set of nodes will always produce the same hash
+func computeSelectedAuctionNodesHash(
+	nodes []*selectedAuctionNode,
+	marshaller marshal.Marshalizer,
+	hasher hashing.Hasher,
+) ([]byte, error) {
+	if check.IfNil(marshaller) {
+		return nil, epochStart.ErrNilMarshalizer
+	}
+	if check.IfNil(hasher) {
+		return nil, epochStart.ErrNilHasher
+	}
+
+	sortedNodes := make([]*selectedAuctionNode, len(nodes))
+	copy(sortedNodes, nodes)
+	sortSelectedAuctionNodes(sortedNodes)
+
+	selection := &batch.Batch{
+		Data: make([][]byte, 0, 3*len(sortedNodes)),
+	}
+	for _, node := range sortedNodes {
+		qualifiedTopUp := node.qualifiedTopUp
+		if qualifiedTopUp == nil {
+			qualifiedTopUp = big.NewInt(0)
+		}
+
+		selection.Data = append(selection.Data,
+			[]byte(node.owner),
+			node.validator.GetPublicKey(),
+			qualifiedTopUp.Bytes(),
+		)
+	}
+
+	selectionBytes, err := marshaller.Marshal(selection)
+	if err != nil {
+		return nil, err
+	}
+
+	return hasher.Compute(string(selectionBytes)), nil
+}
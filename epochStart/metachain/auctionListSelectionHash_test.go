@@ -0,0 +1,132 @@
+package metachain
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/hashing/sha256"
+	"github.com/multiversx/mx-chain-core-go/marshal"
+	"github.com/multiversx/mx-chain-go/epochStart"
+	"github.com/multiversx/mx-chain-go/state"
+	"github.com/stretchr/testify/require"
+)
+
+func createSelectedAuctionNodesForHash() []*selectedAuctionNode {
+	return []*selectedAuctionNode{
+		{owner: "owner1", validator: &state.ValidatorInfo{PublicKey: []byte("pubKey1")}, qualifiedTopUp: big.NewInt(1000)},
+		{owner: "owner2", validator: &state.ValidatorInfo{PublicKey: []byte("pubKey2")}, qualifiedTopUp: big.NewInt(500)},
+		{owner: "owner2", validator: &state.ValidatorInfo{PublicKey: []byte("pubKey3")}, qualifiedTopUp: big.NewInt(500)},
+		{owner: "owner3", validator: &state.ValidatorInfo{PublicKey: []byte("pubKey4")}, qualifiedTopUp: nil},
+	}
+}
+
+func TestComputeSelectedAuctionNodesHash(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil marshaller should error", func(t *testing.T) {
+		t.Parallel()
+
+		hash, err := computeSelectedAuctionNodesHash(createSelectedAuctionNodesForHash(), nil, sha256.NewSha256())
+		require.Nil(t, hash)
+		require.Equal(t, epochStart.ErrNilMarshalizer, err)
+	})
+	t.Run("nil hasher should error", func(t *testing.T) {
+		t.Parallel()
+
+		hash, err := computeSelectedAuctionNodesHash(createSelectedAuctionNodesForHash(), &marshal.GogoProtoMarshalizer{}, nil)
+		require.Nil(t, hash)
+		require.Equal(t, epochStart.ErrNilHasher, err)
+	})
+	t.Run("same selection should produce the same hash regardless of the order", func(t *testing.T) {
+		t.Parallel()
+
+		marshaller := &marshal.GogoProtoMarshalizer{}
+		hasher := sha256.NewSha256()
+
+		nodes := createSelectedAuctionNodesForHash()
+		expectedHash, err := computeSelectedAuctionNodesHash(nodes, marshaller, hasher)
+		require.Nil(t, err)
+		require.Len(t, expectedHash, hasher.Size())
+
+		for i := 0; i < 100; i++ {
+			shuffledNodes := createSelectedAuctionNodesForHash()
+			shuffledNodes[0], shuffledNodes[3] = shuffledNodes[3], shuffledNodes[0]
+			shuffledNodes[1], shuffledNodes[2] = shuffledNodes[2], shuffledNodes[1]
+
+			hash, errCompute := computeSelectedAuctionNodesHash(shuffledNodes, marshaller, hasher)
+			require.Nil(t, errCompute)
+			require.Equal(t, expectedHash, hash)
+		}
+
+		// the provided slice should not be altered
+		require.Equal(t, createSelectedAuctionNodesForHash(), nodes)
+	})
+	t.Run("different selection should produce a different hash", func(t *testing.T) {
+		t.Parallel()
+
+		marshaller := &marshal.GogoProtoMarshalizer{}
+		hasher := sha256.NewSha256()
+
+		hash1, err := computeSelectedAuctionNodesHash(createSelectedAuctionNodesForHash(), marshaller, hasher)
+		require.Nil(t, err)
+
+		nodes := createSelectedAuctionNodesForHash()
+		nodes[1].qualifiedTopUp = big.NewInt(501)
+		hash2, err := computeSelectedAuctionNodesHash(nodes, marshaller, hasher)
+		require.Nil(t, err)
+		require.NotEqual(t, hash1, hash2)
+
+		nodes = createSelectedAuctionNodesForHash()
+		nodes[2].owner = "owner3"
+		hash3, err := computeSelectedAuctionNodesHash(nodes, marshaller, hasher)
+		require.Nil(t, err)
+		require.NotEqual(t, hash1, hash3)
+	})
+}
+
+func TestAuctionListSelector_SelectedNodesHash(t *testing.T) {
+	t.Parallel()
+
+	t.Run("without marshaller should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createAuctionListSelectorArgs(nil)
+		args.Hasher = sha256.NewSha256()
+		als, err := NewAuctionListSelector(args)
+		require.Nil(t, err)
+
+		hash, err := als.SelectedNodesHash()
+		require.Nil(t, hash)
+		require.Equal(t, epochStart.ErrNilMarshalizer, err)
+	})
+	t.Run("without hasher should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createAuctionListSelectorArgs(nil)
+		args.Marshalizer = &marshal.GogoProtoMarshalizer{}
+		als, err := NewAuctionListSelector(args)
+		require.Nil(t, err)
+
+		hash, err := als.SelectedNodesHash()
+		require.Nil(t, hash)
+		require.Equal(t, epochStart.ErrNilHasher, err)
+	})
+	t.Run("with marshaller and hasher should work", func(t *testing.T) {
+		t.Parallel()
+
+		marshaller := &marshal.GogoProtoMarshalizer{}
+		hasher := sha256.NewSha256()
+		args := createAuctionListSelectorArgs(nil)
+		args.Marshalizer = marshaller
+		args.Hasher = hasher
+		als, err := NewAuctionListSelector(args)
+		require.Nil(t, err)
+
+		als.selectedNodes = createSelectedAuctionNodesForHash()
+		hash, err := als.SelectedNodesHash()
+		require.Nil(t, err)
+
+		expectedHash, _ := computeSelectedAuctionNodesHash(createSelectedAuctionNodesForHash(), marshaller, hasher)
+		require.Equal(t, expectedHash, hash)
+	})
+}
@@ -4,15 +4,19 @@ import (
 	"fmt"
 	"math/big"
 	"strings"
+	"sync"
 
 	"github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/hashing"
+	"github.com/multiversx/mx-chain-core-go/marshal"
 	"github.com/multiversx/mx-chain-go/common"
 	"github.com/multiversx/mx-chain-go/config"
 	"github.com/multiversx/mx-chain-go/epochStart"
 	"github.com/multiversx/mx-chain-go/process"
 	"github.com/multiversx/mx-chain-go/sharding"
 	"github.com/multiversx/mx-chain-go/state"
+	logger "github.com/multiversx/mx-chain-logger-go"
 )
 
 // OwnerAuctionData holds necessary auction data for an owner
@@ -41,6 +45,21 @@ type auctionListSelector struct {
 	nodesConfigProvider  epochStart.MaxNodesChangeConfigProvider
 	auctionListDisplayer AuctionListDisplayHandler
 	softAuctionConfig    *auctionConfig
+	marshaller           marshal.Marshalizer
+	hasher               hashing.Hasher
+
+	addressPubKeyConverter   core.PubkeyConverter
+	validatorPubKeyConverter core.PubkeyConverter
+
+	mutSelectedNodes sync.RWMutex
+	selectedNodes    []*selectedAuctionNode
+	ownersData       map[string]*OwnerAuctionData
+}
+
+type selectedAuctionNode struct {
+	owner          string
+	validator      state.ValidatorInfoHandler
+	qualifiedTopUp *big.Int
 }
 
 // AuctionListSelectorArgs is a struct placeholder for all arguments required to create an auctionListSelector
@@ -51,6 +70,13 @@ type AuctionListSelectorArgs struct {
 	AuctionListDisplayHandler    AuctionListDisplayHandler
 	SoftAuctionConfig            config.SoftAuctionConfig
 	Denomination                 int
+	// Marshalizer and Hasher are optional, being needed only by the hashing features (see SelectedNodesHash)
+	Marshalizer marshal.Marshalizer
+	Hasher      hashing.Hasher
+	// AddressPubKeyConverter and ValidatorPubKeyConverter are optional, being needed only when exporting the auction
+	// data for the API (see OwnersAuctionAPIData)
+	AddressPubKeyConverter   core.PubkeyConverter
+	ValidatorPubKeyConverter core.PubkeyConverter
 }
 
 // NewAuctionListSelector will create a new auctionListSelector, which handles selection of nodes from auction list based
@@ -79,6 +105,11 @@ func NewAuctionListSelector(args AuctionListSelectorArgs) (*auctionListSelector,
 		nodesConfigProvider:  args.MaxNodesChangeConfigProvider,
 		auctionListDisplayer: args.AuctionListDisplayHandler,
 		softAuctionConfig:    softAuctionConfig,
+		marshaller:           args.Marshalizer,
+		hasher:               args.Hasher,
+
+		addressPubKeyConverter:   args.AddressPubKeyConverter,
+		validatorPubKeyConverter: args.ValidatorPubKeyConverter,
 	}, nil
 }
 
@@ -341,6 +372,7 @@ func (als *auctionListSelector) sortAuctionList(
 	randomness []byte,
 ) error {
 	softAuctionNodesConfig := als.calcSoftAuctionNodesConfig(ownersData, numOfAvailableNodeSlots)
+	als.setOwnersData(ownersData, softAuctionNodesConfig)
 	selectedNodes := als.selectNodes(softAuctionNodesConfig, numOfAvailableNodeSlots, randomness)
 	return markAuctionNodesAsSelected(selectedNodes, validatorsInfoMap)
 }
@@ -373,11 +405,54 @@ func (als *auctionListSelector) calcSoftAuctionNodesConfig(
 		maxNumberOfIterationsReached = iterationNumber >= als.softAuctionConfig.maxNumberOfIterations
 	}
 
+	als.displayMinRequiredTopUp(topUp, minTopUp)
+	return previousConfig
+}
+
+func (als *auctionListSelector) displayMinRequiredTopUp(topUp *big.Int, startTopUp *big.Int) {
+	if log.GetLevel() > logger.LogDebug {
+		return
+	}
+
+	minRequiredTopUp, numIterations, err := ComputeMinRequiredTopUp(topUp, startTopUp, als.softAuctionConfig.step)
+	if err != nil {
+		log.Warn("auctionListSelector: could not compute min required top up", "error", err)
+		return
+	}
+
 	log.Debug("auctionListSelector: found min required",
-		"topUp", getPrettyValue(topUp, als.softAuctionConfig.denominator),
-		"after num of iterations", iterationNumber,
+		"topUp", getPrettyValue(minRequiredTopUp, als.softAuctionConfig.denominator),
+		"after num of iterations", numIterations.String(),
 	)
-	return previousConfig
+}
+
+// ComputeMinRequiredTopUp computes the min required top up found by the soft auction, starting from the top up at which
+// the iteration stopped. If the iteration went past the min top up, the required top up is the previous step, the one
+// for which enough nodes still qualified. It returns the min required top up and the number of iterations needed to reach it
+func ComputeMinRequiredTopUp(topUp *big.Int, min *big.Int, step *big.Int) (*big.Int, *big.Int, error) {
+	if topUp == nil || min == nil || step == nil {
+		return nil, nil, errNilTopUpValue
+	}
+	if step.Sign() <= 0 {
+		return nil, nil, fmt.Errorf("%w: %s", errInvalidTopUpStep, step.String())
+	}
+	if min.Cmp(topUp) > 0 {
+		return nil, nil, fmt.Errorf("%w: min top up %s, top up %s", errMinTopUpGreaterThanTopUp, min.String(), topUp.String())
+	}
+
+	minRequiredTopUp := big.NewInt(0).Set(topUp)
+	if minRequiredTopUp.Cmp(min) > 0 {
+		minRequiredTopUp.Sub(minRequiredTopUp, step)
+	}
+	if minRequiredTopUp.Cmp(min) < 0 {
+		minRequiredTopUp.Set(min)
+	}
+
+	iteratedValues := big.NewInt(0).Sub(minRequiredTopUp, min)
+	numIterations := big.NewInt(0).Div(iteratedValues, step)
+	numIterations.Add(numIterations, big.NewInt(1))
+
+	return minRequiredTopUp, numIterations, nil
 }
 
 func (als *auctionListSelector) getMinMaxPossibleTopUp(ownersData map[string]*OwnerAuctionData) (*big.Int, *big.Int) {
@@ -470,6 +545,44 @@ func markAuctionNodesAsSelected(
 	return nil
 }
 
+func (als *auctionListSelector) setSelectedNodes(
+	selectedNodes []state.ValidatorInfoHandler,
+	ownersData map[string]*OwnerAuctionData,
+	validatorTopUpMap map[string]*big.Int,
+) {
+	blsKeysOwnerMap := getBlsKeyOwnerMap(ownersData)
+	nodes := make([]*selectedAuctionNode, 0, len(selectedNodes))
+	for _, validator := range selectedNodes {
+		pubKey := string(validator.GetPublicKey())
+		nodes = append(nodes, &selectedAuctionNode{
+			owner:          blsKeysOwnerMap[pubKey],
+			validator:      validator,
+			qualifiedTopUp: validatorTopUpMap[pubKey],
+		})
+	}
+
+	sortSelectedAuctionNodes(nodes)
+
+	als.mutSelectedNodes.Lock()
+	als.selectedNodes = nodes
+	als.mutSelectedNodes.Unlock()
+}
+
+// SelectedNodesSorted returns the nodes selected from the auction list in the last selection process. The nodes are
+// sorted by their qualified top up in descending order and, in case of equal top up, by their public key in ascending
+// order, so the output is the same across runs, regardless of the randomness used during selection
+func (als *auctionListSelector) SelectedNodesSorted() []state.ValidatorInfoHandler {
+	als.mutSelectedNodes.RLock()
+	defer als.mutSelectedNodes.RUnlock()
+
+	selectedNodes := make([]state.ValidatorInfoHandler, 0, len(als.selectedNodes))
+	for _, node := range als.selectedNodes {
+		selectedNodes = append(selectedNodes, node.validator)
+	}
+
+	return selectedNodes
+}
+
 // IsInterfaceNil checks if the underlying pointer is nil
 func (als *auctionListSelector) IsInterfaceNil() bool {
 	return als == nil
@@ -893,3 +893,75 @@ func TestAuctionListSelector_calcSoftAuctionNodesConfig(t *testing.T) {
 	selectedNodes = als.selectNodes(softAuctionConfig, 1, randomness)
 	require.Equal(t, []state.ValidatorInfoHandler{v5}, selectedNodes)
 }
+
+func TestComputeMinRequiredTopUp(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil values should error", func(t *testing.T) {
+		t.Parallel()
+
+		minTopUp, numIterations, err := ComputeMinRequiredTopUp(nil, big.NewInt(1), big.NewInt(1))
+		require.Nil(t, minTopUp)
+		require.Nil(t, numIterations)
+		require.Equal(t, errNilTopUpValue, err)
+
+		_, _, err = ComputeMinRequiredTopUp(big.NewInt(1), nil, big.NewInt(1))
+		require.Equal(t, errNilTopUpValue, err)
+
+		_, _, err = ComputeMinRequiredTopUp(big.NewInt(1), big.NewInt(1), nil)
+		require.Equal(t, errNilTopUpValue, err)
+	})
+	t.Run("non-positive step should error", func(t *testing.T) {
+		t.Parallel()
+
+		minTopUp, numIterations, err := ComputeMinRequiredTopUp(big.NewInt(10), big.NewInt(1), big.NewInt(0))
+		require.Nil(t, minTopUp)
+		require.Nil(t, numIterations)
+		require.ErrorIs(t, err, errInvalidTopUpStep)
+
+		_, _, err = ComputeMinRequiredTopUp(big.NewInt(10), big.NewInt(1), big.NewInt(-1))
+		require.ErrorIs(t, err, errInvalidTopUpStep)
+	})
+	t.Run("min greater than top up should error", func(t *testing.T) {
+		t.Parallel()
+
+		minTopUp, numIterations, err := ComputeMinRequiredTopUp(big.NewInt(10), big.NewInt(11), big.NewInt(1))
+		require.Nil(t, minTopUp)
+		require.Nil(t, numIterations)
+		require.ErrorIs(t, err, errMinTopUpGreaterThanTopUp)
+	})
+	t.Run("top up equal to min should return min", func(t *testing.T) {
+		t.Parallel()
+
+		minTopUp, numIterations, err := ComputeMinRequiredTopUp(big.NewInt(1000), big.NewInt(1000), big.NewInt(10))
+		require.Nil(t, err)
+		require.Equal(t, big.NewInt(1000), minTopUp)
+		require.Equal(t, big.NewInt(1), numIterations)
+	})
+	t.Run("top up one step above min should return min", func(t *testing.T) {
+		t.Parallel()
+
+		minTopUp, numIterations, err := ComputeMinRequiredTopUp(big.NewInt(1010), big.NewInt(1000), big.NewInt(10))
+		require.Nil(t, err)
+		require.Equal(t, big.NewInt(1000), minTopUp)
+		require.Equal(t, big.NewInt(1), numIterations)
+	})
+	t.Run("top up several steps above min", func(t *testing.T) {
+		t.Parallel()
+
+		topUp := big.NewInt(1050)
+		minTopUp, numIterations, err := ComputeMinRequiredTopUp(topUp, big.NewInt(1000), big.NewInt(10))
+		require.Nil(t, err)
+		require.Equal(t, big.NewInt(1040), minTopUp)
+		require.Equal(t, big.NewInt(5), numIterations)
+		require.Equal(t, big.NewInt(1050), topUp)
+	})
+	t.Run("top up less than one step above min should return min", func(t *testing.T) {
+		t.Parallel()
+
+		minTopUp, numIterations, err := ComputeMinRequiredTopUp(big.NewInt(1005), big.NewInt(1000), big.NewInt(10))
+		require.Nil(t, err)
+		require.Equal(t, big.NewInt(1000), minTopUp)
+		require.Equal(t, big.NewInt(1), numIterations)
+	})
+}
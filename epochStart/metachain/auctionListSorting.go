@@ -28,6 +28,7 @@ func (als *auctionListSelector) selectNodes(
 	als.auctionListDisplayer.DisplayOwnersSelectedNodes(ownersData)
 	sortValidators(selectedFromAuction, validatorTopUpMap, normRand)
 	als.auctionListDisplayer.DisplayAuctionList(selectedFromAuction, ownersData, numAvailableSlots)
+	als.setSelectedNodes(selectedFromAuction[:numAvailableSlots], ownersData, validatorTopUpMap)
 
 	return selectedFromAuction[:numAvailableSlots]
 }
@@ -102,3 +103,25 @@ func compareByXORWithRandomness(pubKey1, pubKey2, randomness []byte) bool {
 
 	return bytes.Compare(key1Xor, key2Xor) == 1
 }
+
+func sortSelectedAuctionNodes(list []*selectedAuctionNode) {
+	sort.SliceStable(list, func(i, j int) bool {
+		topUpComparison := compareTopUps(list[i].qualifiedTopUp, list[j].qualifiedTopUp)
+		if topUpComparison == 0 {
+			return bytes.Compare(list[i].validator.GetPublicKey(), list[j].validator.GetPublicKey()) < 0
+		}
+
+		return topUpComparison > 0
+	})
+}
+
+func compareTopUps(topUp1 *big.Int, topUp2 *big.Int) int {
+	if topUp1 == nil {
+		topUp1 = big.NewInt(0)
+	}
+	if topUp2 == nil {
+		topUp2 = big.NewInt(0)
+	}
+
+	return topUp1.Cmp(topUp2)
+}
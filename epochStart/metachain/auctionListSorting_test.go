@@ -1,8 +1,10 @@
 package metachain
 
 import (
+	"math/big"
 	"testing"
 
+	"github.com/multiversx/mx-chain-go/state"
 	"github.com/stretchr/testify/require"
 )
 
@@ -37,3 +39,36 @@ func TestCalcNormalizedRandomness(t *testing.T) {
 		require.Empty(t, result)
 	})
 }
+
+func TestAuctionListSelector_SelectedNodesSorted(t *testing.T) {
+	t.Parallel()
+
+	v1 := &state.ValidatorInfo{PublicKey: []byte("pubKey1")}
+	v2 := &state.ValidatorInfo{PublicKey: []byte("pubKey2")}
+	v3 := &state.ValidatorInfo{PublicKey: []byte("pubKey3")}
+	v4 := &state.ValidatorInfo{PublicKey: []byte("pubKey4")}
+
+	ownersData := map[string]*OwnerAuctionData{
+		"owner1": {auctionList: []state.ValidatorInfoHandler{v1, v3}},
+		"owner2": {auctionList: []state.ValidatorInfoHandler{v2, v4}},
+	}
+	validatorTopUpMap := map[string]*big.Int{
+		"pubKey1": big.NewInt(100),
+		"pubKey2": big.NewInt(200),
+		"pubKey3": big.NewInt(100),
+		"pubKey4": big.NewInt(100),
+	}
+	expectedOrder := []state.ValidatorInfoHandler{v2, v1, v3, v4}
+
+	als, _ := NewAuctionListSelector(createAuctionListSelectorArgs(nil))
+	require.Empty(t, als.SelectedNodesSorted())
+
+	als.setSelectedNodes([]state.ValidatorInfoHandler{v4, v3, v1, v2}, ownersData, validatorTopUpMap)
+	require.Equal(t, expectedOrder, als.SelectedNodesSorted())
+
+	als.setSelectedNodes([]state.ValidatorInfoHandler{v1, v2, v3, v4}, ownersData, validatorTopUpMap)
+	require.Equal(t, expectedOrder, als.SelectedNodesSorted())
+
+	als.setSelectedNodes([]state.ValidatorInfoHandler{v3, v4, v2, v1}, ownersData, validatorTopUpMap)
+	require.Equal(t, expectedOrder, als.SelectedNodesSorted())
+}
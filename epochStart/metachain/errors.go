@@ -9,3 +9,11 @@ var errCannotComputeDenominator = errors.New("cannot compute denominator value")
 var errNilAuctionListDisplayHandler = errors.New("nil auction list display handler provided")
 
 var errNilTableDisplayHandler = errors.New("nil table display handler provided")
+
+var errNilWriter = errors.New("nil writer provided")
+
+var errNilTopUpValue = errors.New("nil top up value provided")
+
+var errInvalidTopUpStep = errors.New("invalid top up step provided")
+
+var errMinTopUpGreaterThanTopUp = errors.New("min top up is greater than the top up")
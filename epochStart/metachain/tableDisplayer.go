@@ -2,11 +2,13 @@ package metachain
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/multiversx/mx-chain-core-go/display"
 )
 
 type tableDisplayer struct {
+	writer io.Writer
 }
 
 // NewTableDisplayer will create a component able to display tables in logger
@@ -14,7 +16,19 @@ func NewTableDisplayer() *tableDisplayer {
 	return &tableDisplayer{}
 }
 
-// DisplayTable will display a table in the log
+// NewTableDisplayerWithWriter will create a component able to display tables in the provided writer, instead of logger.
+// Useful for routing the output to a dedicated audit log
+func NewTableDisplayerWithWriter(writer io.Writer) (*tableDisplayer, error) {
+	if writer == nil {
+		return nil, errNilWriter
+	}
+
+	return &tableDisplayer{
+		writer: writer,
+	}, nil
+}
+
+// DisplayTable will display a table in the log or in the writer, if one was provided
 func (tb *tableDisplayer) DisplayTable(tableHeader []string, lines []*display.LineData, message string) {
 	table, err := display.CreateTableString(tableHeader, lines)
 	if err != nil {
@@ -23,7 +37,15 @@ func (tb *tableDisplayer) DisplayTable(tableHeader []string, lines []*display.Li
 	}
 
 	msg := fmt.Sprintf("%s\n%s", message, table)
-	log.Debug(msg)
+	if tb.writer == nil {
+		log.Debug(msg)
+		return
+	}
+
+	_, err = fmt.Fprintln(tb.writer, msg)
+	if err != nil {
+		log.Warn("could not write table", "message", message, "error", err)
+	}
 }
 
 // IsInterfaceNil checks if the underlying pointer is nil
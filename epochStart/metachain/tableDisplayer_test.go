@@ -0,0 +1,38 @@
+package metachain
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/display"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTableDisplayerWithWriter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil writer should error", func(t *testing.T) {
+		t.Parallel()
+
+		td, err := NewTableDisplayerWithWriter(nil)
+		require.Nil(t, td)
+		require.Equal(t, errNilWriter, err)
+	})
+	t.Run("should write the table in the provided writer", func(t *testing.T) {
+		t.Parallel()
+
+		buff := &bytes.Buffer{}
+		td, err := NewTableDisplayerWithWriter(buff)
+		require.Nil(t, err)
+		require.False(t, td.IsInterfaceNil())
+
+		lines := []*display.LineData{
+			display.NewLineData(false, []string{"owner1", "3"}),
+			display.NewLineData(false, []string{"owner2", "5"}),
+		}
+		td.DisplayTable([]string{"Owner", "Num nodes"}, lines, "auction owners")
+
+		expectedTable, _ := display.CreateTableString([]string{"Owner", "Num nodes"}, lines)
+		require.Equal(t, "auction owners\n"+expectedTable+"\n", buff.String())
+	})
+}
@@ -426,6 +426,11 @@ func (inf *initialNodeFacade) GetSCRsByTxHash(_ string, _ string) ([]*transactio
 	return nil, errNodeStarting
 }
 
+// GetTransactionDetails returns nil and error
+func (inf *initialNodeFacade) GetTransactionDetails(_ string, _ string) (*common.ApiTransactionDetails, error) {
+	return nil, errNodeStarting
+}
+
 // GetManagedKeysCount returns 0
 func (inf *initialNodeFacade) GetManagedKeysCount() int {
 	return 0
@@ -51,6 +51,16 @@ type ApiResolverStub struct {
 	GetWaitingManagedKeysCalled                 func() ([]string, error)
 	GetWaitingEpochsLeftForPublicKeyCalled      func(publicKey string) (uint32, error)
 	GetSCRsByTxHashCalled                       func(txHash string, scrHash string) ([]*transaction.ApiSmartContractResult, error)
+	GetTransactionDetailsCalled                 func(txHash string, originalSender string) (*common.ApiTransactionDetails, error)
+}
+
+// GetTransactionDetails -
+func (ars *ApiResolverStub) GetTransactionDetails(txHash string, originalSender string) (*common.ApiTransactionDetails, error) {
+	if ars.GetTransactionDetailsCalled != nil {
+		return ars.GetTransactionDetailsCalled(txHash, originalSender)
+	}
+
+	return nil, nil
 }
 
 // GetSCRsByTxHash -
@@ -309,6 +309,11 @@ func (nf *nodeFacade) GetSCRsByTxHash(txHash string, scrHash string) ([]*transac
 	return nf.apiResolver.GetSCRsByTxHash(txHash, scrHash)
 }
 
+// GetTransactionDetails will return the transaction with the provided hash, together with the details of its smart contract results
+func (nf *nodeFacade) GetTransactionDetails(txHash string, originalSender string) (*common.ApiTransactionDetails, error) {
+	return nf.apiResolver.GetTransactionDetails(txHash, originalSender)
+}
+
 // GetTransactionsPool will return a structure containing the transactions pool that is to be returned on API calls
 func (nf *nodeFacade) GetTransactionsPool(fields string) (*common.TransactionsPoolAPIResponse, error) {
 	return nf.apiResolver.GetTransactionsPool(fields)
@@ -499,6 +499,7 @@ type EconomicsHandler interface {
 // LogsFacade defines the interface of a logs facade
 type LogsFacade interface {
 	GetLog(logKey []byte, epoch uint32) (*transaction.ApiLogs, error)
+	GetLogDetails(logKey []byte, epoch uint32) (*common.ApiLogsDetails, error)
 	IncludeLogsInTransactions(txs []*transaction.ApiTransactionResult, logsKeys [][]byte, epoch uint32) error
 	IsInterfaceNil() bool
 }
@@ -913,6 +913,8 @@ func (pcf *processComponentsFactory) newMetaBlockProcessor(
 		AuctionListDisplayHandler:    auctionListDisplayer,
 		SoftAuctionConfig:            pcf.systemSCConfig.SoftAuctionConfig,
 		Denomination:                 pcf.economicsConfig.GlobalSettings.Denomination,
+		Marshalizer:                  pcf.coreData.InternalMarshalizer(),
+		Hasher:                       pcf.coreData.Hasher(),
 	}
 	auctionListSelector, err := metachainEpochStart.NewAuctionListSelector(argsAuctionListSelector)
 	if err != nil {
@@ -930,6 +932,8 @@ func (pcf *processComponentsFactory) newMetaBlockProcessor(
 		SoftAuctionConfig:            pcf.systemSCConfig.SoftAuctionConfig,
 		Denomination:                 pcf.economicsConfig.GlobalSettings.Denomination,
 		AuctionListDisplayHandler:    factoryDisabled.NewDisabledAuctionListDisplayer(),
+		AddressPubKeyConverter:       pcf.coreData.AddressPubKeyConverter(),
+		ValidatorPubKeyConverter:     pcf.coreData.ValidatorPubKeyConverter(),
 	}
 	auctionListSelectorAPI, err := metachainEpochStart.NewAuctionListSelector(argsAuctionListSelectorAPI)
 	if err != nil {
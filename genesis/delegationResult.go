@@ -0,0 +1,70 @@
+package genesis
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// DelegationResult represents the DTO that contains the delegation results metrics
+type DelegationResult struct {
+	NumTotalStaked    int `json:"numTotalStaked"`
+	NumTotalDelegated int `json:"numTotalDelegated"`
+	// NumVerifyQueries is the number of delegators and delegated nodes checked while verifying the delegation
+	// contracts, each of them being checked with one SC query. The retries of a query and the total stake queries are
+	// not counted
+	NumVerifyQueries int `json:"numVerifyQueries"`
+	// HadContracts is true if the shard had at least one delegation contract to process, so the delegation was
+	// executed. When the empty contracts are skipped, only the contracts with delegated nodes or delegators are counted.
+	// It allows telling a shard without any delegation work from one where it ran
+	HadContracts bool                       `json:"hadContracts"`
+	Contracts    []DelegationContractResult `json:"contracts,omitempty"`
+	// ContractsWithoutDelegators and ContractsWithoutNodes hold the addresses of the executed delegation contracts
+	// which do not have any delegator or, respectively, any delegated node, as they are likely misconfigured
+	ContractsWithoutDelegators []string `json:"contractsWithoutDelegators,omitempty"`
+	ContractsWithoutNodes      []string `json:"contractsWithoutNodes,omitempty"`
+}
+
+// DelegationContractResult represents the DTO that contains the delegation results metrics of one delegation contract
+type DelegationContractResult struct {
+	Address      string `json:"address"`
+	Owner        string `json:"owner"`
+	NumDelegated int    `json:"numDelegated"`
+	NumStaked    int    `json:"numStaked"`
+}
+
+// DelegationNodesCheck and DelegationDelegatorsCheck are the names of the post import checks of a delegation contract
+const (
+	DelegationNodesCheck      = "nodes"
+	DelegationDelegatorsCheck = "delegators"
+)
+
+// DelegationDiscrepancy represents the DTO that contains a mismatch, found after the delegation was executed, between
+// what was sent to a delegation contract and what the contract reports. Reported is a base 10 number
+type DelegationDiscrepancy struct {
+	Address  string `json:"address"`
+	Owner    string `json:"owner"`
+	Check    string `json:"check"`
+	Sent     int    `json:"sent"`
+	Reported string `json:"reported"`
+}
+
+// MarshalJSON returns the JSON representation of the delegation result. The contracts are sorted by their address, so
+// the same result always produces the same output and the results of different runs can be compared
+func (dr DelegationResult) MarshalJSON() ([]byte, error) {
+	// the alias type does not have the MarshalJSON method, avoiding the infinite recursion
+	type delegationResultAlias DelegationResult
+
+	sortedContracts := make([]DelegationContractResult, len(dr.Contracts))
+	copy(sortedContracts, dr.Contracts)
+	sort.SliceStable(sortedContracts, func(i, j int) bool {
+		return sortedContracts[i].Address < sortedContracts[j].Address
+	})
+
+	alias := delegationResultAlias(dr)
+	alias.Contracts = sortedContracts
+	if len(dr.Contracts) == 0 {
+		alias.Contracts = nil
+	}
+
+	return json.Marshal(alias)
+}
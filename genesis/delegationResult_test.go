@@ -0,0 +1,57 @@
+package genesis
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDelegationResult_MarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	t.Run("without contracts should omit the contracts field", func(t *testing.T) {
+		t.Parallel()
+
+		dr := DelegationResult{
+			Contracts: make([]DelegationContractResult, 0),
+		}
+		buff, err := json.Marshal(dr)
+		require.Nil(t, err)
+		assert.Equal(t, `{"numTotalStaked":0,"numTotalDelegated":0,"numVerifyQueries":0,"hadContracts":false}`, string(buff))
+	})
+	t.Run("should sort the contracts by address", func(t *testing.T) {
+		t.Parallel()
+
+		dr := DelegationResult{
+			NumTotalStaked:    3,
+			NumTotalDelegated: 4,
+			NumVerifyQueries:  7,
+			HadContracts:      true,
+			Contracts: []DelegationContractResult{
+				{Address: "erd1b", Owner: "owner2", NumDelegated: 1, NumStaked: 1},
+				{Address: "erd1a", Owner: "owner1", NumDelegated: 3, NumStaked: 2},
+			},
+		}
+		buff, err := json.Marshal(dr)
+		require.Nil(t, err)
+
+		expectedJSON := `{"numTotalStaked":3,"numTotalDelegated":4,"numVerifyQueries":7,"hadContracts":true,"contracts":[` +
+			`{"address":"erd1a","owner":"owner1","numDelegated":3,"numStaked":2},` +
+			`{"address":"erd1b","owner":"owner2","numDelegated":1,"numStaked":1}]}`
+		assert.Equal(t, expectedJSON, string(buff))
+
+		// the provided result should not be altered
+		assert.Equal(t, "erd1b", dr.Contracts[0].Address)
+
+		recovered := DelegationResult{}
+		err = json.Unmarshal(buff, &recovered)
+		require.Nil(t, err)
+		assert.Equal(t, dr.NumTotalStaked, recovered.NumTotalStaked)
+		assert.Equal(t, dr.NumTotalDelegated, recovered.NumTotalDelegated)
+		assert.Equal(t, dr.NumVerifyQueries, recovered.NumVerifyQueries)
+		assert.True(t, recovered.HadContracts)
+		assert.Equal(t, []DelegationContractResult{dr.Contracts[1], dr.Contracts[0]}, recovered.Contracts)
+	})
+}
@@ -175,3 +175,36 @@ var ErrNilRoundConfig = errors.New("nil round config")
 
 // ErrNilGasSchedule signals that an operation has been attempted with a nil gas schedule
 var ErrNilGasSchedule = errors.New("nil gas schedule")
+
+// ErrInvalidQueryTimeout signals that an invalid query timeout was provided
+var ErrInvalidQueryTimeout = errors.New("invalid query timeout")
+
+// ErrInvalidQueryRetryBackoff signals that an invalid query retry backoff was provided
+var ErrInvalidQueryRetryBackoff = errors.New("invalid query retry backoff")
+
+// ErrQueryTimeout signals that a query did not finish in the allowed time
+var ErrQueryTimeout = errors.New("query timeout")
+
+// ErrNonceNotIncreased signals that the nonce did not increase between consecutive transactions of the same sender
+var ErrNonceNotIncreased = errors.New("nonce not increased")
+
+// ErrInvalidStakeData signals that the stake transaction data is not valid
+var ErrInvalidStakeData = errors.New("invalid stake data")
+
+// ErrDuplicatedDelegatedNode signals that the same node was delegated more than one time
+var ErrDuplicatedDelegatedNode = errors.New("duplicated delegated node")
+
+// ErrTxDataTooLong signals that the data of a genesis transaction exceeds the maximum allowed length
+var ErrTxDataTooLong = errors.New("transaction data too long")
+
+// ErrDelegationContractNotFound signals that the provided address is not of a delegation contract from the current shard
+var ErrDelegationContractNotFound = errors.New("delegation contract not found on the current shard")
+
+// ErrDuplicatedDelegationContract signals that the same delegation contract was provided more than one time
+var ErrDuplicatedDelegationContract = errors.New("duplicated delegation contract")
+
+// ErrEmptyNodePriceFunction signals that the node price check was activated without providing the node price function
+var ErrEmptyNodePriceFunction = errors.New("empty node price function")
+
+// ErrNodePriceMismatch signals that the delegation node price differs from the one configured in the staking system
+var ErrNodePriceMismatch = errors.New("node price mismatch")
@@ -21,12 +21,6 @@ const DNSType = "dns"
 // InitialDNSAddress defines the initial address from where the DNS contracts are deployed
 var InitialDNSAddress = bytes.Repeat([]byte{1}, 32)
 
-// DelegationResult represents the DTO that contains the delegation results metrics
-type DelegationResult struct {
-	NumTotalStaked    int
-	NumTotalDelegated int
-}
-
 // AccountsParser contains the parsed genesis json file and has some functionality regarding processed data
 type AccountsParser interface {
 	InitialAccountsSplitOnAddressesShards(shardCoordinator sharding.Coordinator) (map[uint32][]InitialAccountHandler, error)
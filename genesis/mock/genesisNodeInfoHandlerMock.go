@@ -6,6 +6,7 @@ type GenesisNodeInfoHandlerMock struct {
 	AddressBytesValue  []byte
 	PubKeyBytesValue   []byte
 	InitialRatingValue uint32
+	SignatureValue     []byte
 }
 
 // GetInitialRating -
@@ -33,6 +34,11 @@ func (gnihm *GenesisNodeInfoHandlerMock) PubKeyBytes() []byte {
 	return gnihm.PubKeyBytesValue
 }
 
+// Signature -
+func (gnihm *GenesisNodeInfoHandlerMock) Signature() []byte {
+	return gnihm.SignatureValue
+}
+
 // IsInterfaceNil -
 func (gnihm *GenesisNodeInfoHandlerMock) IsInterfaceNil() bool {
 	return gnihm == nil
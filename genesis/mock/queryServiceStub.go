@@ -9,9 +9,10 @@ import (
 
 // QueryServiceStub -
 type QueryServiceStub struct {
-	ComputeScCallGasLimitCalled func(tx *transaction.Transaction) (uint64, error)
-	ExecuteQueryCalled          func(query *process.SCQuery) (*vmcommon.VMOutput, common.BlockInfo, error)
-	CloseCalled                 func() error
+	ComputeScCallGasLimitCalled     func(tx *transaction.Transaction) (uint64, error)
+	ExecuteQueryCalled              func(query *process.SCQuery) (*vmcommon.VMOutput, common.BlockInfo, error)
+	CloseCalled                     func() error
+	SupportsConcurrentQueriesCalled func() bool
 }
 
 // ComputeScCallGasLimit -
@@ -32,6 +33,15 @@ func (qss *QueryServiceStub) ExecuteQuery(query *process.SCQuery) (*vmcommon.VMO
 	return &vmcommon.VMOutput{}, nil, nil
 }
 
+// SupportsConcurrentQueries -
+func (qss *QueryServiceStub) SupportsConcurrentQueries() bool {
+	if qss.SupportsConcurrentQueriesCalled != nil {
+		return qss.SupportsConcurrentQueriesCalled()
+	}
+
+	return false
+}
+
 // Close -
 func (qss *QueryServiceStub) Close() error {
 	if qss.CloseCalled != nil {
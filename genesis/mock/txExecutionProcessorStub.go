@@ -9,11 +9,14 @@ import (
 
 // TxExecutionProcessorStub -
 type TxExecutionProcessorStub struct {
-	ExecuteTransactionCalled func(nonce uint64, sndAddr []byte, rcvAddress []byte, value *big.Int, data []byte) error
-	AccountExistsCalled      func(address []byte) bool
-	GetNonceCalled           func(senderBytes []byte) (uint64, error)
-	AddBalanceCalled         func(senderBytes []byte, value *big.Int) error
-	AddNonceCalled           func(senderBytes []byte, nonce uint64) error
+	ExecuteTransactionCalled         func(nonce uint64, sndAddr []byte, rcvAddress []byte, value *big.Int, data []byte) error
+	AccountExistsCalled              func(address []byte) bool
+	GetNonceCalled                   func(senderBytes []byte) (uint64, error)
+	AddBalanceCalled                 func(senderBytes []byte, value *big.Int) error
+	AddNonceCalled                   func(senderBytes []byte, nonce uint64) error
+	GetExecutedTransactionsCalled    func() []data.TransactionHandler
+	NumExecutedTransactionsCalled    func() int
+	RevertExecutedTransactionsCalled func(numTransactions int)
 }
 
 // ExecuteTransaction -
@@ -63,9 +66,29 @@ func (teps *TxExecutionProcessorStub) AddNonce(senderBytes []byte, nonce uint64)
 
 // GetExecutedTransactions -
 func (teps *TxExecutionProcessorStub) GetExecutedTransactions() []data.TransactionHandler {
+	if teps.GetExecutedTransactionsCalled != nil {
+		return teps.GetExecutedTransactionsCalled()
+	}
+
 	return nil
 }
 
+// NumExecutedTransactions -
+func (teps *TxExecutionProcessorStub) NumExecutedTransactions() int {
+	if teps.NumExecutedTransactionsCalled != nil {
+		return teps.NumExecutedTransactionsCalled()
+	}
+
+	return 0
+}
+
+// RevertExecutedTransactions -
+func (teps *TxExecutionProcessorStub) RevertExecutedTransactions(numTransactions int) {
+	if teps.RevertExecutedTransactionsCalled != nil {
+		teps.RevertExecutedTransactionsCalled(numTransactions)
+	}
+}
+
 // IsInterfaceNil -
 func (teps *TxExecutionProcessorStub) IsInterfaceNil() bool {
 	return teps == nil
@@ -74,6 +74,13 @@ type ArgsGenesisBlockCreator struct {
 
 	GenesisNodePrice *big.Int
 	GenesisString    string
+	// DelegationTotalStakeFunction is the view function used to verify the total staked value reported by each genesis
+	// delegation contract, summing all its returned elements. When empty, the total staked value is not verified
+	DelegationTotalStakeFunction string
+	// DelegationTransactionalMode makes the genesis delegation revert the accounts state and the executed transactions
+	// if any of its phases fails. It keeps all the journal entries created by the delegation in memory until the
+	// accounts are committed, so it is off by default
+	DelegationTransactionalMode bool
 
 	// created components
 	importHandler          update.ImportHandler
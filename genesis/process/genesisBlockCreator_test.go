@@ -572,6 +572,70 @@ func TestGenesisBlockCreator_CreateGenesisBlocksJustDelegationShouldWorkAndDNS(t
 	assert.Equal(t, 3, len(blocks))
 }
 
+func TestGenesisBlockCreator_CreateGenesisBlocksDelegationTotalStakeVerification(t *testing.T) {
+	scAddressBytes, _ := hex.DecodeString("00000000000000000500761b8c4a25d3979359223208b412285f635e71300102")
+	stakedAddr, _ := hex.DecodeString("b00102030405060708090001020304050607080900010203040506070809000b")
+	initialNodesSetup := &mock.InitialNodesHandlerStub{
+		InitialNodesInfoCalled: func() (map[uint32][]nodesCoordinator.GenesisNodeInfoHandler, map[uint32][]nodesCoordinator.GenesisNodeInfoHandler) {
+			return map[uint32][]nodesCoordinator.GenesisNodeInfoHandler{
+				0: {
+					&mock.GenesisNodeInfoHandlerMock{
+						AddressBytesValue: scAddressBytes,
+						PubKeyBytesValue:  bytes.Repeat([]byte{1}, 96),
+					},
+					&mock.GenesisNodeInfoHandlerMock{
+						AddressBytesValue: stakedAddr,
+						PubKeyBytesValue:  bytes.Repeat([]byte{2}, 96),
+					},
+				},
+				1: {
+					&mock.GenesisNodeInfoHandlerMock{
+						AddressBytesValue: scAddressBytes,
+						PubKeyBytesValue:  bytes.Repeat([]byte{3}, 96),
+					},
+				},
+			}, make(map[uint32][]nodesCoordinator.GenesisNodeInfoHandler)
+		},
+		MinNumberOfNodesCalled: func() uint32 {
+			return 1
+		},
+	}
+
+	t.Run("total stake by type should match the delegated values", func(t *testing.T) {
+		arg := createMockArgument(
+			t,
+			"testdata/genesisTest1.json",
+			initialNodesSetup,
+			big.NewInt(22000),
+		)
+		arg.DelegationTotalStakeFunction = "getTotalStakeByType"
+
+		gbc, err := NewGenesisBlockCreator(arg)
+		require.Nil(t, err)
+
+		blocks, err := gbc.CreateGenesisBlocks()
+		assert.Nil(t, err)
+		assert.Equal(t, 3, len(blocks))
+	})
+	t.Run("total active stake should not match the delegated values", func(t *testing.T) {
+		// the genesis delegated stake is not active yet, so the contract reports a zero active stake
+		arg := createMockArgument(
+			t,
+			"testdata/genesisTest1.json",
+			initialNodesSetup,
+			big.NewInt(22000),
+		)
+		arg.DelegationTotalStakeFunction = "getTotalActiveStake"
+
+		gbc, err := NewGenesisBlockCreator(arg)
+		require.Nil(t, err)
+
+		blocks, err := gbc.CreateGenesisBlocks()
+		assert.True(t, errors.Is(err, genesis.ErrWhileVerifyingDelegation))
+		assert.Nil(t, blocks)
+	})
+}
+
 func TestGenesisBlockCreator_CreateGenesisBlocksStakingAndDelegationShouldWorkAndDNS(t *testing.T) {
 	scAddressBytes, _ := hex.DecodeString("00000000000000000500761b8c4a25d3979359223208b412285f635e71300102")
 	stakedAddr, _ := hex.DecodeString("b00102030405060708090001020304050607080900010203040506070809000b")
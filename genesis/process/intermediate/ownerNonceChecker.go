@@ -0,0 +1,34 @@
+package intermediate
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/multiversx/mx-chain-go/genesis"
+)
+
+// ownerNonceChecker verifies that the nonces used by the same owner strictly increase between consecutive
+// transactions sent in the same phase, so a misbehaving executor will not make the transactions silently overwrite
+type ownerNonceChecker struct {
+	phase      string
+	lastNonces map[string]uint64
+}
+
+func newOwnerNonceChecker(phase string) *ownerNonceChecker {
+	return &ownerNonceChecker{
+		phase:      phase,
+		lastNonces: make(map[string]uint64),
+	}
+}
+
+func (checker *ownerNonceChecker) check(owner []byte, nonce uint64) error {
+	lastNonce, found := checker.lastNonces[string(owner)]
+	if found && nonce <= lastNonce {
+		return fmt.Errorf("%w for owner %s in phase %s: last nonce %d, current nonce %d",
+			genesis.ErrNonceNotIncreased, hex.EncodeToString(owner), checker.phase, lastNonce, nonce)
+	}
+
+	checker.lastNonces[string(owner)] = nonce
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package intermediate
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-go/genesis"
+	"github.com/multiversx/mx-chain-vm-common-go/parsers"
+)
+
+// StakeDataEncoder formats the data field of a stake transaction, given the function name and the staked value
+type StakeDataEncoder func(function string, value *big.Int) string
+
+// EvenHexStakeDataEncoder encodes the staked value as an even-length hex string. It is the default stake data encoder
+func EvenHexStakeDataEncoder(function string, value *big.Int) string {
+	return fmt.Sprintf("%s@%s", function, core.ConvertToEvenHexBigInt(value))
+}
+
+// PlainHexStakeDataEncoder encodes the staked value as a base16 string, without any padding
+func PlainHexStakeDataEncoder(function string, value *big.Int) string {
+	return fmt.Sprintf("%s@%s", function, value.Text(16))
+}
+
+// validateStakeData checks that the provided stake data can be parsed back in the provided function and value
+func validateStakeData(stakeData string, function string, value *big.Int) error {
+	parsedFunction, args, err := parsers.NewCallArgsParser().ParseData(stakeData)
+	if err != nil {
+		return fmt.Errorf("%w: %s, data %s", genesis.ErrInvalidStakeData, err.Error(), stakeData)
+	}
+	if parsedFunction != function {
+		return fmt.Errorf("%w: expected function %s, got %s", genesis.ErrInvalidStakeData, function, parsedFunction)
+	}
+	if len(args) != 1 {
+		return fmt.Errorf("%w: expected one argument, got %d", genesis.ErrInvalidStakeData, len(args))
+	}
+
+	parsedValue := big.NewInt(0).SetBytes(args[0])
+	if parsedValue.Cmp(value) != 0 {
+		return fmt.Errorf("%w: expected value %s, got %s", genesis.ErrInvalidStakeData, value.String(), parsedValue.String())
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package intermediate
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-go/genesis"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvenHexStakeDataEncoder(t *testing.T) {
+	t.Parallel()
+
+	t.Run("odd-length value should be padded", func(t *testing.T) {
+		t.Parallel()
+
+		value := big.NewInt(0xabc)
+		stakeData := EvenHexStakeDataEncoder(stakeFunction, value)
+		assert.Equal(t, "stakeGenesis@0abc", stakeData)
+		assert.Nil(t, validateStakeData(stakeData, stakeFunction, value))
+	})
+	t.Run("even-length value should not be padded", func(t *testing.T) {
+		t.Parallel()
+
+		value := big.NewInt(0xabcd)
+		stakeData := EvenHexStakeDataEncoder(stakeFunction, value)
+		assert.Equal(t, "stakeGenesis@abcd", stakeData)
+		assert.Nil(t, validateStakeData(stakeData, stakeFunction, value))
+	})
+}
+
+func TestPlainHexStakeDataEncoder(t *testing.T) {
+	t.Parallel()
+
+	value := big.NewInt(0xabc)
+	stakeData := PlainHexStakeDataEncoder(stakeFunction, value)
+	assert.Equal(t, "stakeGenesis@abc", stakeData)
+
+	err := validateStakeData(stakeData, stakeFunction, value)
+	assert.True(t, errors.Is(err, genesis.ErrInvalidStakeData))
+
+	value = big.NewInt(0xabcd)
+	stakeData = PlainHexStakeDataEncoder(stakeFunction, value)
+	assert.Equal(t, "stakeGenesis@abcd", stakeData)
+	assert.Nil(t, validateStakeData(stakeData, stakeFunction, value))
+}
+
+func TestValidateStakeData(t *testing.T) {
+	t.Parallel()
+
+	value := big.NewInt(100)
+
+	err := validateStakeData("otherFunction@64", stakeFunction, value)
+	assert.True(t, errors.Is(err, genesis.ErrInvalidStakeData))
+
+	err = validateStakeData("stakeGenesis@64@64", stakeFunction, value)
+	assert.True(t, errors.Is(err, genesis.ErrInvalidStakeData))
+
+	err = validateStakeData("stakeGenesis@65", stakeFunction, value)
+	assert.True(t, errors.Is(err, genesis.ErrInvalidStakeData))
+
+	err = validateStakeData("stakeGenesis@64", stakeFunction, value)
+	assert.Nil(t, err)
+}
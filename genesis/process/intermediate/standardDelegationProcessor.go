@@ -2,12 +2,18 @@ package intermediate
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"math/big"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/atomic"
 	"github.com/multiversx/mx-chain-core-go/core/check"
 	"github.com/multiversx/mx-chain-core-go/data"
 	"github.com/multiversx/mx-chain-go/genesis"
@@ -15,7 +21,10 @@ import (
 	"github.com/multiversx/mx-chain-go/process"
 	"github.com/multiversx/mx-chain-go/sharding"
 	"github.com/multiversx/mx-chain-go/sharding/nodesCoordinator"
+	"github.com/multiversx/mx-chain-go/state"
+	"github.com/multiversx/mx-chain-go/vm"
 	logger "github.com/multiversx/mx-chain-logger-go"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
 )
 
 // ArgStandardDelegationProcessor is the argument used to construct a standard delegation processor
@@ -27,12 +36,98 @@ type ArgStandardDelegationProcessor struct {
 	NodesListSplitter   genesis.NodesListSplitter
 	QueryService        external.SCQueryService
 	NodePrice           *big.Int
+	QueryTimeout        time.Duration
+	StakeDataEncoder    StakeDataEncoder
+	// ActivationStatusFunction is the view function used to check if a delegation contract is already activated, so the
+	// activation can be skipped. It should return a single element, non-zero if the contract is active. When empty,
+	// the activation is sent to all contracts
+	ActivationStatusFunction string
+	// MaxTxDataLength is the maximum length of the data field of the transactions sent to the delegation contracts.
+	// Zero means no limit
+	MaxTxDataLength uint32
+	// Accounts, when provided, activates the transactional mode: the accounts state is snapshot before the first
+	// delegation transaction is executed and is reverted if any of the delegation phases fails, along with the executed
+	// transactions list of the Executor, if it supports it (see executedTransactionsReverter). Taking the snapshot is
+	// cheap (it only records the journal length), but all the journal entries created during the delegation execution
+	// are kept in memory until the accounts are committed and a revert will undo each of them
+	Accounts state.AccountsAdapter
+	// BatchStakeFunction is the function used to stake the values of all the delegators of a contract in a single call,
+	// sent by the contract owner with the delegator addresses and values as argument pairs. When empty, one stake
+	// transaction is sent for each delegator
+	BatchStakeFunction string
+	// BatchStakeSupportFunction is the view function used to check if a delegation contract supports the batch stake
+	// function. It should return a single element, non-zero if the function is supported. The contracts that do not
+	// support it are staked with one transaction per delegator. When empty, all contracts are considered to support it
+	BatchStakeSupportFunction string
+	// QueryMaxAttempts is the maximum number of times a verification query is executed when it fails with a transient
+	// error, such as a VM error. The verification mismatches and the timeouts are never retried. Zero means one attempt
+	QueryMaxAttempts uint32
+	// QueryRetryBackoff is the time waited before the first retry of a failed verification query, doubled before each
+	// of the following retries
+	QueryRetryBackoff time.Duration
+	// NumNodesFunction and NumDelegatorsFunction are the view functions used by PostImportReport to get the number of
+	// nodes and, respectively, the number of delegators each delegation contract reports. When empty, the getNumNodes
+	// and getNumUsers functions are used
+	NumNodesFunction      string
+	NumDelegatorsFunction string
+	// CheckNodePrice activates the cross-check of NodePrice against the node price configured in the staking system:
+	// before any transaction is sent, NodePriceFunction is queried on the NodePriceSCAddress contract and the delegation
+	// is not executed if the returned price differs. NodePriceFunction is mandatory if the check is active, while an
+	// empty NodePriceSCAddress means the validator system SC
+	CheckNodePrice     bool
+	NodePriceSCAddress []byte
+	NodePriceFunction  string
+	// VerifyNumWorkers is the maximum number of delegators of a contract verified in parallel. Zero or one means the
+	// delegators are verified one after the other. It is only applied if the QueryService reports that it supports
+	// concurrent queries (see concurrentQueryService), otherwise the concurrent queries would be serialized by the
+	// query service anyway. The verification errors are reported in the delegators order, regardless of the number
+	// of workers
+	VerifyNumWorkers uint32
+	// CollectAllVerifyErrors makes the verification of the delegators of a contract continue after a transient query
+	// error, so all the failed delegators are reported. By default, the verification stops at the first transient
+	// error, while the staked value mismatches of all the delegators are reported anyway
+	CollectAllVerifyErrors bool
+	// TotalStakeFunction is the view function used to verify the total staked value reported by each delegation
+	// contract against the sum of the values of its delegators. All the returned elements are summed, so a function
+	// reporting the stake split by type, such as getTotalStakeByType, can be used. When empty, only the stake of each
+	// delegator is verified
+	TotalStakeFunction string
+	// SkipEmptyContracts makes the delegation skip the contracts without any delegated nodes and delegators, so no
+	// transaction is sent towards them. It changes the genesis transactions and so the genesis root hash, so it is off
+	// by default and all the nodes of a network should use the same value
+	SkipEmptyContracts bool
 }
 
 const stakeFunction = "stakeGenesis"
 const addNodesFunction = "addNodes"
 const activateFunction = "activateGenesis"
 const setStakePerNodeFunction = "setStakePerNode"
+const getNumNodesFunction = "getNumNodes"
+const getNumUsersFunction = "getNumUsers"
+
+// nodeSignatureHandler is implemented by the genesis nodes that carry a signature
+type nodeSignatureHandler interface {
+	Signature() []byte
+}
+
+// executedTransactionsReverter is implemented by the transaction executors able to drop the transactions executed
+// after a given point from their executed transactions list
+type executedTransactionsReverter interface {
+	NumExecutedTransactions() int
+	RevertExecutedTransactions(numTransactions int)
+}
+
+// delegationSnapshot holds the state recorded before the first delegation transaction is executed
+type delegationSnapshot struct {
+	journalLen              int
+	numExecutedTransactions int
+}
+
+// concurrentQueryService is implemented by the query services that can execute more queries at the same time. The
+// SC query service runs its queries one after the other, so it does not implement it
+type concurrentQueryService interface {
+	SupportsConcurrentQueries() bool
+}
 
 var log = logger.GetOrCreate("genesis/process/intermediate")
 var zero = big.NewInt(0)
@@ -46,6 +141,27 @@ type standardDelegationProcessor struct {
 	nodesListSplitter    genesis.NodesListSplitter
 	queryService         external.SCQueryService
 	nodePrice            *big.Int
+	queryTimeout         time.Duration
+	stakeDataEncoder     StakeDataEncoder
+	activationStatusFunc string
+	totalStakeFunc       string
+	maxTxDataLength      uint32
+	accounts             state.AccountsAdapter
+	batchStakeFunc       string
+	batchSupportFunc     string
+	numVerifyQueries     atomic.Counter
+	queryMaxAttempts     uint32
+	queryRetryBackoff    time.Duration
+	numNodesFunc         string
+	numDelegatorsFunc    string
+	mutExecuted          sync.RWMutex
+	executedContracts    map[string]genesis.DelegationContractResult
+	checkNodePrice       bool
+	nodePriceSCAddress   []byte
+	nodePriceFunc        string
+	verifyNumWorkers     uint32
+	collectAllVerifyErrs bool
+	skipEmptyContracts   bool
 }
 
 // NewStandardDelegationProcessor returns a new standard delegation processor instance
@@ -74,6 +190,45 @@ func NewStandardDelegationProcessor(arg ArgStandardDelegationProcessor) (*standa
 	if arg.NodePrice.Cmp(zero) <= 0 {
 		return nil, genesis.ErrInvalidInitialNodePrice
 	}
+	if arg.QueryTimeout <= 0 {
+		return nil, genesis.ErrInvalidQueryTimeout
+	}
+	if arg.QueryRetryBackoff < 0 {
+		return nil, genesis.ErrInvalidQueryRetryBackoff
+	}
+
+	queryMaxAttempts := arg.QueryMaxAttempts
+	if queryMaxAttempts == 0 {
+		queryMaxAttempts = 1
+	}
+	if arg.CheckNodePrice && len(arg.NodePriceFunction) == 0 {
+		return nil, genesis.ErrEmptyNodePriceFunction
+	}
+	nodePriceSCAddress := arg.NodePriceSCAddress
+	if len(nodePriceSCAddress) == 0 {
+		nodePriceSCAddress = vm.ValidatorSCAddress
+	}
+
+	numNodesFunc := arg.NumNodesFunction
+	if len(numNodesFunc) == 0 {
+		numNodesFunc = getNumNodesFunction
+	}
+	numDelegatorsFunc := arg.NumDelegatorsFunction
+	if len(numDelegatorsFunc) == 0 {
+		numDelegatorsFunc = getNumUsersFunction
+	}
+
+	stakeDataEncoder := arg.StakeDataEncoder
+	if stakeDataEncoder == nil {
+		stakeDataEncoder = EvenHexStakeDataEncoder
+	}
+
+	verifyNumWorkers := arg.VerifyNumWorkers
+	if verifyNumWorkers > 1 && !supportsConcurrentQueries(arg.QueryService) {
+		log.Debug("NewStandardDelegationProcessor: the query service does not support concurrent queries, "+
+			"the delegators will be verified sequentially", "num workers", verifyNumWorkers)
+		verifyNumWorkers = 1
+	}
 
 	return &standardDelegationProcessor{
 		TxExecutionProcessor: arg.Executor,
@@ -83,6 +238,25 @@ func NewStandardDelegationProcessor(arg ArgStandardDelegationProcessor) (*standa
 		nodesListSplitter:    arg.NodesListSplitter,
 		queryService:         arg.QueryService,
 		nodePrice:            arg.NodePrice,
+		queryTimeout:         arg.QueryTimeout,
+		stakeDataEncoder:     stakeDataEncoder,
+		activationStatusFunc: arg.ActivationStatusFunction,
+		totalStakeFunc:       arg.TotalStakeFunction,
+		maxTxDataLength:      arg.MaxTxDataLength,
+		accounts:             arg.Accounts,
+		batchStakeFunc:       arg.BatchStakeFunction,
+		batchSupportFunc:     arg.BatchStakeSupportFunction,
+		queryMaxAttempts:     queryMaxAttempts,
+		queryRetryBackoff:    arg.QueryRetryBackoff,
+		numNodesFunc:         numNodesFunc,
+		numDelegatorsFunc:    numDelegatorsFunc,
+		executedContracts:    make(map[string]genesis.DelegationContractResult),
+		checkNodePrice:       arg.CheckNodePrice,
+		nodePriceSCAddress:   nodePriceSCAddress,
+		nodePriceFunc:        arg.NodePriceFunction,
+		verifyNumWorkers:     verifyNumWorkers,
+		collectAllVerifyErrs: arg.CollectAllVerifyErrors,
+		skipEmptyContracts:   arg.SkipEmptyContracts,
 	}, nil
 }
 
@@ -92,39 +266,182 @@ func (sdp *standardDelegationProcessor) ExecuteDelegation() (genesis.DelegationR
 	if err != nil {
 		return genesis.DelegationResult{}, nil, err
 	}
+
+	dr, err := sdp.executeDelegationOnContracts(smartContracts)
+	if err != nil || !dr.HadContracts {
+		return genesis.DelegationResult{}, nil, err
+	}
+
+	delegationTxs := sdp.TxExecutionProcessor.GetExecutedTransactions()
+
+	return dr, delegationTxs, nil
+}
+
+// ExecuteDelegationForContracts will execute the same phases as ExecuteDelegation, but only on the delegation contracts
+// with the provided addresses, allowing the retry of the failed ones. Each address should be of a delegation contract
+// from this shard and should not be provided more than once
+func (sdp *standardDelegationProcessor) ExecuteDelegationForContracts(addresses [][]byte) (genesis.DelegationResult, error) {
+	smartContracts, err := sdp.getDelegationScOnCurrentShard()
+	if err != nil {
+		return genesis.DelegationResult{}, err
+	}
+
+	selectedSmartContracts, err := selectDelegationContracts(smartContracts, addresses)
+	if err != nil {
+		return genesis.DelegationResult{}, err
+	}
+
+	return sdp.executeDelegationOnContracts(selectedSmartContracts)
+}
+
+// selectDelegationContracts returns the delegation contracts with the provided addresses, in the order of the addresses
+func selectDelegationContracts(
+	smartContracts []genesis.InitialSmartContractHandler,
+	addresses [][]byte,
+) ([]genesis.InitialSmartContractHandler, error) {
+	smartContractsByAddress := make(map[string]genesis.InitialSmartContractHandler, len(smartContracts))
+	for _, sc := range smartContracts {
+		smartContractsByAddress[string(getDeployedSCAddressBytes(sc))] = sc
+	}
+
+	selectedSmartContracts := make([]genesis.InitialSmartContractHandler, 0, len(addresses))
+	selectedAddresses := make(map[string]struct{}, len(addresses))
+	for _, address := range addresses {
+		_, isSelected := selectedAddresses[string(address)]
+		if isSelected {
+			return nil, fmt.Errorf("%w: %s", genesis.ErrDuplicatedDelegationContract, hex.EncodeToString(address))
+		}
+
+		sc, found := smartContractsByAddress[string(address)]
+		if !found || len(address) == 0 {
+			return nil, fmt.Errorf("%w: %s", genesis.ErrDelegationContractNotFound, hex.EncodeToString(address))
+		}
+
+		selectedAddresses[string(address)] = struct{}{}
+		selectedSmartContracts = append(selectedSmartContracts, sc)
+	}
+
+	return selectedSmartContracts, nil
+}
+
+// executeDelegationOnContracts executes all the delegation phases on the provided delegation contracts. The contracts
+// without delegated nodes and delegators are skipped if SkipEmptyContracts was set
+func (sdp *standardDelegationProcessor) executeDelegationOnContracts(
+	smartContracts []genesis.InitialSmartContractHandler,
+) (genesis.DelegationResult, error) {
+	smartContracts = sdp.filterOutEmptyDelegationContracts(smartContracts)
 	if len(smartContracts) == 0 {
-		return genesis.DelegationResult{}, nil, nil
+		return genesis.DelegationResult{}, nil
 	}
 
-	err = sdp.setDelegationStartParameters(smartContracts)
+	err := sdp.preflightVerifySignatures(smartContracts)
 	if err != nil {
-		return genesis.DelegationResult{}, nil, err
+		return genesis.DelegationResult{}, err
+	}
+
+	err = sdp.checkDelegatedNodesUniqueness(smartContracts)
+	if err != nil {
+		return genesis.DelegationResult{}, err
+	}
+
+	err = sdp.checkNodePriceAgainstStakingSystem()
+	if err != nil {
+		return genesis.DelegationResult{}, err
 	}
 
-	dr := genesis.DelegationResult{}
+	snapshot := sdp.takeSnapshot()
+	dr, err := sdp.executeDelegationPhases(smartContracts)
+	if err != nil {
+		return genesis.DelegationResult{}, sdp.revertToSnapshot(snapshot, err)
+	}
+
+	dr.Contracts = sdp.computeContractsResults(smartContracts)
+	sdp.recordExecutedContracts(smartContracts, dr.Contracts)
+	dr.ContractsWithoutDelegators, dr.ContractsWithoutNodes = getUnderUtilizedContracts(dr.Contracts)
+
+	return dr, nil
+}
+
+// executeDelegationPhases sends the delegation transactions to the provided contracts and verifies the result. It stops
+// at the first failed phase
+func (sdp *standardDelegationProcessor) executeDelegationPhases(
+	smartContracts []genesis.InitialSmartContractHandler,
+) (genesis.DelegationResult, error) {
+	err := sdp.setDelegationStartParameters(smartContracts)
+	if err != nil {
+		return genesis.DelegationResult{}, err
+	}
+
+	dr := genesis.DelegationResult{
+		HadContracts: true,
+	}
 	dr.NumTotalDelegated, err = sdp.executeManageBlsKeys(smartContracts)
 	if err != nil {
-		return genesis.DelegationResult{}, nil, err
+		return genesis.DelegationResult{}, err
 	}
 
 	dr.NumTotalStaked, err = sdp.executeStake(smartContracts)
 	if err != nil {
-		return genesis.DelegationResult{}, nil, err
+		return genesis.DelegationResult{}, err
 	}
 
 	err = sdp.executeActivation(smartContracts)
 	if err != nil {
-		return genesis.DelegationResult{}, nil, err
+		return genesis.DelegationResult{}, err
 	}
 
+	numQueriesBeforeVerify := sdp.numVerifyQueries.Get()
 	err = sdp.executeVerify(smartContracts)
 	if err != nil {
-		return genesis.DelegationResult{}, nil, err
+		return genesis.DelegationResult{}, err
 	}
+	dr.NumVerifyQueries = int(sdp.numVerifyQueries.Get() - numQueriesBeforeVerify)
 
-	delegationTxs := sdp.TxExecutionProcessor.GetExecutedTransactions()
+	return dr, nil
+}
+
+// takeSnapshot records the accounts journal length and the number of executed transactions if the transactional mode
+// is active. Returns nil otherwise
+func (sdp *standardDelegationProcessor) takeSnapshot() *delegationSnapshot {
+	if check.IfNil(sdp.accounts) {
+		return nil
+	}
 
-	return dr, delegationTxs, err
+	snapshot := &delegationSnapshot{
+		journalLen: sdp.accounts.JournalLen(),
+	}
+	reverter, ok := sdp.TxExecutionProcessor.(executedTransactionsReverter)
+	if ok {
+		snapshot.numExecutedTransactions = reverter.NumExecutedTransactions()
+	}
+
+	return snapshot
+}
+
+// revertToSnapshot reverts the accounts state and the executed transactions list to the provided snapshot, if the
+// transactional mode is active. The provided error is always returned, wrapped with the revert error, if any
+func (sdp *standardDelegationProcessor) revertToSnapshot(snapshot *delegationSnapshot, originalErr error) error {
+	if snapshot == nil {
+		return originalErr
+	}
+
+	reverter, ok := sdp.TxExecutionProcessor.(executedTransactionsReverter)
+	if ok {
+		reverter.RevertExecutedTransactions(snapshot.numExecutedTransactions)
+	}
+
+	errRevert := sdp.accounts.RevertToSnapshot(snapshot.journalLen)
+	if errRevert != nil {
+		return fmt.Errorf("%w, reverting the accounts state failed: %s", originalErr, errRevert.Error())
+	}
+
+	log.Debug("genesis delegation failed, accounts state reverted",
+		"snapshot", snapshot.journalLen,
+		"num executed transactions", snapshot.numExecutedTransactions,
+		"error", originalErr,
+	)
+
+	return originalErr
 }
 
 func (sdp *standardDelegationProcessor) getDelegationScOnCurrentShard() ([]genesis.InitialSmartContractHandler, error) {
@@ -148,6 +465,166 @@ func (sdp *standardDelegationProcessor) getDelegationScOnCurrentShard() ([]genes
 	return smartContracts, nil
 }
 
+// computeContractsResults returns the number of delegated nodes and the number of staking accounts of each of the
+// provided delegation contracts
+func (sdp *standardDelegationProcessor) computeContractsResults(
+	smartContracts []genesis.InitialSmartContractHandler,
+) []genesis.DelegationContractResult {
+	contractsResults := make([]genesis.DelegationContractResult, 0, len(smartContracts))
+	for _, sc := range smartContracts {
+		scAddress := getDeployedSCAddressBytes(sc)
+		contractsResults = append(contractsResults, genesis.DelegationContractResult{
+			Address:      getDeployedSCAddress(sc),
+			Owner:        sc.GetOwner(),
+			NumDelegated: len(sdp.nodesListSplitter.GetDelegatedNodes(scAddress)),
+			NumStaked:    len(sdp.accuntsParser.GetInitialAccountsForDelegated(scAddress)),
+		})
+	}
+
+	return contractsResults
+}
+
+// recordExecutedContracts stores the results of the provided delegation contracts, replacing the ones recorded by a
+// previous execution on the same contracts, so they can be cross-checked by PostImportReport
+func (sdp *standardDelegationProcessor) recordExecutedContracts(
+	smartContracts []genesis.InitialSmartContractHandler,
+	contractsResults []genesis.DelegationContractResult,
+) {
+	sdp.mutExecuted.Lock()
+	defer sdp.mutExecuted.Unlock()
+
+	for i, sc := range smartContracts {
+		sdp.executedContracts[string(getDeployedSCAddressBytes(sc))] = contractsResults[i]
+	}
+}
+
+// PostImportReport cross-checks, through SC queries, each delegation contract on which the delegation was executed:
+// the number of nodes sent with the addNodes transaction against the number of nodes reported by the contract and the
+// number of delegators for which a stake was sent against the number of delegators reported by the contract. It returns
+// the found discrepancies, sorted by the contract address, catching the transactions which silently failed
+func (sdp *standardDelegationProcessor) PostImportReport() ([]genesis.DelegationDiscrepancy, error) {
+	sdp.mutExecuted.RLock()
+	addresses := make([]string, 0, len(sdp.executedContracts))
+	contractsResults := make(map[string]genesis.DelegationContractResult, len(sdp.executedContracts))
+	for address, contractResult := range sdp.executedContracts {
+		addresses = append(addresses, address)
+		contractsResults[address] = contractResult
+	}
+	sdp.mutExecuted.RUnlock()
+
+	sort.Strings(addresses)
+
+	discrepancies := make([]genesis.DelegationDiscrepancy, 0)
+	for _, address := range addresses {
+		contractResult := contractsResults[address]
+		checks := []struct {
+			name     string
+			function string
+			numSent  int
+		}{
+			{name: genesis.DelegationNodesCheck, function: sdp.numNodesFunc, numSent: contractResult.NumDelegated},
+			{name: genesis.DelegationDelegatorsCheck, function: sdp.numDelegatorsFunc, numSent: contractResult.NumStaked},
+		}
+
+		for _, c := range checks {
+			numReported, err := sdp.queryBigIntWithRetry([]byte(address), c.function, nil)
+			if err != nil {
+				return nil, fmt.Errorf("%w while checking the %s of contract %s", err, c.name, contractResult.Address)
+			}
+			if numReported.Cmp(big.NewInt(int64(c.numSent))) == 0 {
+				continue
+			}
+
+			log.Warn("genesis delegation contract discrepancy",
+				"SC address", contractResult.Address,
+				"SC owner", contractResult.Owner,
+				"check", c.name,
+				"sent", c.numSent,
+				"reported", numReported.String(),
+			)
+			discrepancies = append(discrepancies, genesis.DelegationDiscrepancy{
+				Address:  contractResult.Address,
+				Owner:    contractResult.Owner,
+				Check:    c.name,
+				Sent:     c.numSent,
+				Reported: numReported.String(),
+			})
+		}
+	}
+
+	return discrepancies, nil
+}
+
+// getUnderUtilizedContracts returns the addresses of the provided delegation contracts which do not have any delegator
+// and, separately, the addresses of the ones which do not have any delegated node. Nil slices are returned if there
+// are no such contracts
+func getUnderUtilizedContracts(contractsResults []genesis.DelegationContractResult) ([]string, []string) {
+	var contractsWithoutDelegators, contractsWithoutNodes []string
+	for _, contractResult := range contractsResults {
+		if contractResult.NumStaked == 0 {
+			contractsWithoutDelegators = append(contractsWithoutDelegators, contractResult.Address)
+		}
+		if contractResult.NumDelegated == 0 {
+			contractsWithoutNodes = append(contractsWithoutNodes, contractResult.Address)
+		}
+	}
+
+	return contractsWithoutDelegators, contractsWithoutNodes
+}
+
+// DelegatedNodesByContract returns the public keys of the delegated nodes for each delegation contract from this shard,
+// keyed by the hex encoded contract address
+func (sdp *standardDelegationProcessor) DelegatedNodesByContract() (map[string][][]byte, error) {
+	smartContracts, err := sdp.getDelegationScOnCurrentShard()
+	if err != nil {
+		return nil, err
+	}
+
+	delegatedNodesByContract := make(map[string][][]byte, len(smartContracts))
+	for _, sc := range smartContracts {
+		scAddress := getDeployedSCAddressBytes(sc)
+		delegatedNodes := sdp.nodesListSplitter.GetDelegatedNodes(scAddress)
+
+		pubKeys := make([][]byte, 0, len(delegatedNodes))
+		for _, node := range delegatedNodes {
+			pubKeys = append(pubKeys, node.PubKeyBytes())
+		}
+
+		delegatedNodesByContract[hex.EncodeToString(scAddress)] = pubKeys
+	}
+
+	return delegatedNodesByContract, nil
+}
+
+// filterOutEmptyDelegationContracts removes the delegation contracts that do not have any delegated nodes nor any
+// delegators, as there is no transaction that should be sent towards them in any of the phases. The contracts are
+// returned unchanged if the empty contracts should not be skipped
+func (sdp *standardDelegationProcessor) filterOutEmptyDelegationContracts(
+	smartContracts []genesis.InitialSmartContractHandler,
+) []genesis.InitialSmartContractHandler {
+	if !sdp.skipEmptyContracts {
+		return smartContracts
+	}
+
+	filteredSmartContracts := make([]genesis.InitialSmartContractHandler, 0, len(smartContracts))
+	for _, sc := range smartContracts {
+		scAddress := getDeployedSCAddressBytes(sc)
+		numDelegatedNodes := len(sdp.nodesListSplitter.GetDelegatedNodes(scAddress))
+		numDelegators := len(sdp.accuntsParser.GetInitialAccountsForDelegated(scAddress))
+		if numDelegatedNodes == 0 && numDelegators == 0 {
+			log.Debug("genesis delegation SC does not have staked nodes nor delegators, skipping",
+				"SC owner", sc.GetOwner(),
+				"SC address", getDeployedSCAddress(sc),
+			)
+			continue
+		}
+
+		filteredSmartContracts = append(filteredSmartContracts, sc)
+	}
+
+	return filteredSmartContracts
+}
+
 func getDeployedSCAddress(sc genesis.InitialSmartContractHandler) string {
 	if len(sc.Addresses()) != 1 {
 		return ""
@@ -162,7 +639,27 @@ func getDeployedSCAddressBytes(sc genesis.InitialSmartContractHandler) []byte {
 	return sc.AddressesBytes()[0]
 }
 
+// checkNodePriceAgainstStakingSystem errors if the node price configured in the staking system differs from the node
+// price set on the delegation contracts. It does nothing if the check is not active
+func (sdp *standardDelegationProcessor) checkNodePriceAgainstStakingSystem() error {
+	if !sdp.checkNodePrice {
+		return nil
+	}
+
+	stakingNodePrice, err := sdp.queryBigIntWithRetry(sdp.nodePriceSCAddress, sdp.nodePriceFunc, nil)
+	if err != nil {
+		return fmt.Errorf("%w while querying the staking system node price", err)
+	}
+	if stakingNodePrice.Cmp(sdp.nodePrice) != 0 {
+		return fmt.Errorf("%w: staking system %s, delegation %s",
+			genesis.ErrNodePriceMismatch, stakingNodePrice.String(), sdp.nodePrice.String())
+	}
+
+	return nil
+}
+
 func (sdp *standardDelegationProcessor) setDelegationStartParameters(smartContracts []genesis.InitialSmartContractHandler) error {
+	nonceChecker := newOwnerNonceChecker(setStakePerNodeFunction)
 	for _, sc := range smartContracts {
 
 		delegatedNodes := sdp.nodesListSplitter.GetDelegatedNodes(getDeployedSCAddressBytes(sc))
@@ -176,7 +673,7 @@ func (sdp *standardDelegationProcessor) setDelegationStartParameters(smartContra
 			"shard ID", sdp.shardCoordinator.SelfId(),
 		)
 
-		err := sdp.executeSetNodePrice(sc)
+		err := sdp.executeSetNodePrice(sc, nonceChecker)
 		if err != nil {
 			return err
 		}
@@ -185,13 +682,21 @@ func (sdp *standardDelegationProcessor) setDelegationStartParameters(smartContra
 	return nil
 }
 
-func (sdp *standardDelegationProcessor) executeSetNodePrice(sc genesis.InitialSmartContractHandler) error {
+func (sdp *standardDelegationProcessor) executeSetNodePrice(sc genesis.InitialSmartContractHandler, nonceChecker *ownerNonceChecker) error {
 	setStakePerNodeTxData := fmt.Sprintf("%s@%s", setStakePerNodeFunction, core.ConvertToEvenHexBigInt(sdp.nodePrice))
 
 	nonce, err := sdp.GetNonce(sc.OwnerBytes())
 	if err != nil {
 		return err
 	}
+	err = nonceChecker.check(sc.OwnerBytes(), nonce)
+	if err != nil {
+		return err
+	}
+	err = sdp.checkTxDataLength([]byte(setStakePerNodeTxData), sc, setStakePerNodeFunction)
+	if err != nil {
+		return err
+	}
 
 	return sdp.ExecuteTransaction(
 		nonce,
@@ -215,14 +720,9 @@ func (sdp *standardDelegationProcessor) executeStake(smartContracts []genesis.In
 			continue
 		}
 
-		totalDelegated := big.NewInt(0)
-		for _, ac := range accounts {
-			err := sdp.stake(ac, sc)
-			if err != nil {
-				return 0, fmt.Errorf("%w while calling stake function from account %s", err, ac.GetAddress())
-			}
-
-			totalDelegated.Add(totalDelegated, ac.GetDelegationHandler().GetValue())
+		totalDelegated, err := sdp.stakeAccounts(accounts, sc)
+		if err != nil {
+			return 0, err
 		}
 
 		log.Trace("executeStake",
@@ -237,6 +737,92 @@ func (sdp *standardDelegationProcessor) executeStake(smartContracts []genesis.In
 	return stakedOnDelegation, nil
 }
 
+// stakeAccounts stakes the values of the provided accounts on the provided contract, returning the total staked value.
+// A single batch stake transaction is sent if the contract supports it, one stake transaction per account otherwise
+func (sdp *standardDelegationProcessor) stakeAccounts(
+	accounts []genesis.InitialAccountHandler,
+	sc genesis.InitialSmartContractHandler,
+) (*big.Int, error) {
+	totalDelegated := big.NewInt(0)
+	if sdp.isBatchStakeSupported(sc) {
+		err := sdp.batchStake(accounts, sc)
+		if err != nil {
+			return nil, fmt.Errorf("%w while calling batch stake function for SC %s", err, getDeployedSCAddress(sc))
+		}
+
+		for _, ac := range accounts {
+			totalDelegated.Add(totalDelegated, ac.GetDelegationHandler().GetValue())
+		}
+
+		return totalDelegated, nil
+	}
+
+	for _, ac := range accounts {
+		err := sdp.stake(ac, sc)
+		if err != nil {
+			return nil, fmt.Errorf("%w while calling stake function from account %s", err, ac.GetAddress())
+		}
+
+		totalDelegated.Add(totalDelegated, ac.GetDelegationHandler().GetValue())
+	}
+
+	return totalDelegated, nil
+}
+
+func (sdp *standardDelegationProcessor) isBatchStakeSupported(sc genesis.InitialSmartContractHandler) bool {
+	if len(sdp.batchStakeFunc) == 0 {
+		return false
+	}
+	if len(sdp.batchSupportFunc) == 0 {
+		return true
+	}
+
+	status, err := sdp.queryBigInt(getDeployedSCAddressBytes(sc), sdp.batchSupportFunc, nil)
+	if err != nil {
+		log.Debug("executeStake: can not determine if batch stake is supported, will stake for each account",
+			"SC address", getDeployedSCAddress(sc),
+			"function", sdp.batchSupportFunc,
+			"error", err,
+		)
+		return false
+	}
+
+	return status.Sign() != 0
+}
+
+func (sdp *standardDelegationProcessor) batchStake(accounts []genesis.InitialAccountHandler, sc genesis.InitialSmartContractHandler) error {
+	batchStakeData := sdp.batchStakeFunc
+	for _, ac := range accounts {
+		dh := ac.GetDelegationHandler()
+		if check.IfNil(dh) {
+			return genesis.ErrNilDelegationHandler
+		}
+		if dh.GetValue() == nil {
+			return genesis.ErrInvalidDelegationValue
+		}
+
+		batchStakeData += fmt.Sprintf("@%s@%s", hex.EncodeToString(ac.AddressBytes()), core.ConvertToEvenHexBigInt(dh.GetValue()))
+	}
+
+	err := sdp.checkTxDataLength([]byte(batchStakeData), sc, sdp.batchStakeFunc)
+	if err != nil {
+		return err
+	}
+
+	nonce, err := sdp.GetNonce(sc.OwnerBytes())
+	if err != nil {
+		return err
+	}
+
+	return sdp.ExecuteTransaction(
+		nonce,
+		sc.OwnerBytes(),
+		getDeployedSCAddressBytes(sc),
+		zero,
+		[]byte(batchStakeData),
+	)
+}
+
 func (sdp *standardDelegationProcessor) stake(ac genesis.InitialAccountHandler, sc genesis.InitialSmartContractHandler) error {
 	isIntraShardCall := sdp.shardCoordinator.SameShard(ac.AddressBytes(), getDeployedSCAddressBytes(sc))
 
@@ -258,7 +844,16 @@ func (sdp *standardDelegationProcessor) stake(ac genesis.InitialAccountHandler,
 		}
 	}
 
-	stakeData := fmt.Sprintf("%s@%s", stakeFunction, core.ConvertToEvenHexBigInt(dh.GetValue()))
+	stakeData := sdp.stakeDataEncoder(stakeFunction, dh.GetValue())
+	err = validateStakeData(stakeData, stakeFunction, dh.GetValue())
+	if err != nil {
+		return err
+	}
+	err = sdp.checkTxDataLength([]byte(stakeData), sc, stakeFunction)
+	if err != nil {
+		return err
+	}
+
 	err = sdp.ExecuteTransaction(
 		nonce,
 		ac.AddressBytes(),
@@ -273,6 +868,16 @@ func (sdp *standardDelegationProcessor) stake(ac genesis.InitialAccountHandler,
 	return nil
 }
 
+// checkTxDataLength errors if the provided transaction data exceeds the configured maximum length
+func (sdp *standardDelegationProcessor) checkTxDataLength(txData []byte, sc genesis.InitialSmartContractHandler, phase string) error {
+	if sdp.maxTxDataLength == 0 || len(txData) <= int(sdp.maxTxDataLength) {
+		return nil
+	}
+
+	return fmt.Errorf("%w for SC %s, owner %s, phase %s: length %d, maximum %d",
+		genesis.ErrTxDataTooLong, getDeployedSCAddress(sc), sc.GetOwner(), phase, len(txData), sdp.maxTxDataLength)
+}
+
 func (sdp *standardDelegationProcessor) executeManageBlsKeys(
 	smartContracts []genesis.InitialSmartContractHandler,
 ) (int, error) {
@@ -284,6 +889,7 @@ func (sdp *standardDelegationProcessor) executeManageBlsKeys(
 	)
 
 	totalDelegated := 0
+	nonceChecker := newOwnerNonceChecker(addNodesFunction)
 	for _, sc := range smartContracts {
 		delegatedNodes := sdp.nodesListSplitter.GetDelegatedNodes(getDeployedSCAddressBytes(sc))
 
@@ -317,13 +923,23 @@ func (sdp *standardDelegationProcessor) executeManageBlsKeys(
 		if err != nil {
 			return 0, err
 		}
+		err = nonceChecker.check(sc.OwnerBytes(), nonce)
+		if err != nil {
+			return 0, err
+		}
+
+		addNodesTxData := []byte(strings.Join(arguments, "@"))
+		err = sdp.checkTxDataLength(addNodesTxData, sc, addNodesFunction)
+		if err != nil {
+			return 0, err
+		}
 
 		err = sdp.ExecuteTransaction(
 			nonce,
 			sc.OwnerBytes(),
 			getDeployedSCAddressBytes(sc),
 			big.NewInt(0),
-			[]byte(strings.Join(arguments, "@")),
+			addNodesTxData,
 		)
 		if err != nil {
 			return 0, err
@@ -341,6 +957,7 @@ func (sdp *standardDelegationProcessor) executeActivation(smartContracts []genes
 		"function", activateFunction,
 	)
 
+	nonceChecker := newOwnerNonceChecker(activateFunction)
 	for _, sc := range smartContracts {
 		log.Trace("executeActivation",
 			"SC owner", sc.GetOwner(),
@@ -349,10 +966,27 @@ func (sdp *standardDelegationProcessor) executeActivation(smartContracts []genes
 			"function", activateFunction,
 		)
 
+		if sdp.isActivated(sc) {
+			log.Debug("executeActivation: delegation SC already activated, skipping",
+				"SC owner", sc.GetOwner(),
+				"SC address", getDeployedSCAddress(sc),
+				"shard ID", sdp.shardCoordinator.SelfId(),
+			)
+			continue
+		}
+
 		nonce, err := sdp.GetNonce(sc.OwnerBytes())
 		if err != nil {
 			return err
 		}
+		err = nonceChecker.check(sc.OwnerBytes(), nonce)
+		if err != nil {
+			return err
+		}
+		err = sdp.checkTxDataLength([]byte(activateFunction), sc, activateFunction)
+		if err != nil {
+			return err
+		}
 
 		err = sdp.ExecuteTransaction(
 			nonce,
@@ -369,6 +1003,26 @@ func (sdp *standardDelegationProcessor) executeActivation(smartContracts []genes
 	return nil
 }
 
+// isActivated returns true only if the configured activation status function reports the contract as active. If the
+// status can not be determined, the contract is considered not activated, so the activation will be sent
+func (sdp *standardDelegationProcessor) isActivated(sc genesis.InitialSmartContractHandler) bool {
+	if len(sdp.activationStatusFunc) == 0 {
+		return false
+	}
+
+	status, err := sdp.queryBigInt(getDeployedSCAddressBytes(sc), sdp.activationStatusFunc, nil)
+	if err != nil {
+		log.Debug("executeActivation: can not determine the activation status, will activate",
+			"SC address", getDeployedSCAddress(sc),
+			"function", sdp.activationStatusFunc,
+			"error", err,
+		)
+		return false
+	}
+
+	return status.Sign() != 0
+}
+
 func (sdp *standardDelegationProcessor) executeVerify(smartContracts []genesis.InitialSmartContractHandler) error {
 	for _, sc := range smartContracts {
 		err := sdp.verify(sc)
@@ -402,9 +1056,32 @@ func (sdp *standardDelegationProcessor) verify(sc genesis.InitialSmartContractHa
 }
 
 func (sdp *standardDelegationProcessor) verifyStakedValue(sc genesis.InitialSmartContractHandler) error {
-	providedStakedValue := big.NewInt(0)
+	providedStakedValue, err := sdp.computeExpectedTotalStake(sc)
+	if err != nil {
+		return err
+	}
+	if len(sdp.totalStakeFunc) == 0 {
+		return nil
+	}
+
+	scTotalStake, err := sdp.querySumWithRetry(getDeployedSCAddressBytes(sc), sdp.totalStakeFunc, nil)
+	if err != nil {
+		return err
+	}
+	if scTotalStake.Cmp(providedStakedValue) != 0 {
+		return fmt.Errorf("%w total staked value mismatch: from SC: %s, provided: %s",
+			genesis.ErrWhileVerifyingDelegation, scTotalStake.String(), providedStakedValue.String())
+	}
+
+	return nil
+}
+
+// computeExpectedTotalStake sums the values of all the delegators of the provided contract, checking on the way that
+// each delegator's stake was correctly recorded by the contract
+func (sdp *standardDelegationProcessor) computeExpectedTotalStake(sc genesis.InitialSmartContractHandler) (*big.Int, error) {
 	providedDelegators := sdp.accuntsParser.GetInitialAccountsForDelegated(getDeployedSCAddressBytes(sc))
 
+	delegators := make([]genesis.InitialAccountHandler, 0, len(providedDelegators))
 	for _, delegator := range providedDelegators {
 		if check.IfNil(delegator) {
 			continue
@@ -417,35 +1094,128 @@ func (sdp *standardDelegationProcessor) verifyStakedValue(sc genesis.InitialSmar
 			continue
 		}
 
-		err := sdp.checkDelegator(delegator, sc)
-		if err != nil {
-			return err
+		delegators = append(delegators, delegator)
+	}
+
+	err := aggregateDelegatorsErrors(sdp.checkDelegators(delegators, sc))
+	if err != nil {
+		return nil, err
+	}
+
+	providedStakedValue := big.NewInt(0)
+	for _, delegator := range delegators {
+		providedStakedValue.Add(providedStakedValue, delegator.GetDelegationHandler().GetValue())
+	}
+
+	return providedStakedValue, nil
+}
+
+func supportsConcurrentQueries(queryService external.SCQueryService) bool {
+	concurrentService, ok := queryService.(concurrentQueryService)
+
+	return ok && concurrentService.SupportsConcurrentQueries()
+}
+
+// checkDelegators checks the provided delegators using at most the configured number of workers and returns the
+// verification error of each delegator, on the delegator's position. Unless all the errors should be collected, no
+// other delegator is checked after a transient error occurred, the unchecked delegators having a nil error
+func (sdp *standardDelegationProcessor) checkDelegators(
+	delegators []genesis.InitialAccountHandler,
+	sc genesis.InitialSmartContractHandler,
+) []error {
+	if sdp.verifyNumWorkers <= 1 {
+		return sdp.checkDelegatorsSequentially(delegators, sc)
+	}
+
+	errs := make([]error, len(delegators))
+	throttler := make(chan struct{}, sdp.verifyNumWorkers)
+	stop := atomic.Flag{}
+	wg := sync.WaitGroup{}
+	for i, delegator := range delegators {
+		throttler <- struct{}{}
+		if stop.IsSet() {
+			<-throttler
+			break
 		}
 
-		providedStakedValue.Add(providedStakedValue, dh.GetValue())
+		wg.Add(1)
+		go func(idx int, delegator genesis.InitialAccountHandler) {
+			defer func() {
+				<-throttler
+				wg.Done()
+			}()
+
+			errs[idx] = sdp.checkDelegator(delegator, sc)
+			if sdp.shouldStopVerification(errs[idx]) {
+				stop.SetValue(true)
+			}
+		}(i, delegator)
 	}
+	wg.Wait()
 
-	return nil
+	return errs
+}
+
+func (sdp *standardDelegationProcessor) checkDelegatorsSequentially(
+	delegators []genesis.InitialAccountHandler,
+	sc genesis.InitialSmartContractHandler,
+) []error {
+	errs := make([]error, len(delegators))
+	for i, delegator := range delegators {
+		errs[i] = sdp.checkDelegator(delegator, sc)
+		if sdp.shouldStopVerification(errs[i]) {
+			break
+		}
+	}
+
+	return errs
+}
+
+// shouldStopVerification returns true if no other delegator should be checked after the provided error. A timeout
+// always stops the verification, as the timed out query may still hold the query service
+func (sdp *standardDelegationProcessor) shouldStopVerification(err error) bool {
+	if errors.Is(err, genesis.ErrQueryTimeout) {
+		return true
+	}
+
+	return err != nil && isTransientQueryError(err) && !sdp.collectAllVerifyErrs
+}
+
+// aggregateDelegatorsErrors returns nil if there is no error, the error itself if there is only one or the first error
+// extended with the messages of all the others, in the provided order
+func aggregateDelegatorsErrors(errs []error) error {
+	var firstErr error
+	messages := make([]string, 0)
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		if firstErr == nil {
+			firstErr = err
+			continue
+		}
+
+		messages = append(messages, err.Error())
+	}
+
+	if len(messages) == 0 {
+		return firstErr
+	}
+
+	return fmt.Errorf("%w; %d more delegators failed the verification: %s",
+		firstErr, len(messages), strings.Join(messages, "; "))
 }
 
 func (sdp *standardDelegationProcessor) checkDelegator(
 	delegator genesis.InitialAccountHandler,
 	sc genesis.InitialSmartContractHandler,
 ) error {
-	scQueryStakeValue := &process.SCQuery{
-		ScAddress: getDeployedSCAddressBytes(sc),
-		FuncName:  "getUserStake",
-		Arguments: [][]byte{delegator.AddressBytes()},
-	}
-	vmOutputStakeValue, _, err := sdp.queryService.ExecuteQuery(scQueryStakeValue)
+	sdp.numVerifyQueries.Increment()
+
+	scStakedValue, err := sdp.queryBigIntWithRetry(getDeployedSCAddressBytes(sc), "getUserStake", [][]byte{delegator.AddressBytes()})
 	if err != nil {
 		return err
 	}
-	if len(vmOutputStakeValue.ReturnData) != 1 {
-		return fmt.Errorf("%w return data should have contained one element", genesis.ErrWhileVerifyingDelegation)
-	}
-
-	scStakedValue := big.NewInt(0).SetBytes(vmOutputStakeValue.ReturnData[0])
 	if scStakedValue.Cmp(delegator.GetDelegationHandler().GetValue()) != 0 {
 		return fmt.Errorf("%w staked data mismatch: from SC: %s, provided: %s, account %s",
 			genesis.ErrWhileVerifyingDelegation, scStakedValue.String(),
@@ -455,6 +1225,136 @@ func (sdp *standardDelegationProcessor) checkDelegator(
 	return nil
 }
 
+type queryResult struct {
+	vmOutput *vmcommon.VMOutput
+	err      error
+}
+
+// executeQueryWithTimeout executes the provided query, returning an error if the query did not finish in the
+// configured time frame. The query can not be cancelled, so a timed out query keeps running and holding the query
+// service, which executes the queries one after the other. That is why a timeout is not retried
+func (sdp *standardDelegationProcessor) executeQueryWithTimeout(scQuery *process.SCQuery) (*vmcommon.VMOutput, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), sdp.queryTimeout)
+	defer cancel()
+
+	chResult := make(chan queryResult, 1)
+	go func() {
+		var result queryResult
+		result.vmOutput, _, result.err = sdp.queryService.ExecuteQuery(scQuery)
+		chResult <- result
+	}()
+
+	select {
+	case result := <-chResult:
+		return result.vmOutput, result.err
+	case <-ctx.Done():
+		return nil, fmt.Errorf("%w after %v, SC %s, function %s",
+			genesis.ErrQueryTimeout, sdp.queryTimeout, hex.EncodeToString(scQuery.ScAddress), scQuery.FuncName)
+	}
+}
+
+// executeQueryWithRetry executes the provided query, retrying it with an increasing backoff while it fails with a
+// transient error, up to the configured maximum number of attempts
+func (sdp *standardDelegationProcessor) executeQueryWithRetry(scQuery *process.SCQuery) (*vmcommon.VMOutput, error) {
+	backoff := sdp.queryRetryBackoff
+	for attempt := uint32(1); ; attempt++ {
+		vmOutput, err := sdp.executeQueryWithTimeout(scQuery)
+		if err == nil {
+			return vmOutput, nil
+		}
+		if !isTransientQueryError(err) {
+			return nil, err
+		}
+		if attempt >= sdp.queryMaxAttempts {
+			if attempt == 1 {
+				return nil, err
+			}
+
+			return nil, fmt.Errorf("%w after %d attempts", err, attempt)
+		}
+
+		log.Debug("standardDelegationProcessor: query failed, will retry",
+			"SC address", hex.EncodeToString(scQuery.ScAddress),
+			"function", scQuery.FuncName,
+			"attempt", attempt,
+			"backoff", backoff,
+			"error", err,
+		)
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// isTransientQueryError returns false for the errors signaling that the delegation contract state does not match
+// the genesis configuration, as executing the query again will not change the outcome, and for the timeouts, as a new
+// query would only wait behind the timed out one
+func isTransientQueryError(err error) bool {
+	isMismatch := errors.Is(err, genesis.ErrWhileVerifyingDelegation) ||
+		errors.Is(err, genesis.ErrSignatureMismatch) ||
+		errors.Is(err, genesis.ErrEmptyReturnData)
+	isTimeout := errors.Is(err, genesis.ErrQueryTimeout)
+
+	return !isMismatch && !isTimeout
+}
+
+// queryBigInt executes the provided view function on the SC and decodes the single returned element as a big integer
+func (sdp *standardDelegationProcessor) queryBigInt(scAddress []byte, funcName string, args [][]byte) (*big.Int, error) {
+	return sdp.queryBigIntUsing(sdp.executeQueryWithTimeout, scAddress, funcName, args)
+}
+
+// queryBigIntWithRetry is the same as queryBigInt, but retries the query while it fails with a transient error
+func (sdp *standardDelegationProcessor) queryBigIntWithRetry(scAddress []byte, funcName string, args [][]byte) (*big.Int, error) {
+	return sdp.queryBigIntUsing(sdp.executeQueryWithRetry, scAddress, funcName, args)
+}
+
+// querySumWithRetry executes the query, retrying it while it fails with a transient error, and returns the sum of all
+// the returned elements
+func (sdp *standardDelegationProcessor) querySumWithRetry(scAddress []byte, funcName string, args [][]byte) (*big.Int, error) {
+	scQuery := &process.SCQuery{
+		ScAddress: scAddress,
+		FuncName:  funcName,
+		Arguments: args,
+	}
+	vmOutput, err := sdp.executeQueryWithRetry(scQuery)
+	if err != nil {
+		return nil, err
+	}
+	if len(vmOutput.ReturnData) == 0 {
+		return nil, fmt.Errorf("%w return data should have contained at least one element, function %s",
+			genesis.ErrWhileVerifyingDelegation, funcName)
+	}
+
+	sum := big.NewInt(0)
+	for _, returnData := range vmOutput.ReturnData {
+		sum.Add(sum, big.NewInt(0).SetBytes(returnData))
+	}
+
+	return sum, nil
+}
+
+func (sdp *standardDelegationProcessor) queryBigIntUsing(
+	executeQuery func(scQuery *process.SCQuery) (*vmcommon.VMOutput, error),
+	scAddress []byte,
+	funcName string,
+	args [][]byte,
+) (*big.Int, error) {
+	scQuery := &process.SCQuery{
+		ScAddress: scAddress,
+		FuncName:  funcName,
+		Arguments: args,
+	}
+	vmOutput, err := executeQuery(scQuery)
+	if err != nil {
+		return nil, err
+	}
+	if len(vmOutput.ReturnData) != 1 {
+		return nil, fmt.Errorf("%w return data should have contained one element, function %s, got %d elements",
+			genesis.ErrWhileVerifyingDelegation, funcName, len(vmOutput.ReturnData))
+	}
+
+	return big.NewInt(0).SetBytes(vmOutput.ReturnData[0]), nil
+}
+
 func (sdp *standardDelegationProcessor) verifyRegisteredNodes(sc genesis.InitialSmartContractHandler) error {
 	delegatedNodes := sdp.nodesListSplitter.GetDelegatedNodes(getDeployedSCAddressBytes(sc))
 	if len(delegatedNodes) == 0 {
@@ -481,6 +1381,7 @@ func (sdp *standardDelegationProcessor) verifyOneNode(
 	sc genesis.InitialSmartContractHandler,
 	node nodesCoordinator.GenesisNodeInfoHandler,
 ) error {
+	sdp.numVerifyQueries.Increment()
 
 	function := "getNodeSignature"
 	scQueryBlsKeys := &process.SCQuery{
@@ -489,7 +1390,7 @@ func (sdp *standardDelegationProcessor) verifyOneNode(
 		Arguments: [][]byte{node.PubKeyBytes()},
 	}
 
-	vmOutput, _, err := sdp.queryService.ExecuteQuery(scQueryBlsKeys)
+	vmOutput, err := sdp.executeQueryWithRetry(scQueryBlsKeys)
 	if err != nil {
 		return err
 	}
@@ -511,6 +1412,69 @@ func (sdp *standardDelegationProcessor) verifyOneNode(
 	return nil
 }
 
+// PreflightVerifySignatures checks, before any transaction is sent, that all the delegated nodes of the delegation
+// contracts from this shard carry the expected genesis signature
+func (sdp *standardDelegationProcessor) PreflightVerifySignatures() error {
+	smartContracts, err := sdp.getDelegationScOnCurrentShard()
+	if err != nil {
+		return err
+	}
+
+	return sdp.preflightVerifySignatures(smartContracts)
+}
+
+func (sdp *standardDelegationProcessor) preflightVerifySignatures(smartContracts []genesis.InitialSmartContractHandler) error {
+	for _, sc := range smartContracts {
+		delegatedNodes := sdp.nodesListSplitter.GetDelegatedNodes(getDeployedSCAddressBytes(sc))
+		for _, node := range delegatedNodes {
+			if !hasExpectedGenesisSignature(node) {
+				return fmt.Errorf("%w in preflight check for SC %s, owner %s, node %s",
+					genesis.ErrSignatureMismatch, getDeployedSCAddress(sc), sc.GetOwner(),
+					hex.EncodeToString(node.PubKeyBytes()),
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkDelegatedNodesUniqueness errors if the same node public key is delegated more than one time, either to different
+// contracts or to the same contract
+func (sdp *standardDelegationProcessor) checkDelegatedNodesUniqueness(smartContracts []genesis.InitialSmartContractHandler) error {
+	contractsByNode := make(map[string]genesis.InitialSmartContractHandler)
+	for _, sc := range smartContracts {
+		delegatedNodes := sdp.nodesListSplitter.GetDelegatedNodes(getDeployedSCAddressBytes(sc))
+		for _, node := range delegatedNodes {
+			pubKey := string(node.PubKeyBytes())
+			previousSc, found := contractsByNode[pubKey]
+			if found {
+				return fmt.Errorf("%w, node %s delegated to SC %s, owner %s and to SC %s, owner %s",
+					genesis.ErrDuplicatedDelegatedNode, hex.EncodeToString(node.PubKeyBytes()),
+					getDeployedSCAddress(previousSc), previousSc.GetOwner(),
+					getDeployedSCAddress(sc), sc.GetOwner(),
+				)
+			}
+
+			contractsByNode[pubKey] = sc
+		}
+	}
+
+	return nil
+}
+
+// hasExpectedGenesisSignature returns true if the provided node does not carry a signature or carries the genesis signature
+func hasExpectedGenesisSignature(node nodesCoordinator.GenesisNodeInfoHandler) bool {
+	signatureHandler, ok := node.(nodeSignatureHandler)
+	if !ok {
+		return true
+	}
+
+	signature := signatureHandler.Signature()
+
+	return len(signature) == 0 || bytes.Equal(signature, genesisSignature)
+}
+
 // IsInterfaceNil returns if underlying object is true
 func (sdp *standardDelegationProcessor) IsInterfaceNil() bool {
 	return sdp == nil || sdp.TxExecutionProcessor == nil
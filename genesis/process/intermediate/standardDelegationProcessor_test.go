@@ -2,10 +2,14 @@ package intermediate
 
 import (
 	"bytes"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"math/big"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/multiversx/mx-chain-core-go/core/check"
 	coreData "github.com/multiversx/mx-chain-core-go/data"
@@ -16,6 +20,8 @@ import (
 	"github.com/multiversx/mx-chain-go/process"
 	"github.com/multiversx/mx-chain-go/sharding"
 	"github.com/multiversx/mx-chain-go/sharding/nodesCoordinator"
+	stateMock "github.com/multiversx/mx-chain-go/testscommon/state"
+	"github.com/multiversx/mx-chain-go/vm"
 	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
 	"github.com/stretchr/testify/assert"
 )
@@ -29,6 +35,7 @@ func createMockStandardDelegationProcessorArg() ArgStandardDelegationProcessor {
 		NodesListSplitter:   &mock.NodesListSplitterStub{},
 		QueryService:        &mock.QueryServiceStub{},
 		NodePrice:           big.NewInt(10),
+		QueryTimeout:        time.Second,
 	}
 }
 
@@ -120,6 +127,39 @@ func TestNewStandardDelegationProcessor_ZeroNodePriceShouldErr(t *testing.T) {
 	assert.Equal(t, genesis.ErrInvalidInitialNodePrice, err)
 }
 
+func TestNewStandardDelegationProcessor_InvalidQueryTimeoutShouldErr(t *testing.T) {
+	t.Parallel()
+
+	arg := createMockStandardDelegationProcessorArg()
+	arg.QueryTimeout = 0
+	dp, err := NewStandardDelegationProcessor(arg)
+
+	assert.True(t, check.IfNil(dp))
+	assert.Equal(t, genesis.ErrInvalidQueryTimeout, err)
+}
+
+func TestNewStandardDelegationProcessor_InvalidQueryRetryBackoffShouldErr(t *testing.T) {
+	t.Parallel()
+
+	arg := createMockStandardDelegationProcessorArg()
+	arg.QueryRetryBackoff = -time.Millisecond
+	dp, err := NewStandardDelegationProcessor(arg)
+
+	assert.True(t, check.IfNil(dp))
+	assert.Equal(t, genesis.ErrInvalidQueryRetryBackoff, err)
+}
+
+func TestNewStandardDelegationProcessor_NodePriceCheckWithoutFunctionShouldErr(t *testing.T) {
+	t.Parallel()
+
+	arg := createMockStandardDelegationProcessorArg()
+	arg.CheckNodePrice = true
+	dp, err := NewStandardDelegationProcessor(arg)
+
+	assert.True(t, check.IfNil(dp))
+	assert.Equal(t, genesis.ErrEmptyNodePriceFunction, err)
+}
+
 func TestNewStandardDelegationProcessor_ShouldWork(t *testing.T) {
 	t.Parallel()
 
@@ -187,6 +227,7 @@ func TestStandardDelegationProcessor_ExecuteDelegationNoDelegationScShouldRetNil
 
 	assert.Nil(t, err)
 	assert.Equal(t, genesis.DelegationResult{}, result)
+	assert.False(t, result.HadContracts)
 }
 
 func TestStandardDelegationProcessor_ExecuteDelegationStakeShouldWork(t *testing.T) {
@@ -302,8 +343,2095 @@ func TestStandardDelegationProcessor_ExecuteDelegationStakeShouldWork(t *testing
 	expectedResult := genesis.DelegationResult{
 		NumTotalDelegated: 3,
 		NumTotalStaked:    2,
+		NumVerifyQueries:  5,
+		HadContracts:      true,
+		Contracts: []genesis.DelegationContractResult{
+			{NumDelegated: 3, NumStaked: 2},
+		},
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedResult, result)
+}
+
+func TestStandardDelegationProcessor_StakeWithCustomStakeDataEncoder(t *testing.T) {
+	t.Parallel()
+
+	delegationSc := []byte("delegation SC")
+	staker := &data.InitialAccount{
+		Delegation: &data.DelegationData{
+			Value: big.NewInt(0xabc),
+		},
+	}
+	staker.SetAddressBytes([]byte("staker"))
+	staker.Delegation.SetAddressBytes(delegationSc)
+	sc := &data.InitialSmartContract{
+		Type: genesis.DelegationType,
+	}
+	sc.AddAddressBytes(delegationSc)
+
+	t.Run("custom encoder should be used", func(t *testing.T) {
+		t.Parallel()
+
+		var stakeData []byte
+		arg := createMockStandardDelegationProcessorArg()
+		arg.ShardCoordinator = &mock.ShardCoordinatorMock{
+			SelfShardId: 0,
+			NumOfShards: 2,
+		}
+		arg.Executor = &mock.TxExecutionProcessorStub{
+			ExecuteTransactionCalled: func(nonce uint64, sndAddr []byte, rcvAddress []byte, value *big.Int, data []byte) error {
+				stakeData = data
+				return nil
+			},
+		}
+		arg.StakeDataEncoder = func(function string, value *big.Int) string {
+			return function + "@" + hex.EncodeToString(value.FillBytes(make([]byte, 4)))
+		}
+		dp, _ := NewStandardDelegationProcessor(arg)
+
+		err := dp.stake(staker, sc)
+		assert.Nil(t, err)
+		assert.Equal(t, "stakeGenesis@00000abc", string(stakeData))
+	})
+	t.Run("unparsable stake data should error", func(t *testing.T) {
+		t.Parallel()
+
+		arg := createMockStandardDelegationProcessorArg()
+		arg.ShardCoordinator = &mock.ShardCoordinatorMock{
+			SelfShardId: 0,
+			NumOfShards: 2,
+		}
+		arg.Executor = &mock.TxExecutionProcessorStub{
+			ExecuteTransactionCalled: func(nonce uint64, sndAddr []byte, rcvAddress []byte, value *big.Int, data []byte) error {
+				assert.Fail(t, "should have not sent the transaction")
+				return nil
+			},
+		}
+		arg.StakeDataEncoder = PlainHexStakeDataEncoder
+		dp, _ := NewStandardDelegationProcessor(arg)
+
+		err := dp.stake(staker, sc)
+		assert.True(t, errors.Is(err, genesis.ErrInvalidStakeData))
+	})
+	t.Run("nil encoder should default to even-length hex", func(t *testing.T) {
+		t.Parallel()
+
+		var stakeData []byte
+		arg := createMockStandardDelegationProcessorArg()
+		arg.ShardCoordinator = &mock.ShardCoordinatorMock{
+			SelfShardId: 0,
+			NumOfShards: 2,
+		}
+		arg.Executor = &mock.TxExecutionProcessorStub{
+			ExecuteTransactionCalled: func(nonce uint64, sndAddr []byte, rcvAddress []byte, value *big.Int, data []byte) error {
+				stakeData = data
+				return nil
+			},
+		}
+		arg.StakeDataEncoder = nil
+		dp, _ := NewStandardDelegationProcessor(arg)
+
+		err := dp.stake(staker, sc)
+		assert.Nil(t, err)
+		assert.Equal(t, "stakeGenesis@0abc", string(stakeData))
+	})
+}
+
+func TestStandardDelegationProcessor_PreflightVerifySignatures(t *testing.T) {
+	t.Parallel()
+
+	delegationSc := []byte("delegation SC")
+	createArg := func(nodeSignature []byte, numExecutedTxs *int) ArgStandardDelegationProcessor {
+		staker := &data.InitialAccount{
+			Delegation: &data.DelegationData{
+				Value: big.NewInt(2),
+			},
+		}
+		staker.SetAddressBytes([]byte("staker"))
+		staker.Delegation.SetAddressBytes(delegationSc)
+
+		arg := createMockStandardDelegationProcessorArg()
+		arg.Executor = &mock.TxExecutionProcessorStub{
+			ExecuteTransactionCalled: func(nonce uint64, sndAddr []byte, rcvAddress []byte, value *big.Int, data []byte) error {
+				*numExecutedTxs++
+				return nil
+			},
+		}
+		arg.AccountsParser = &mock.AccountsParserStub{
+			GetInitialAccountsForDelegatedCalled: func(addressBytes []byte) []genesis.InitialAccountHandler {
+				return []genesis.InitialAccountHandler{staker}
+			},
+		}
+		arg.SmartContractParser = &mock.SmartContractParserStub{
+			InitialSmartContractsSplitOnOwnersShardsCalled: func(shardCoordinator sharding.Coordinator) (map[uint32][]genesis.InitialSmartContractHandler, error) {
+				sc := &data.InitialSmartContract{
+					Type: genesis.DelegationType,
+				}
+				sc.AddAddressBytes(delegationSc)
+
+				return map[uint32][]genesis.InitialSmartContractHandler{
+					0: {sc},
+				}, nil
+			},
+		}
+		arg.NodesListSplitter = &mock.NodesListSplitterStub{
+			GetDelegatedNodesCalled: func(delegationScAddress []byte) []nodesCoordinator.GenesisNodeInfoHandler {
+				return []nodesCoordinator.GenesisNodeInfoHandler{
+					&mock.GenesisNodeInfoHandlerMock{
+						AddressBytesValue: delegationSc,
+						PubKeyBytesValue:  []byte("pubkey1"),
+					},
+					&mock.GenesisNodeInfoHandlerMock{
+						AddressBytesValue: delegationSc,
+						PubKeyBytesValue:  []byte("pubkey2"),
+						SignatureValue:    nodeSignature,
+					},
+				}
+			},
+		}
+
+		return arg
+	}
+
+	t.Run("expected signatures should work", func(t *testing.T) {
+		t.Parallel()
+
+		numExecutedTxs := 0
+		dp, _ := NewStandardDelegationProcessor(createArg(genesisSignature, &numExecutedTxs))
+
+		err := dp.PreflightVerifySignatures()
+		assert.Nil(t, err)
+	})
+	t.Run("unexpected signature should error", func(t *testing.T) {
+		t.Parallel()
+
+		numExecutedTxs := 0
+		dp, _ := NewStandardDelegationProcessor(createArg([]byte("unexpected signature"), &numExecutedTxs))
+
+		err := dp.PreflightVerifySignatures()
+		assert.True(t, errors.Is(err, genesis.ErrSignatureMismatch))
+		assert.True(t, strings.Contains(err.Error(), hex.EncodeToString([]byte("pubkey2"))))
+	})
+	t.Run("unexpected signature should fail the delegation before sending any transaction", func(t *testing.T) {
+		t.Parallel()
+
+		numExecutedTxs := 0
+		dp, _ := NewStandardDelegationProcessor(createArg([]byte("unexpected signature"), &numExecutedTxs))
+
+		_, _, err := dp.ExecuteDelegation()
+		assert.True(t, errors.Is(err, genesis.ErrSignatureMismatch))
+		assert.Equal(t, 0, numExecutedTxs)
+	})
+}
+
+func TestStandardDelegationProcessor_ExecuteDelegationShouldSkipEmptyContracts(t *testing.T) {
+	t.Parallel()
+
+	emptyDelegationSc := []byte("empty delegation SC")
+	delegationSc := []byte("delegation SC")
+	pubkey := []byte("pubkey")
+
+	numTxsToDelegationSc := 0
+	arg := createMockStandardDelegationProcessorArg()
+	arg.SkipEmptyContracts = true
+	arg.Executor = &mock.TxExecutionProcessorStub{
+		ExecuteTransactionCalled: func(nonce uint64, sndAddr []byte, rcvAddress []byte, value *big.Int, data []byte) error {
+			if bytes.Equal(rcvAddress, emptyDelegationSc) {
+				assert.Fail(t, fmt.Sprintf("should have not sent %s to the empty delegation SC", string(data)))
+			}
+			if bytes.Equal(rcvAddress, delegationSc) {
+				numTxsToDelegationSc++
+			}
+
+			return nil
+		},
+	}
+	arg.ShardCoordinator = &mock.ShardCoordinatorMock{
+		SelfShardId: 0,
+		NumOfShards: 2,
+	}
+	arg.SmartContractParser = &mock.SmartContractParserStub{
+		InitialSmartContractsSplitOnOwnersShardsCalled: func(shardCoordinator sharding.Coordinator) (map[uint32][]genesis.InitialSmartContractHandler, error) {
+			emptySc := &data.InitialSmartContract{
+				Type: genesis.DelegationType,
+			}
+			emptySc.AddAddressBytes(emptyDelegationSc)
+
+			sc := &data.InitialSmartContract{
+				Type: genesis.DelegationType,
+			}
+			sc.AddAddressBytes(delegationSc)
+			sc.AddAddress("delegation SC address")
+
+			return map[uint32][]genesis.InitialSmartContractHandler{
+				0: {emptySc, sc},
+			}, nil
+		},
+	}
+	arg.QueryService = &mock.QueryServiceStub{
+		ExecuteQueryCalled: func(query *process.SCQuery) (*vmcommon.VMOutput, common.BlockInfo, error) {
+			assert.Equal(t, delegationSc, query.ScAddress)
+			if query.FuncName == "getNodeSignature" {
+				return &vmcommon.VMOutput{
+					ReturnData: [][]byte{genesisSignature},
+				}, nil, nil
+			}
+
+			return nil, nil, fmt.Errorf("unexpected function")
+		},
+	}
+	arg.NodesListSplitter = &mock.NodesListSplitterStub{
+		GetDelegatedNodesCalled: func(delegationScAddress []byte) []nodesCoordinator.GenesisNodeInfoHandler {
+			if !bytes.Equal(delegationScAddress, delegationSc) {
+				return nil
+			}
+
+			return []nodesCoordinator.GenesisNodeInfoHandler{
+				&mock.GenesisNodeInfoHandlerMock{
+					AddressBytesValue: delegationSc,
+					PubKeyBytesValue:  pubkey,
+				},
+			}
+		},
+	}
+	dp, _ := NewStandardDelegationProcessor(arg)
+
+	result, _, err := dp.ExecuteDelegation()
+
+	expectedResult := genesis.DelegationResult{
+		NumTotalDelegated: 1,
+		NumTotalStaked:    0,
+		NumVerifyQueries:  1,
+		HadContracts:      true,
+		Contracts: []genesis.DelegationContractResult{
+			{Address: "delegation SC address", NumDelegated: 1, NumStaked: 0},
+		},
+		ContractsWithoutDelegators: []string{"delegation SC address"},
 	}
 
 	assert.Nil(t, err)
 	assert.Equal(t, expectedResult, result)
+	// setStakePerNode, addNodes and activateGenesis
+	assert.Equal(t, 3, numTxsToDelegationSc)
+}
+
+func TestStandardDelegationProcessor_ExecuteDelegationHadContracts(t *testing.T) {
+	t.Parallel()
+
+	delegationSc := []byte("delegation SC")
+	delegator := &data.InitialAccount{
+		Delegation: &data.DelegationData{
+			Value: big.NewInt(2),
+		},
+	}
+	delegator.SetAddressBytes([]byte("delegator"))
+	delegator.Delegation.SetAddressBytes(delegationSc)
+
+	createArg := func(contractType string, delegators []genesis.InitialAccountHandler) ArgStandardDelegationProcessor {
+		arg := createMockStandardDelegationProcessorArg()
+		arg.SkipEmptyContracts = true
+		arg.ShardCoordinator = &mock.ShardCoordinatorMock{
+			SelfShardId: 0,
+			NumOfShards: 2,
+		}
+		arg.SmartContractParser = &mock.SmartContractParserStub{
+			InitialSmartContractsSplitOnOwnersShardsCalled: func(shardCoordinator sharding.Coordinator) (map[uint32][]genesis.InitialSmartContractHandler, error) {
+				sc := &data.InitialSmartContract{
+					Type: contractType,
+				}
+				sc.AddAddressBytes(delegationSc)
+
+				return map[uint32][]genesis.InitialSmartContractHandler{
+					0: {sc},
+				}, nil
+			},
+		}
+		arg.AccountsParser = &mock.AccountsParserStub{
+			GetInitialAccountsForDelegatedCalled: func(addressBytes []byte) []genesis.InitialAccountHandler {
+				return delegators
+			},
+		}
+		arg.QueryService = &mock.QueryServiceStub{
+			ExecuteQueryCalled: func(query *process.SCQuery) (*vmcommon.VMOutput, common.BlockInfo, error) {
+				if query.FuncName == "getUserStake" {
+					return &vmcommon.VMOutput{
+						ReturnData: [][]byte{big.NewInt(2).Bytes()},
+					}, nil, nil
+				}
+
+				return nil, nil, fmt.Errorf("unexpected function")
+			},
+		}
+
+		return arg
+	}
+
+	t.Run("no delegation contract should not have contracts", func(t *testing.T) {
+		t.Parallel()
+
+		dp, _ := NewStandardDelegationProcessor(createArg("test", []genesis.InitialAccountHandler{delegator}))
+
+		result, txs, err := dp.ExecuteDelegation()
+		assert.Nil(t, err)
+		assert.False(t, result.HadContracts)
+		assert.Empty(t, txs)
+	})
+	t.Run("skipped empty delegation contract should not have contracts", func(t *testing.T) {
+		t.Parallel()
+
+		dp, _ := NewStandardDelegationProcessor(createArg(genesis.DelegationType, nil))
+
+		result, txs, err := dp.ExecuteDelegation()
+		assert.Nil(t, err)
+		assert.False(t, result.HadContracts)
+		assert.Empty(t, txs)
+	})
+	t.Run("delegation contract should have contracts", func(t *testing.T) {
+		t.Parallel()
+
+		dp, _ := NewStandardDelegationProcessor(createArg(genesis.DelegationType, []genesis.InitialAccountHandler{delegator}))
+
+		result, _, err := dp.ExecuteDelegation()
+		assert.Nil(t, err)
+		assert.True(t, result.HadContracts)
+		assert.Equal(t, 1, result.NumTotalStaked)
+	})
+}
+
+func TestStandardDelegationProcessor_FilterOutEmptyDelegationContracts(t *testing.T) {
+	t.Parallel()
+
+	emptyDelegationSc := []byte("empty delegation SC")
+	delegationSc := []byte("delegation SC")
+
+	emptySc := &data.InitialSmartContract{Type: genesis.DelegationType}
+	emptySc.AddAddressBytes(emptyDelegationSc)
+	sc := &data.InitialSmartContract{Type: genesis.DelegationType}
+	sc.AddAddressBytes(delegationSc)
+	smartContracts := []genesis.InitialSmartContractHandler{emptySc, sc}
+
+	createProcessor := func(skipEmptyContracts bool) *standardDelegationProcessor {
+		arg := createMockStandardDelegationProcessorArg()
+		arg.SkipEmptyContracts = skipEmptyContracts
+		arg.NodesListSplitter = &mock.NodesListSplitterStub{
+			GetDelegatedNodesCalled: func(delegationScAddress []byte) []nodesCoordinator.GenesisNodeInfoHandler {
+				if !bytes.Equal(delegationScAddress, delegationSc) {
+					return nil
+				}
+
+				return []nodesCoordinator.GenesisNodeInfoHandler{&mock.GenesisNodeInfoHandlerMock{}}
+			},
+		}
+		arg.AccountsParser = &mock.AccountsParserStub{
+			GetInitialAccountsForDelegatedCalled: func(addressBytes []byte) []genesis.InitialAccountHandler {
+				return nil
+			},
+		}
+		dp, _ := NewStandardDelegationProcessor(arg)
+
+		return dp
+	}
+
+	t.Run("should keep all the contracts by default", func(t *testing.T) {
+		t.Parallel()
+
+		dp := createProcessor(false)
+		assert.Equal(t, smartContracts, dp.filterOutEmptyDelegationContracts(smartContracts))
+	})
+	t.Run("should remove the empty contracts if enabled", func(t *testing.T) {
+		t.Parallel()
+
+		dp := createProcessor(true)
+		assert.Equal(t, []genesis.InitialSmartContractHandler{sc}, dp.filterOutEmptyDelegationContracts(smartContracts))
+	})
+}
+
+func TestStandardDelegationProcessor_QueryBigInt(t *testing.T) {
+	t.Parallel()
+
+	scAddress := []byte("delegation SC")
+	funcName := "getUserStake"
+	args := [][]byte{[]byte("delegator")}
+
+	createProcessor := func(returnData [][]byte, queryErr error) *standardDelegationProcessor {
+		arg := createMockStandardDelegationProcessorArg()
+		arg.QueryService = &mock.QueryServiceStub{
+			ExecuteQueryCalled: func(query *process.SCQuery) (*vmcommon.VMOutput, common.BlockInfo, error) {
+				assert.Equal(t, scAddress, query.ScAddress)
+				assert.Equal(t, funcName, query.FuncName)
+				assert.Equal(t, args, query.Arguments)
+
+				return &vmcommon.VMOutput{ReturnData: returnData}, nil, queryErr
+			},
+		}
+		dp, _ := NewStandardDelegationProcessor(arg)
+
+		return dp
+	}
+
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		dp := createProcessor([][]byte{big.NewInt(1234).Bytes()}, nil)
+		value, err := dp.queryBigInt(scAddress, funcName, args)
+		assert.Nil(t, err)
+		assert.Equal(t, big.NewInt(1234), value)
+	})
+	t.Run("query error should error", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := fmt.Errorf("expected error")
+		dp := createProcessor(nil, expectedErr)
+		value, err := dp.queryBigInt(scAddress, funcName, args)
+		assert.Equal(t, expectedErr, err)
+		assert.Nil(t, value)
+	})
+	t.Run("zero return data should error", func(t *testing.T) {
+		t.Parallel()
+
+		dp := createProcessor(make([][]byte, 0), nil)
+		value, err := dp.queryBigInt(scAddress, funcName, args)
+		assert.ErrorIs(t, err, genesis.ErrWhileVerifyingDelegation)
+		assert.Nil(t, value)
+	})
+	t.Run("multiple return data should error", func(t *testing.T) {
+		t.Parallel()
+
+		dp := createProcessor([][]byte{{1}, {2}}, nil)
+		value, err := dp.queryBigInt(scAddress, funcName, args)
+		assert.ErrorIs(t, err, genesis.ErrWhileVerifyingDelegation)
+		assert.Nil(t, value)
+	})
+}
+
+func TestStandardDelegationProcessor_ExecuteQueryWithTimeout(t *testing.T) {
+	t.Parallel()
+
+	scQuery := &process.SCQuery{
+		ScAddress: []byte("delegation SC"),
+		FuncName:  "getUserStake",
+	}
+
+	t.Run("query finishing in time should work", func(t *testing.T) {
+		t.Parallel()
+
+		expectedVmOutput := &vmcommon.VMOutput{ReturnData: [][]byte{[]byte("data")}}
+		arg := createMockStandardDelegationProcessorArg()
+		arg.QueryService = &mock.QueryServiceStub{
+			ExecuteQueryCalled: func(query *process.SCQuery) (*vmcommon.VMOutput, common.BlockInfo, error) {
+				return expectedVmOutput, nil, nil
+			},
+		}
+		dp, _ := NewStandardDelegationProcessor(arg)
+
+		vmOutput, err := dp.executeQueryWithTimeout(scQuery)
+		assert.Nil(t, err)
+		assert.Equal(t, expectedVmOutput, vmOutput)
+	})
+	t.Run("blocking query should error", func(t *testing.T) {
+		t.Parallel()
+
+		chUnblock := make(chan struct{})
+		defer close(chUnblock)
+
+		arg := createMockStandardDelegationProcessorArg()
+		arg.QueryTimeout = time.Millisecond * 100
+		arg.QueryService = &mock.QueryServiceStub{
+			ExecuteQueryCalled: func(query *process.SCQuery) (*vmcommon.VMOutput, common.BlockInfo, error) {
+				<-chUnblock
+				return &vmcommon.VMOutput{}, nil, nil
+			},
+		}
+		dp, _ := NewStandardDelegationProcessor(arg)
+
+		vmOutput, err := dp.executeQueryWithTimeout(scQuery)
+		assert.True(t, errors.Is(err, genesis.ErrQueryTimeout))
+		assert.Nil(t, vmOutput)
+
+		_, err = dp.queryBigInt(scQuery.ScAddress, scQuery.FuncName, nil)
+		assert.True(t, errors.Is(err, genesis.ErrQueryTimeout))
+	})
+}
+
+func TestStandardDelegationProcessor_ExecuteQueryWithRetry(t *testing.T) {
+	t.Parallel()
+
+	scQuery := &process.SCQuery{
+		ScAddress: []byte("delegation SC"),
+		FuncName:  "getUserStake",
+	}
+	expectedErr := errors.New("transient VM error")
+
+	createProcessor := func(maxAttempts uint32, numFailures int, returnData [][]byte, numCalls *int) *standardDelegationProcessor {
+		arg := createMockStandardDelegationProcessorArg()
+		arg.QueryMaxAttempts = maxAttempts
+		arg.QueryRetryBackoff = time.Millisecond
+		arg.QueryService = &mock.QueryServiceStub{
+			ExecuteQueryCalled: func(query *process.SCQuery) (*vmcommon.VMOutput, common.BlockInfo, error) {
+				*numCalls++
+				if *numCalls <= numFailures {
+					return nil, nil, expectedErr
+				}
+
+				return &vmcommon.VMOutput{ReturnData: returnData}, nil, nil
+			},
+		}
+		dp, _ := NewStandardDelegationProcessor(arg)
+
+		return dp
+	}
+
+	t.Run("transient error then success should work", func(t *testing.T) {
+		t.Parallel()
+
+		numCalls := 0
+		dp := createProcessor(3, 2, [][]byte{big.NewInt(1234).Bytes()}, &numCalls)
+
+		value, err := dp.queryBigIntWithRetry(scQuery.ScAddress, scQuery.FuncName, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, big.NewInt(1234), value)
+		assert.Equal(t, 3, numCalls)
+		assert.Zero(t, dp.numVerifyQueries.Get())
+	})
+	t.Run("transient error on all attempts should error", func(t *testing.T) {
+		t.Parallel()
+
+		numCalls := 0
+		dp := createProcessor(2, 3, [][]byte{big.NewInt(1234).Bytes()}, &numCalls)
+
+		vmOutput, err := dp.executeQueryWithRetry(scQuery)
+		assert.True(t, errors.Is(err, expectedErr))
+		assert.True(t, strings.Contains(err.Error(), "after 2 attempts"))
+		assert.Nil(t, vmOutput)
+		assert.Equal(t, 2, numCalls)
+	})
+	t.Run("retry not configured should execute the query once", func(t *testing.T) {
+		t.Parallel()
+
+		numCalls := 0
+		dp := createProcessor(0, 1, [][]byte{big.NewInt(1234).Bytes()}, &numCalls)
+
+		vmOutput, err := dp.executeQueryWithRetry(scQuery)
+		assert.Equal(t, expectedErr, err)
+		assert.Nil(t, vmOutput)
+		assert.Equal(t, 1, numCalls)
+	})
+	t.Run("mismatch error should not be retried", func(t *testing.T) {
+		t.Parallel()
+
+		numCalls := 0
+		dp := createProcessor(3, 0, [][]byte{{1}, {2}}, &numCalls)
+
+		value, err := dp.queryBigIntWithRetry(scQuery.ScAddress, scQuery.FuncName, nil)
+		assert.True(t, errors.Is(err, genesis.ErrWhileVerifyingDelegation))
+		assert.Nil(t, value)
+		assert.Equal(t, 1, numCalls)
+	})
+	t.Run("signature mismatch should not be retried", func(t *testing.T) {
+		t.Parallel()
+
+		numCalls := 0
+		dp := createProcessor(3, 1, [][]byte{[]byte("wrong signature")}, &numCalls)
+		sc := &data.InitialSmartContract{
+			Type: genesis.DelegationType,
+		}
+		sc.AddAddressBytes(scQuery.ScAddress)
+		node := &mock.GenesisNodeInfoHandlerMock{
+			AddressBytesValue: scQuery.ScAddress,
+			PubKeyBytesValue:  []byte("pubkey"),
+		}
+
+		err := dp.verifyOneNode(sc, node)
+		assert.True(t, errors.Is(err, genesis.ErrSignatureMismatch))
+		assert.Equal(t, 2, numCalls)
+	})
+	t.Run("timeout should not be retried", func(t *testing.T) {
+		t.Parallel()
+
+		chBlock := make(chan struct{})
+		defer close(chBlock)
+
+		numCalls := int32(0)
+		mutCalls := sync.Mutex{}
+		arg := createMockStandardDelegationProcessorArg()
+		arg.QueryMaxAttempts = 3
+		arg.QueryRetryBackoff = time.Millisecond
+		arg.QueryTimeout = time.Millisecond * 10
+		arg.QueryService = &mock.QueryServiceStub{
+			ExecuteQueryCalled: func(query *process.SCQuery) (*vmcommon.VMOutput, common.BlockInfo, error) {
+				mutCalls.Lock()
+				numCalls++
+				mutCalls.Unlock()
+
+				<-chBlock
+				return &vmcommon.VMOutput{}, nil, nil
+			},
+		}
+		dp, _ := NewStandardDelegationProcessor(arg)
+
+		vmOutput, err := dp.executeQueryWithRetry(scQuery)
+		assert.True(t, errors.Is(err, genesis.ErrQueryTimeout))
+		assert.False(t, strings.Contains(err.Error(), "attempts"))
+		assert.Nil(t, vmOutput)
+
+		mutCalls.Lock()
+		assert.Equal(t, int32(1), numCalls)
+		mutCalls.Unlock()
+	})
+}
+
+func TestStandardDelegationProcessor_NumVerifyQueries(t *testing.T) {
+	t.Parallel()
+
+	delegationSc := []byte("delegation SC")
+	sc := &data.InitialSmartContract{Type: genesis.DelegationType}
+	sc.AddAddressBytes(delegationSc)
+
+	numDelegators := 2
+	delegators := make([]genesis.InitialAccountHandler, 0, numDelegators)
+	for i := 0; i < numDelegators; i++ {
+		delegator := &data.InitialAccount{
+			Address: fmt.Sprintf("delegator%d", i),
+			Delegation: &data.DelegationData{
+				Value: big.NewInt(2),
+			},
+		}
+		delegator.SetAddressBytes([]byte(delegator.Address))
+		delegator.Delegation.SetAddressBytes(delegationSc)
+		delegators = append(delegators, delegator)
+	}
+
+	numNodes := 3
+	nodes := make([]nodesCoordinator.GenesisNodeInfoHandler, 0, numNodes)
+	for i := 0; i < numNodes; i++ {
+		nodes = append(nodes, &mock.GenesisNodeInfoHandlerMock{
+			AddressBytesValue: delegationSc,
+			PubKeyBytesValue:  []byte(fmt.Sprintf("pubkey%d", i)),
+		})
+	}
+
+	// each query fails once with a transient error, so it is executed twice
+	failedQueries := make(map[string]struct{})
+	numExecutedQueries := 0
+	arg := createMockStandardDelegationProcessorArg()
+	arg.QueryMaxAttempts = 2
+	arg.QueryRetryBackoff = time.Millisecond
+	arg.TotalStakeFunction = "getTotalStakeByType"
+	arg.AccountsParser = &mock.AccountsParserStub{
+		GetInitialAccountsForDelegatedCalled: func(addressBytes []byte) []genesis.InitialAccountHandler {
+			return delegators
+		},
+	}
+	arg.NodesListSplitter = &mock.NodesListSplitterStub{
+		GetDelegatedNodesCalled: func(delegationScAddress []byte) []nodesCoordinator.GenesisNodeInfoHandler {
+			return nodes
+		},
+	}
+	arg.QueryService = &mock.QueryServiceStub{
+		ExecuteQueryCalled: func(query *process.SCQuery) (*vmcommon.VMOutput, common.BlockInfo, error) {
+			numExecutedQueries++
+			queryID := query.FuncName
+			if len(query.Arguments) > 0 {
+				queryID += string(query.Arguments[0])
+			}
+			_, failed := failedQueries[queryID]
+			if !failed {
+				failedQueries[queryID] = struct{}{}
+				return nil, nil, errors.New("transient VM error")
+			}
+
+			switch query.FuncName {
+			case "getUserStake":
+				return &vmcommon.VMOutput{ReturnData: [][]byte{big.NewInt(2).Bytes()}}, nil, nil
+			case "getTotalStakeByType":
+				return &vmcommon.VMOutput{ReturnData: [][]byte{big.NewInt(4).Bytes()}}, nil, nil
+			case "getNodeSignature":
+				return &vmcommon.VMOutput{ReturnData: [][]byte{genesisSignature}}, nil, nil
+			}
+
+			return nil, nil, fmt.Errorf("unexpected function")
+		},
+	}
+	dp, _ := NewStandardDelegationProcessor(arg)
+
+	err := dp.executeVerify([]genesis.InitialSmartContractHandler{sc})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(numDelegators+numNodes), dp.numVerifyQueries.Get())
+	// the total stake query and the retries are executed, but not counted
+	assert.Equal(t, 2*(numDelegators+numNodes+1), numExecutedQueries)
+}
+
+func TestStandardDelegationProcessor_DelegatedNodesByContract(t *testing.T) {
+	t.Parallel()
+
+	t.Run("split fails should error", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := fmt.Errorf("expected error")
+		arg := createMockStandardDelegationProcessorArg()
+		arg.SmartContractParser = &mock.SmartContractParserStub{
+			InitialSmartContractsSplitOnOwnersShardsCalled: func(shardCoordinator sharding.Coordinator) (map[uint32][]genesis.InitialSmartContractHandler, error) {
+				return nil, expectedErr
+			},
+		}
+		dp, _ := NewStandardDelegationProcessor(arg)
+
+		delegatedNodes, err := dp.DelegatedNodesByContract()
+		assert.Equal(t, expectedErr, err)
+		assert.Nil(t, delegatedNodes)
+	})
+	t.Run("should work with multiple contracts", func(t *testing.T) {
+		t.Parallel()
+
+		delegationSc1 := []byte("delegation SC 1")
+		delegationSc2 := []byte("delegation SC 2")
+		delegationSc3 := []byte("delegation SC 3")
+		mapNodes := map[string][][]byte{
+			string(delegationSc1): {[]byte("pubkey1"), []byte("pubkey2")},
+			string(delegationSc2): {[]byte("pubkey3"), []byte("pubkey4"), []byte("pubkey5")},
+		}
+
+		arg := createMockStandardDelegationProcessorArg()
+		arg.ShardCoordinator = &mock.ShardCoordinatorMock{
+			SelfShardId: 0,
+			NumOfShards: 2,
+		}
+		arg.SmartContractParser = &mock.SmartContractParserStub{
+			InitialSmartContractsSplitOnOwnersShardsCalled: func(shardCoordinator sharding.Coordinator) (map[uint32][]genesis.InitialSmartContractHandler, error) {
+				scs := make([]genesis.InitialSmartContractHandler, 0)
+				for _, address := range [][]byte{delegationSc1, delegationSc2, delegationSc3} {
+					sc := &data.InitialSmartContract{
+						Type: genesis.DelegationType,
+					}
+					sc.AddAddressBytes(address)
+					scs = append(scs, sc)
+				}
+
+				notDelegationSc := &data.InitialSmartContract{
+					Type: "test",
+				}
+				notDelegationSc.AddAddressBytes([]byte("not a delegation SC"))
+				scs = append(scs, notDelegationSc)
+
+				return map[uint32][]genesis.InitialSmartContractHandler{
+					0: scs,
+				}, nil
+			},
+		}
+		arg.NodesListSplitter = &mock.NodesListSplitterStub{
+			GetDelegatedNodesCalled: func(delegationScAddress []byte) []nodesCoordinator.GenesisNodeInfoHandler {
+				nodes := make([]nodesCoordinator.GenesisNodeInfoHandler, 0)
+				for _, pubKey := range mapNodes[string(delegationScAddress)] {
+					nodes = append(nodes, &mock.GenesisNodeInfoHandlerMock{
+						AddressBytesValue: delegationScAddress,
+						PubKeyBytesValue:  pubKey,
+					})
+				}
+
+				return nodes
+			},
+		}
+		dp, _ := NewStandardDelegationProcessor(arg)
+
+		delegatedNodes, err := dp.DelegatedNodesByContract()
+		assert.Nil(t, err)
+
+		expectedDelegatedNodes := map[string][][]byte{
+			hex.EncodeToString(delegationSc1): mapNodes[string(delegationSc1)],
+			hex.EncodeToString(delegationSc2): mapNodes[string(delegationSc2)],
+			hex.EncodeToString(delegationSc3): {},
+		}
+		assert.Equal(t, expectedDelegatedNodes, delegatedNodes)
+	})
+}
+
+func TestStandardDelegationProcessor_ExecuteDelegationNonceNotAdvancedShouldErr(t *testing.T) {
+	t.Parallel()
+
+	owner := []byte("owner")
+	arg := createMockStandardDelegationProcessorArg()
+	arg.Executor = &mock.TxExecutionProcessorStub{
+		GetNonceCalled: func(senderBytes []byte) (uint64, error) {
+			return 5, nil
+		},
+	}
+	arg.ShardCoordinator = &mock.ShardCoordinatorMock{
+		SelfShardId: 0,
+		NumOfShards: 2,
+	}
+	arg.SmartContractParser = &mock.SmartContractParserStub{
+		InitialSmartContractsSplitOnOwnersShardsCalled: func(shardCoordinator sharding.Coordinator) (map[uint32][]genesis.InitialSmartContractHandler, error) {
+			scs := make([]genesis.InitialSmartContractHandler, 0)
+			for _, address := range [][]byte{[]byte("delegation SC 1"), []byte("delegation SC 2")} {
+				sc := &data.InitialSmartContract{
+					Type: genesis.DelegationType,
+				}
+				sc.SetOwnerBytes(owner)
+				sc.AddAddressBytes(address)
+				scs = append(scs, sc)
+			}
+
+			return map[uint32][]genesis.InitialSmartContractHandler{
+				0: scs,
+			}, nil
+		},
+	}
+	arg.NodesListSplitter = &mock.NodesListSplitterStub{
+		GetDelegatedNodesCalled: func(delegationScAddress []byte) []nodesCoordinator.GenesisNodeInfoHandler {
+			return []nodesCoordinator.GenesisNodeInfoHandler{
+				&mock.GenesisNodeInfoHandlerMock{
+					AddressBytesValue: delegationScAddress,
+					PubKeyBytesValue:  append([]byte("pubkey of "), delegationScAddress...),
+				},
+			}
+		},
+	}
+	dp, _ := NewStandardDelegationProcessor(arg)
+
+	_, _, err := dp.ExecuteDelegation()
+	assert.True(t, errors.Is(err, genesis.ErrNonceNotIncreased))
+	assert.True(t, strings.Contains(err.Error(), setStakePerNodeFunction))
+}
+
+func TestOwnerNonceChecker_Check(t *testing.T) {
+	t.Parallel()
+
+	checker := newOwnerNonceChecker("phase")
+	assert.Nil(t, checker.check([]byte("owner1"), 5))
+	assert.Nil(t, checker.check([]byte("owner2"), 5))
+	assert.Nil(t, checker.check([]byte("owner1"), 6))
+
+	err := checker.check([]byte("owner1"), 6)
+	assert.True(t, errors.Is(err, genesis.ErrNonceNotIncreased))
+
+	err = checker.check([]byte("owner2"), 4)
+	assert.True(t, errors.Is(err, genesis.ErrNonceNotIncreased))
+}
+
+func TestStandardDelegationProcessor_ExecuteActivation(t *testing.T) {
+	t.Parallel()
+
+	activeSc := &data.InitialSmartContract{Type: genesis.DelegationType}
+	activeSc.SetOwnerBytes([]byte("owner1"))
+	activeSc.AddAddressBytes([]byte("active SC"))
+	inactiveSc := &data.InitialSmartContract{Type: genesis.DelegationType}
+	inactiveSc.SetOwnerBytes([]byte("owner2"))
+	inactiveSc.AddAddressBytes([]byte("inactive SC"))
+	unknownSc := &data.InitialSmartContract{Type: genesis.DelegationType}
+	unknownSc.SetOwnerBytes([]byte("owner3"))
+	unknownSc.AddAddressBytes([]byte("unknown SC"))
+	smartContracts := []genesis.InitialSmartContractHandler{activeSc, inactiveSc, unknownSc}
+
+	createArg := func(activatedContracts *[]string, numQueries *int) ArgStandardDelegationProcessor {
+		arg := createMockStandardDelegationProcessorArg()
+		arg.Executor = &mock.TxExecutionProcessorStub{
+			ExecuteTransactionCalled: func(nonce uint64, sndAddr []byte, rcvAddress []byte, value *big.Int, data []byte) error {
+				assert.Equal(t, activateFunction, string(data))
+				*activatedContracts = append(*activatedContracts, string(rcvAddress))
+				return nil
+			},
+		}
+		arg.QueryService = &mock.QueryServiceStub{
+			ExecuteQueryCalled: func(query *process.SCQuery) (*vmcommon.VMOutput, common.BlockInfo, error) {
+				*numQueries++
+				assert.Equal(t, "isGenesisActive", query.FuncName)
+
+				switch string(query.ScAddress) {
+				case "active SC":
+					return &vmcommon.VMOutput{ReturnData: [][]byte{{1}}}, nil, nil
+				case "inactive SC":
+					return &vmcommon.VMOutput{ReturnData: [][]byte{{}}}, nil, nil
+				default:
+					return nil, nil, errors.New("unknown function")
+				}
+			},
+		}
+
+		return arg
+	}
+
+	t.Run("no activation status function should activate all contracts", func(t *testing.T) {
+		t.Parallel()
+
+		activatedContracts := make([]string, 0)
+		numQueries := 0
+		arg := createArg(&activatedContracts, &numQueries)
+		dp, _ := NewStandardDelegationProcessor(arg)
+
+		err := dp.executeActivation(smartContracts)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"active SC", "inactive SC", "unknown SC"}, activatedContracts)
+		assert.Zero(t, numQueries)
+	})
+	t.Run("already active contract should be skipped", func(t *testing.T) {
+		t.Parallel()
+
+		activatedContracts := make([]string, 0)
+		numQueries := 0
+		arg := createArg(&activatedContracts, &numQueries)
+		arg.ActivationStatusFunction = "isGenesisActive"
+		dp, _ := NewStandardDelegationProcessor(arg)
+
+		err := dp.executeActivation(smartContracts)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"inactive SC", "unknown SC"}, activatedContracts)
+		assert.Equal(t, 3, numQueries)
+	})
+}
+
+func TestStandardDelegationProcessor_VerifyStakedValue(t *testing.T) {
+	t.Parallel()
+
+	delegationSc := []byte("delegation SC")
+	sc := &data.InitialSmartContract{Type: genesis.DelegationType}
+	sc.AddAddressBytes(delegationSc)
+
+	createDelegator := func(address string, value int64) *data.InitialAccount {
+		delegator := &data.InitialAccount{
+			Delegation: &data.DelegationData{
+				Value: big.NewInt(value),
+			},
+		}
+		delegator.SetAddressBytes([]byte(address))
+		delegator.Delegation.SetAddressBytes(delegationSc)
+
+		return delegator
+	}
+	delegator1 := createDelegator("delegator1", 3)
+	delegator2 := createDelegator("delegator2", 5)
+
+	createArg := func(scTotalStakeByType ...*big.Int) ArgStandardDelegationProcessor {
+		arg := createMockStandardDelegationProcessorArg()
+		arg.TotalStakeFunction = "getTotalStakeByType"
+		arg.AccountsParser = &mock.AccountsParserStub{
+			GetInitialAccountsForDelegatedCalled: func(addressBytes []byte) []genesis.InitialAccountHandler {
+				return []genesis.InitialAccountHandler{delegator1, delegator2}
+			},
+		}
+		arg.QueryService = &mock.QueryServiceStub{
+			ExecuteQueryCalled: func(query *process.SCQuery) (*vmcommon.VMOutput, common.BlockInfo, error) {
+				switch query.FuncName {
+				case "getUserStake":
+					if bytes.Equal(query.Arguments[0], delegator1.AddressBytes()) {
+						return &vmcommon.VMOutput{ReturnData: [][]byte{delegator1.Delegation.Value.Bytes()}}, nil, nil
+					}
+					return &vmcommon.VMOutput{ReturnData: [][]byte{delegator2.Delegation.Value.Bytes()}}, nil, nil
+				case "getTotalStakeByType":
+					returnData := make([][]byte, 0, len(scTotalStakeByType))
+					for _, value := range scTotalStakeByType {
+						returnData = append(returnData, value.Bytes())
+					}
+					return &vmcommon.VMOutput{ReturnData: returnData}, nil, nil
+				default:
+					return nil, nil, fmt.Errorf("unexpected function")
+				}
+			},
+		}
+
+		return arg
+	}
+
+	t.Run("matching totals should work", func(t *testing.T) {
+		t.Parallel()
+
+		dp, _ := NewStandardDelegationProcessor(createArg(big.NewInt(0), big.NewInt(6), big.NewInt(2)))
+
+		expectedTotal, err := dp.computeExpectedTotalStake(sc)
+		assert.Nil(t, err)
+		assert.Equal(t, big.NewInt(8), expectedTotal)
+
+		err = dp.verifyStakedValue(sc)
+		assert.Nil(t, err)
+	})
+	t.Run("mismatching totals should error", func(t *testing.T) {
+		t.Parallel()
+
+		dp, _ := NewStandardDelegationProcessor(createArg(big.NewInt(0), big.NewInt(7)))
+
+		err := dp.verifyStakedValue(sc)
+		assert.True(t, errors.Is(err, genesis.ErrWhileVerifyingDelegation))
+		assert.True(t, strings.Contains(err.Error(), "total staked value mismatch"))
+	})
+	t.Run("empty return data should error", func(t *testing.T) {
+		t.Parallel()
+
+		dp, _ := NewStandardDelegationProcessor(createArg())
+
+		err := dp.verifyStakedValue(sc)
+		assert.True(t, errors.Is(err, genesis.ErrWhileVerifyingDelegation))
+		assert.True(t, strings.Contains(err.Error(), "at least one element"))
+	})
+	t.Run("empty total stake function should not query the total stake", func(t *testing.T) {
+		t.Parallel()
+
+		arg := createArg(big.NewInt(7))
+		arg.TotalStakeFunction = ""
+		dp, _ := NewStandardDelegationProcessor(arg)
+
+		err := dp.verifyStakedValue(sc)
+		assert.Nil(t, err)
+	})
+}
+
+func TestStandardDelegationProcessor_ComputeExpectedTotalStakeConcurrently(t *testing.T) {
+	t.Parallel()
+
+	delegationSc := []byte("delegation SC")
+	sc := &data.InitialSmartContract{Type: genesis.DelegationType}
+	sc.AddAddressBytes(delegationSc)
+
+	numDelegators := 10
+	delegators := make([]genesis.InitialAccountHandler, 0, numDelegators)
+	delegatorsIndexes := make(map[string]int)
+	for i := 0; i < numDelegators; i++ {
+		delegator := &data.InitialAccount{
+			Address: fmt.Sprintf("delegator%d", i),
+			Delegation: &data.DelegationData{
+				Value: big.NewInt(int64(i + 1)),
+			},
+		}
+		delegator.SetAddressBytes([]byte(delegator.Address))
+		delegator.Delegation.SetAddressBytes(delegationSc)
+
+		delegators = append(delegators, delegator)
+		delegatorsIndexes[delegator.Address] = i
+	}
+
+	// the later delegators respond faster, so the verification errors are produced in the reverse order
+	createArg := func(numWorkers uint32, collectAll bool, getStakedValue func(idx int) (*big.Int, error)) (ArgStandardDelegationProcessor, *int32) {
+		numQueries := int32(0)
+		mutQueries := sync.Mutex{}
+
+		arg := createMockStandardDelegationProcessorArg()
+		arg.VerifyNumWorkers = numWorkers
+		arg.CollectAllVerifyErrors = collectAll
+		arg.AccountsParser = &mock.AccountsParserStub{
+			GetInitialAccountsForDelegatedCalled: func(addressBytes []byte) []genesis.InitialAccountHandler {
+				return delegators
+			},
+		}
+		arg.QueryService = &mock.QueryServiceStub{
+			ExecuteQueryCalled: func(query *process.SCQuery) (*vmcommon.VMOutput, common.BlockInfo, error) {
+				mutQueries.Lock()
+				numQueries++
+				mutQueries.Unlock()
+
+				idx := delegatorsIndexes[string(query.Arguments[0])]
+				time.Sleep(time.Millisecond * time.Duration(numDelegators-idx))
+
+				value, err := getStakedValue(idx)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				return &vmcommon.VMOutput{ReturnData: [][]byte{value.Bytes()}}, nil, nil
+			},
+			SupportsConcurrentQueriesCalled: func() bool {
+				return true
+			},
+		}
+
+		return arg, &numQueries
+	}
+
+	t.Run("should report all the mismatches in the delegators order", func(t *testing.T) {
+		t.Parallel()
+
+		arg, _ := createArg(4, false, func(idx int) (*big.Int, error) {
+			if idx%3 == 0 {
+				return big.NewInt(100), nil
+			}
+
+			return big.NewInt(int64(idx + 1)), nil
+		})
+		dp, _ := NewStandardDelegationProcessor(arg)
+
+		for i := 0; i < 5; i++ {
+			expectedTotal, err := dp.computeExpectedTotalStake(sc)
+			assert.Nil(t, expectedTotal)
+			assert.True(t, errors.Is(err, genesis.ErrWhileVerifyingDelegation))
+
+			message := err.Error()
+			assert.True(t, strings.Contains(message, "3 more delegators failed the verification"))
+			lastPosition := -1
+			for _, idx := range []int{0, 3, 6, 9} {
+				position := strings.Index(message, fmt.Sprintf("account delegator%d", idx))
+				assert.True(t, position > lastPosition)
+				lastPosition = position
+			}
+		}
+	})
+	t.Run("should return the same error as the serial verification", func(t *testing.T) {
+		t.Parallel()
+
+		getStakedValue := func(idx int) (*big.Int, error) {
+			if idx == 2 || idx == 5 {
+				return big.NewInt(0), nil
+			}
+
+			return big.NewInt(int64(idx + 1)), nil
+		}
+
+		argSerial, _ := createArg(0, false, getStakedValue)
+		dpSerial, _ := NewStandardDelegationProcessor(argSerial)
+		argConcurrent, _ := createArg(uint32(numDelegators), false, getStakedValue)
+		dpConcurrent, _ := NewStandardDelegationProcessor(argConcurrent)
+
+		_, errSerial := dpSerial.computeExpectedTotalStake(sc)
+		_, errConcurrent := dpConcurrent.computeExpectedTotalStake(sc)
+		assert.NotNil(t, errSerial)
+		assert.Equal(t, errSerial.Error(), errConcurrent.Error())
+	})
+	t.Run("matching values should work", func(t *testing.T) {
+		t.Parallel()
+
+		arg, numQueries := createArg(3, false, func(idx int) (*big.Int, error) {
+			return big.NewInt(int64(idx + 1)), nil
+		})
+		dp, _ := NewStandardDelegationProcessor(arg)
+
+		expectedTotal, err := dp.computeExpectedTotalStake(sc)
+		assert.Nil(t, err)
+		assert.Equal(t, big.NewInt(55), expectedTotal)
+		assert.Equal(t, int32(numDelegators), *numQueries)
+	})
+	t.Run("query service without concurrent queries support should verify sequentially", func(t *testing.T) {
+		t.Parallel()
+
+		arg, _ := createArg(4, false, func(idx int) (*big.Int, error) {
+			return big.NewInt(int64(idx + 1)), nil
+		})
+		queryService := arg.QueryService.(*mock.QueryServiceStub)
+		queryService.SupportsConcurrentQueriesCalled = nil
+		executeQuery := queryService.ExecuteQueryCalled
+		numRunningQueries := int32(0)
+		maxRunningQueries := int32(0)
+		mutRunning := sync.Mutex{}
+		queryService.ExecuteQueryCalled = func(query *process.SCQuery) (*vmcommon.VMOutput, common.BlockInfo, error) {
+			mutRunning.Lock()
+			numRunningQueries++
+			if numRunningQueries > maxRunningQueries {
+				maxRunningQueries = numRunningQueries
+			}
+			mutRunning.Unlock()
+
+			defer func() {
+				mutRunning.Lock()
+				numRunningQueries--
+				mutRunning.Unlock()
+			}()
+
+			return executeQuery(query)
+		}
+		dp, _ := NewStandardDelegationProcessor(arg)
+
+		expectedTotal, err := dp.computeExpectedTotalStake(sc)
+		assert.Nil(t, err)
+		assert.Equal(t, big.NewInt(55), expectedTotal)
+		assert.Equal(t, int32(1), maxRunningQueries)
+	})
+	t.Run("transient error should stop the verification", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		arg, numQueries := createArg(2, false, func(idx int) (*big.Int, error) {
+			if idx == 0 {
+				return nil, expectedErr
+			}
+
+			return big.NewInt(int64(idx + 1)), nil
+		})
+		dp, _ := NewStandardDelegationProcessor(arg)
+
+		expectedTotal, err := dp.computeExpectedTotalStake(sc)
+		assert.Nil(t, expectedTotal)
+		assert.Equal(t, expectedErr, err)
+		assert.True(t, *numQueries < int32(numDelegators))
+	})
+	t.Run("timeout should stop the verification in collect all mode", func(t *testing.T) {
+		t.Parallel()
+
+		arg, numQueries := createArg(1, true, func(idx int) (*big.Int, error) {
+			if idx == 0 {
+				return nil, fmt.Errorf("%w after 1ms", genesis.ErrQueryTimeout)
+			}
+
+			return big.NewInt(int64(idx + 1)), nil
+		})
+		dp, _ := NewStandardDelegationProcessor(arg)
+
+		expectedTotal, err := dp.computeExpectedTotalStake(sc)
+		assert.Nil(t, expectedTotal)
+		assert.True(t, errors.Is(err, genesis.ErrQueryTimeout))
+		assert.Equal(t, int32(1), *numQueries)
+	})
+	t.Run("transient error should not stop the verification in collect all mode", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		arg, numQueries := createArg(2, true, func(idx int) (*big.Int, error) {
+			if idx == 0 {
+				return nil, expectedErr
+			}
+			if idx == 7 {
+				return big.NewInt(0), nil
+			}
+
+			return big.NewInt(int64(idx + 1)), nil
+		})
+		dp, _ := NewStandardDelegationProcessor(arg)
+
+		expectedTotal, err := dp.computeExpectedTotalStake(sc)
+		assert.Nil(t, expectedTotal)
+		assert.True(t, errors.Is(err, expectedErr))
+		assert.True(t, strings.Contains(err.Error(), "1 more delegators failed the verification"))
+		assert.True(t, strings.Contains(err.Error(), "account delegator7"))
+		assert.Equal(t, int32(numDelegators), *numQueries)
+	})
+}
+
+func TestStandardDelegationProcessor_ExecuteDelegationDuplicatedNodeShouldErr(t *testing.T) {
+	t.Parallel()
+
+	numExecutedTxs := 0
+	arg := createMockStandardDelegationProcessorArg()
+	arg.Executor = &mock.TxExecutionProcessorStub{
+		ExecuteTransactionCalled: func(nonce uint64, sndAddr []byte, rcvAddress []byte, value *big.Int, data []byte) error {
+			numExecutedTxs++
+			return nil
+		},
+	}
+	arg.ShardCoordinator = &mock.ShardCoordinatorMock{
+		SelfShardId: 0,
+		NumOfShards: 2,
+	}
+	arg.SmartContractParser = &mock.SmartContractParserStub{
+		InitialSmartContractsSplitOnOwnersShardsCalled: func(shardCoordinator sharding.Coordinator) (map[uint32][]genesis.InitialSmartContractHandler, error) {
+			scs := make([]genesis.InitialSmartContractHandler, 0)
+			for _, address := range []string{"delegation SC 1", "delegation SC 2"} {
+				sc := &data.InitialSmartContract{
+					Type: genesis.DelegationType,
+				}
+				sc.SetOwnerBytes([]byte("owner of " + address))
+				sc.AddAddressBytes([]byte(address))
+				sc.AddAddress(address)
+				scs = append(scs, sc)
+			}
+
+			return map[uint32][]genesis.InitialSmartContractHandler{
+				0: scs,
+			}, nil
+		},
+	}
+	arg.NodesListSplitter = &mock.NodesListSplitterStub{
+		GetDelegatedNodesCalled: func(delegationScAddress []byte) []nodesCoordinator.GenesisNodeInfoHandler {
+			nodes := []nodesCoordinator.GenesisNodeInfoHandler{
+				&mock.GenesisNodeInfoHandlerMock{
+					AddressBytesValue: delegationScAddress,
+					PubKeyBytesValue:  append([]byte("pubkey of "), delegationScAddress...),
+				},
+				&mock.GenesisNodeInfoHandlerMock{
+					AddressBytesValue: delegationScAddress,
+					PubKeyBytesValue:  []byte("duplicated pubkey"),
+				},
+			}
+
+			return nodes
+		},
+	}
+	dp, _ := NewStandardDelegationProcessor(arg)
+
+	_, _, err := dp.ExecuteDelegation()
+	assert.True(t, errors.Is(err, genesis.ErrDuplicatedDelegatedNode))
+	assert.True(t, strings.Contains(err.Error(), hex.EncodeToString([]byte("duplicated pubkey"))))
+	assert.True(t, strings.Contains(err.Error(), "delegation SC 1"))
+	assert.True(t, strings.Contains(err.Error(), "delegation SC 2"))
+	assert.Equal(t, 0, numExecutedTxs)
+}
+
+func TestStandardDelegationProcessor_ExecuteDelegationTxDataTooLongShouldErr(t *testing.T) {
+	t.Parallel()
+
+	delegationSc := []byte("delegation SC")
+	executedTxsData := make([]string, 0)
+	arg := createMockStandardDelegationProcessorArg()
+	arg.MaxTxDataLength = 100
+	arg.Executor = &mock.TxExecutionProcessorStub{
+		ExecuteTransactionCalled: func(nonce uint64, sndAddr []byte, rcvAddress []byte, value *big.Int, data []byte) error {
+			executedTxsData = append(executedTxsData, string(data))
+			return nil
+		},
+	}
+	arg.ShardCoordinator = &mock.ShardCoordinatorMock{
+		SelfShardId: 0,
+		NumOfShards: 2,
+	}
+	arg.SmartContractParser = &mock.SmartContractParserStub{
+		InitialSmartContractsSplitOnOwnersShardsCalled: func(shardCoordinator sharding.Coordinator) (map[uint32][]genesis.InitialSmartContractHandler, error) {
+			sc := &data.InitialSmartContract{
+				Type: genesis.DelegationType,
+			}
+			sc.AddAddressBytes(delegationSc)
+			sc.AddAddress(string(delegationSc))
+
+			return map[uint32][]genesis.InitialSmartContractHandler{
+				0: {sc},
+			}, nil
+		},
+	}
+	arg.NodesListSplitter = &mock.NodesListSplitterStub{
+		GetDelegatedNodesCalled: func(delegationScAddress []byte) []nodesCoordinator.GenesisNodeInfoHandler {
+			return []nodesCoordinator.GenesisNodeInfoHandler{
+				&mock.GenesisNodeInfoHandlerMock{
+					AddressBytesValue: delegationSc,
+					PubKeyBytesValue:  []byte("pubkey1"),
+				},
+				&mock.GenesisNodeInfoHandlerMock{
+					AddressBytesValue: delegationSc,
+					PubKeyBytesValue:  []byte("pubkey2"),
+				},
+			}
+		},
+	}
+	dp, _ := NewStandardDelegationProcessor(arg)
+
+	_, _, err := dp.ExecuteDelegation()
+	assert.True(t, errors.Is(err, genesis.ErrTxDataTooLong))
+	assert.True(t, strings.Contains(err.Error(), addNodesFunction))
+	assert.True(t, strings.Contains(err.Error(), string(delegationSc)))
+	// only the setStakePerNode transaction was sent
+	assert.Equal(t, 1, len(executedTxsData))
+	assert.True(t, strings.HasPrefix(executedTxsData[0], setStakePerNodeFunction))
+}
+
+func TestStandardDelegationProcessor_ExecuteDelegationFailureShouldRevert(t *testing.T) {
+	t.Parallel()
+
+	delegationSc := []byte("delegation SC")
+	staker := &data.InitialAccount{
+		Delegation: &data.DelegationData{
+			Value: big.NewInt(2),
+		},
+	}
+	staker.SetAddressBytes([]byte("staker"))
+	staker.Delegation.SetAddressBytes(delegationSc)
+
+	// each executed transaction adds a journal entry, while the failing function, if any, errors
+	createArg := func(journal *[]string, executedTxs *[]string, failingFunction string) ArgStandardDelegationProcessor {
+		arg := createMockStandardDelegationProcessorArg()
+		arg.TotalStakeFunction = "getTotalStakeByType"
+		arg.Executor = &mock.TxExecutionProcessorStub{
+			ExecuteTransactionCalled: func(nonce uint64, sndAddr []byte, rcvAddress []byte, value *big.Int, data []byte) error {
+				*journal = append(*journal, string(data))
+				*executedTxs = append(*executedTxs, string(data))
+				if len(failingFunction) > 0 && strings.HasPrefix(string(data), failingFunction) {
+					return expectedErr
+				}
+
+				return nil
+			},
+			NumExecutedTransactionsCalled: func() int {
+				return len(*executedTxs)
+			},
+			RevertExecutedTransactionsCalled: func(numTransactions int) {
+				*executedTxs = (*executedTxs)[:numTransactions]
+			},
+		}
+		arg.ShardCoordinator = &mock.ShardCoordinatorMock{
+			SelfShardId: 0,
+			NumOfShards: 2,
+		}
+		arg.AccountsParser = &mock.AccountsParserStub{
+			GetInitialAccountsForDelegatedCalled: func(addressBytes []byte) []genesis.InitialAccountHandler {
+				return []genesis.InitialAccountHandler{staker}
+			},
+		}
+		arg.SmartContractParser = &mock.SmartContractParserStub{
+			InitialSmartContractsSplitOnOwnersShardsCalled: func(shardCoordinator sharding.Coordinator) (map[uint32][]genesis.InitialSmartContractHandler, error) {
+				sc := &data.InitialSmartContract{
+					Type: genesis.DelegationType,
+				}
+				sc.AddAddressBytes(delegationSc)
+
+				return map[uint32][]genesis.InitialSmartContractHandler{
+					0: {sc},
+				}, nil
+			},
+		}
+		arg.QueryService = &mock.QueryServiceStub{
+			ExecuteQueryCalled: func(query *process.SCQuery) (*vmcommon.VMOutput, common.BlockInfo, error) {
+				switch query.FuncName {
+				case "getUserStake":
+					return &vmcommon.VMOutput{ReturnData: [][]byte{staker.Delegation.Value.Bytes()}}, nil, nil
+				case "getTotalStakeByType":
+					// the contract reports a different total stake, so the verification fails
+					return &vmcommon.VMOutput{ReturnData: [][]byte{big.NewInt(3).Bytes()}}, nil, nil
+				default:
+					return nil, nil, fmt.Errorf("unexpected function")
+				}
+			},
+		}
+		arg.NodesListSplitter = &mock.NodesListSplitterStub{
+			GetDelegatedNodesCalled: func(delegationScAddress []byte) []nodesCoordinator.GenesisNodeInfoHandler {
+				return []nodesCoordinator.GenesisNodeInfoHandler{
+					&mock.GenesisNodeInfoHandlerMock{
+						AddressBytesValue: delegationSc,
+						PubKeyBytesValue:  []byte("pubkey"),
+					},
+				}
+			},
+		}
+
+		return arg
+	}
+	createAccounts := func(journal *[]string, revertCalled *bool) *stateMock.AccountsStub {
+		return &stateMock.AccountsStub{
+			JournalLenCalled: func() int {
+				return len(*journal)
+			},
+			RevertToSnapshotCalled: func(snapshot int) error {
+				*revertCalled = true
+				*journal = (*journal)[:snapshot]
+				return nil
+			},
+		}
+	}
+
+	t.Run("without accounts the state should not be reverted", func(t *testing.T) {
+		t.Parallel()
+
+		journal := []string{"previous change"}
+		executedTxs := []string{"previous tx"}
+		dp, _ := NewStandardDelegationProcessor(createArg(&journal, &executedTxs, ""))
+
+		_, _, err := dp.ExecuteDelegation()
+		assert.True(t, errors.Is(err, genesis.ErrWhileVerifyingDelegation))
+		assert.Equal(t, 5, len(journal))
+		assert.Equal(t, 5, len(executedTxs))
+	})
+
+	phasesFailures := []struct {
+		phase           string
+		failingFunction string
+		expectedErr     error
+	}{
+		{phase: "set start parameters", failingFunction: setStakePerNodeFunction, expectedErr: expectedErr},
+		{phase: "manage BLS keys", failingFunction: addNodesFunction, expectedErr: expectedErr},
+		{phase: "stake", failingFunction: stakeFunction, expectedErr: expectedErr},
+		{phase: "activation", failingFunction: activateFunction, expectedErr: expectedErr},
+		{phase: "verify", failingFunction: "", expectedErr: genesis.ErrWhileVerifyingDelegation},
+	}
+	for _, phaseFailure := range phasesFailures {
+		pf := phaseFailure
+		t.Run(pf.phase+" failure should revert the accounts state and the executed transactions", func(t *testing.T) {
+			t.Parallel()
+
+			journal := []string{"previous change"}
+			executedTxs := []string{"previous tx"}
+			revertCalled := false
+			arg := createArg(&journal, &executedTxs, pf.failingFunction)
+			arg.Accounts = createAccounts(&journal, &revertCalled)
+			dp, _ := NewStandardDelegationProcessor(arg)
+
+			result, txs, err := dp.ExecuteDelegation()
+			assert.True(t, errors.Is(err, pf.expectedErr))
+			assert.Equal(t, genesis.DelegationResult{}, result)
+			assert.Nil(t, txs)
+			assert.True(t, revertCalled)
+			assert.Equal(t, []string{"previous change"}, journal)
+			assert.Equal(t, []string{"previous tx"}, executedTxs)
+		})
+	}
+
+	t.Run("revert failure should return the original error", func(t *testing.T) {
+		t.Parallel()
+
+		journal := make([]string, 0)
+		executedTxs := make([]string, 0)
+		errRevert := errors.New("revert error")
+		arg := createArg(&journal, &executedTxs, "")
+		arg.Accounts = &stateMock.AccountsStub{
+			JournalLenCalled: func() int {
+				return len(journal)
+			},
+			RevertToSnapshotCalled: func(snapshot int) error {
+				return errRevert
+			},
+		}
+		dp, _ := NewStandardDelegationProcessor(arg)
+
+		_, _, err := dp.ExecuteDelegation()
+		assert.True(t, errors.Is(err, genesis.ErrWhileVerifyingDelegation))
+		assert.True(t, strings.Contains(err.Error(), errRevert.Error()))
+		assert.Equal(t, 0, len(executedTxs))
+	})
+}
+
+func TestStandardDelegationProcessor_ExecuteStakeBatched(t *testing.T) {
+	t.Parallel()
+
+	delegationSc := []byte("delegation SC")
+	owner := []byte("owner")
+	sc := &data.InitialSmartContract{Type: genesis.DelegationType}
+	sc.SetOwnerBytes(owner)
+	sc.AddAddressBytes(delegationSc)
+
+	createDelegator := func(address string, value int64) *data.InitialAccount {
+		delegator := &data.InitialAccount{
+			Delegation: &data.DelegationData{
+				Value: big.NewInt(value),
+			},
+		}
+		delegator.SetAddressBytes([]byte(address))
+		delegator.Delegation.SetAddressBytes(delegationSc)
+
+		return delegator
+	}
+	delegator1 := createDelegator("delegator1", 3)
+	delegator2 := createDelegator("delegator2", 5)
+
+	type executedTx struct {
+		sender []byte
+		data   string
+	}
+	createArg := func(executedTxs *[]executedTx, batchSupported bool) ArgStandardDelegationProcessor {
+		arg := createMockStandardDelegationProcessorArg()
+		arg.Executor = &mock.TxExecutionProcessorStub{
+			ExecuteTransactionCalled: func(nonce uint64, sndAddr []byte, rcvAddress []byte, value *big.Int, data []byte) error {
+				*executedTxs = append(*executedTxs, executedTx{sender: sndAddr, data: string(data)})
+				return nil
+			},
+		}
+		arg.AccountsParser = &mock.AccountsParserStub{
+			GetInitialAccountsForDelegatedCalled: func(addressBytes []byte) []genesis.InitialAccountHandler {
+				return []genesis.InitialAccountHandler{delegator1, delegator2}
+			},
+		}
+		arg.QueryService = &mock.QueryServiceStub{
+			ExecuteQueryCalled: func(query *process.SCQuery) (*vmcommon.VMOutput, common.BlockInfo, error) {
+				if query.FuncName != "isBatchStakeSupported" {
+					return nil, nil, fmt.Errorf("unexpected function")
+				}
+				if !batchSupported {
+					return &vmcommon.VMOutput{ReturnData: [][]byte{{}}}, nil, nil
+				}
+
+				return &vmcommon.VMOutput{ReturnData: [][]byte{{1}}}, nil, nil
+			},
+		}
+		arg.BatchStakeFunction = "batchStakeGenesis"
+		arg.BatchStakeSupportFunction = "isBatchStakeSupported"
+
+		return arg
+	}
+
+	t.Run("supported batch stake should send one transaction", func(t *testing.T) {
+		t.Parallel()
+
+		executedTxs := make([]executedTx, 0)
+		dp, _ := NewStandardDelegationProcessor(createArg(&executedTxs, true))
+
+		numStaked, err := dp.executeStake([]genesis.InitialSmartContractHandler{sc})
+		assert.Nil(t, err)
+		assert.Equal(t, 2, numStaked)
+
+		expectedData := fmt.Sprintf("batchStakeGenesis@%s@03@%s@05",
+			hex.EncodeToString(delegator1.AddressBytes()), hex.EncodeToString(delegator2.AddressBytes()))
+		assert.Equal(t, []executedTx{{sender: owner, data: expectedData}}, executedTxs)
+
+		totalDelegated, err := dp.stakeAccounts([]genesis.InitialAccountHandler{delegator1, delegator2}, sc)
+		assert.Nil(t, err)
+		assert.Equal(t, big.NewInt(8), totalDelegated)
+	})
+	t.Run("unsupported batch stake should fallback to one transaction per account", func(t *testing.T) {
+		t.Parallel()
+
+		executedTxs := make([]executedTx, 0)
+		dp, _ := NewStandardDelegationProcessor(createArg(&executedTxs, false))
+
+		numStaked, err := dp.executeStake([]genesis.InitialSmartContractHandler{sc})
+		assert.Nil(t, err)
+		assert.Equal(t, 2, numStaked)
+
+		expectedTxs := []executedTx{
+			{sender: delegator1.AddressBytes(), data: "stakeGenesis@03"},
+			{sender: delegator2.AddressBytes(), data: "stakeGenesis@05"},
+		}
+		assert.Equal(t, expectedTxs, executedTxs)
+
+		totalDelegated, err := dp.stakeAccounts([]genesis.InitialAccountHandler{delegator1, delegator2}, sc)
+		assert.Nil(t, err)
+		assert.Equal(t, big.NewInt(8), totalDelegated)
+	})
+	t.Run("support query error should fallback to one transaction per account", func(t *testing.T) {
+		t.Parallel()
+
+		executedTxs := make([]executedTx, 0)
+		arg := createArg(&executedTxs, true)
+		arg.QueryService = &mock.QueryServiceStub{
+			ExecuteQueryCalled: func(query *process.SCQuery) (*vmcommon.VMOutput, common.BlockInfo, error) {
+				return nil, nil, errors.New("function not found")
+			},
+		}
+		dp, _ := NewStandardDelegationProcessor(arg)
+
+		numStaked, err := dp.executeStake([]genesis.InitialSmartContractHandler{sc})
+		assert.Nil(t, err)
+		assert.Equal(t, 2, numStaked)
+		assert.Equal(t, 2, len(executedTxs))
+	})
+}
+
+func TestStandardDelegationProcessor_ExecuteDelegationForContracts(t *testing.T) {
+	t.Parallel()
+
+	delegationScA := []byte("delegation SC A")
+	delegationScB := []byte("delegation SC B")
+
+	createStaker := func(address []byte, delegationSc []byte) *data.InitialAccount {
+		staker := &data.InitialAccount{
+			Delegation: &data.DelegationData{
+				Value: big.NewInt(2),
+			},
+		}
+		staker.SetAddressBytes(address)
+		staker.Delegation.SetAddressBytes(delegationSc)
+
+		return staker
+	}
+	stakers := map[string]*data.InitialAccount{
+		string(delegationScA): createStaker([]byte("stakerA"), delegationScA),
+		string(delegationScB): createStaker([]byte("stakerB"), delegationScB),
+	}
+
+	createArg := func(touchedContracts map[string]int) ArgStandardDelegationProcessor {
+		arg := createMockStandardDelegationProcessorArg()
+		arg.Executor = &mock.TxExecutionProcessorStub{
+			ExecuteTransactionCalled: func(nonce uint64, sndAddr []byte, rcvAddress []byte, value *big.Int, data []byte) error {
+				touchedContracts[string(rcvAddress)]++
+				return nil
+			},
+		}
+		arg.AccountsParser = &mock.AccountsParserStub{
+			GetInitialAccountsForDelegatedCalled: func(addressBytes []byte) []genesis.InitialAccountHandler {
+				staker, found := stakers[string(addressBytes)]
+				if !found {
+					return make([]genesis.InitialAccountHandler, 0)
+				}
+
+				return []genesis.InitialAccountHandler{staker}
+			},
+		}
+		arg.SmartContractParser = &mock.SmartContractParserStub{
+			InitialSmartContractsSplitOnOwnersShardsCalled: func(shardCoordinator sharding.Coordinator) (map[uint32][]genesis.InitialSmartContractHandler, error) {
+				scA := &data.InitialSmartContract{
+					Type: genesis.DelegationType,
+				}
+				scA.AddAddressBytes(delegationScA)
+
+				scB := &data.InitialSmartContract{
+					Type: genesis.DelegationType,
+				}
+				scB.AddAddressBytes(delegationScB)
+
+				return map[uint32][]genesis.InitialSmartContractHandler{
+					0: {scA, scB},
+				}, nil
+			},
+		}
+		arg.QueryService = &mock.QueryServiceStub{
+			ExecuteQueryCalled: func(query *process.SCQuery) (*vmcommon.VMOutput, common.BlockInfo, error) {
+				touchedContracts[string(query.ScAddress)]++
+
+				switch query.FuncName {
+				case "getUserStake":
+					return &vmcommon.VMOutput{
+						ReturnData: [][]byte{big.NewInt(2).Bytes()},
+					}, nil, nil
+				case "getNodeSignature":
+					return &vmcommon.VMOutput{
+						ReturnData: [][]byte{genesisSignature},
+					}, nil, nil
+				}
+
+				return nil, nil, fmt.Errorf("unexpected function")
+			},
+		}
+		arg.NodesListSplitter = &mock.NodesListSplitterStub{
+			GetDelegatedNodesCalled: func(delegationScAddress []byte) []nodesCoordinator.GenesisNodeInfoHandler {
+				return []nodesCoordinator.GenesisNodeInfoHandler{
+					&mock.GenesisNodeInfoHandlerMock{
+						AddressBytesValue: delegationScAddress,
+						PubKeyBytesValue:  append([]byte("pubkey of "), delegationScAddress...),
+					},
+				}
+			},
+		}
+
+		return arg
+	}
+
+	t.Run("unknown contract should error", func(t *testing.T) {
+		t.Parallel()
+
+		touchedContracts := make(map[string]int)
+		dp, _ := NewStandardDelegationProcessor(createArg(touchedContracts))
+
+		result, err := dp.ExecuteDelegationForContracts([][]byte{delegationScA, []byte("unknown SC")})
+		assert.True(t, errors.Is(err, genesis.ErrDelegationContractNotFound))
+		assert.Equal(t, genesis.DelegationResult{}, result)
+		assert.Empty(t, touchedContracts)
+	})
+	t.Run("duplicated contract should error", func(t *testing.T) {
+		t.Parallel()
+
+		touchedContracts := make(map[string]int)
+		dp, _ := NewStandardDelegationProcessor(createArg(touchedContracts))
+
+		result, err := dp.ExecuteDelegationForContracts([][]byte{delegationScA, delegationScA})
+		assert.True(t, errors.Is(err, genesis.ErrDuplicatedDelegationContract))
+		assert.Equal(t, genesis.DelegationResult{}, result)
+		assert.Empty(t, touchedContracts)
+	})
+	t.Run("no contracts should not execute anything", func(t *testing.T) {
+		t.Parallel()
+
+		touchedContracts := make(map[string]int)
+		dp, _ := NewStandardDelegationProcessor(createArg(touchedContracts))
+
+		result, err := dp.ExecuteDelegationForContracts(nil)
+		assert.Nil(t, err)
+		assert.Equal(t, genesis.DelegationResult{}, result)
+		assert.Empty(t, touchedContracts)
+	})
+	t.Run("single contract should leave the other contracts untouched", func(t *testing.T) {
+		t.Parallel()
+
+		touchedContracts := make(map[string]int)
+		dp, _ := NewStandardDelegationProcessor(createArg(touchedContracts))
+
+		result, err := dp.ExecuteDelegationForContracts([][]byte{delegationScB})
+		assert.Nil(t, err)
+
+		expectedResult := genesis.DelegationResult{
+			NumTotalDelegated: 1,
+			NumTotalStaked:    1,
+			NumVerifyQueries:  2,
+			HadContracts:      true,
+			Contracts: []genesis.DelegationContractResult{
+				{NumDelegated: 1, NumStaked: 1},
+			},
+		}
+		assert.Equal(t, expectedResult, result)
+		assert.NotZero(t, touchedContracts[string(delegationScB)])
+		assert.Zero(t, touchedContracts[string(delegationScA)])
+	})
+}
+
+func TestStandardDelegationProcessor_ExecuteDelegationShouldReportUnderUtilizedContracts(t *testing.T) {
+	t.Parallel()
+
+	fullSc := []byte("full delegation SC")
+	nodesOnlySc := []byte("nodes only delegation SC")
+	delegatorsOnlySc := []byte("delegators only delegation SC")
+
+	staker1 := &data.InitialAccount{
+		Delegation: &data.DelegationData{
+			Value: big.NewInt(2),
+		},
+	}
+	staker1.SetAddressBytes([]byte("staker1"))
+	staker1.Delegation.SetAddressBytes(fullSc)
+
+	staker2 := &data.InitialAccount{
+		Delegation: &data.DelegationData{
+			Value: big.NewInt(2),
+		},
+	}
+	staker2.SetAddressBytes([]byte("staker2"))
+	staker2.Delegation.SetAddressBytes(delegatorsOnlySc)
+
+	arg := createMockStandardDelegationProcessorArg()
+	arg.AccountsParser = &mock.AccountsParserStub{
+		GetInitialAccountsForDelegatedCalled: func(addressBytes []byte) []genesis.InitialAccountHandler {
+			switch string(addressBytes) {
+			case string(fullSc):
+				return []genesis.InitialAccountHandler{staker1}
+			case string(delegatorsOnlySc):
+				return []genesis.InitialAccountHandler{staker2}
+			}
+
+			return make([]genesis.InitialAccountHandler, 0)
+		},
+	}
+	arg.SmartContractParser = &mock.SmartContractParserStub{
+		InitialSmartContractsSplitOnOwnersShardsCalled: func(shardCoordinator sharding.Coordinator) (map[uint32][]genesis.InitialSmartContractHandler, error) {
+			smartContracts := make([]genesis.InitialSmartContractHandler, 0)
+			for _, address := range [][]byte{fullSc, nodesOnlySc, delegatorsOnlySc} {
+				sc := &data.InitialSmartContract{
+					Type: genesis.DelegationType,
+				}
+				sc.AddAddressBytes(address)
+				sc.AddAddress(string(address))
+				// distinct owners, as the owners nonces are not increased by the stub executor
+				sc.SetOwnerBytes(append([]byte("owner of "), address...))
+				smartContracts = append(smartContracts, sc)
+			}
+
+			return map[uint32][]genesis.InitialSmartContractHandler{
+				0: smartContracts,
+			}, nil
+		},
+	}
+	arg.QueryService = &mock.QueryServiceStub{
+		ExecuteQueryCalled: func(query *process.SCQuery) (*vmcommon.VMOutput, common.BlockInfo, error) {
+			switch query.FuncName {
+			case "getUserStake":
+				return &vmcommon.VMOutput{
+					ReturnData: [][]byte{big.NewInt(2).Bytes()},
+				}, nil, nil
+			case "getNodeSignature":
+				return &vmcommon.VMOutput{
+					ReturnData: [][]byte{genesisSignature},
+				}, nil, nil
+			}
+
+			return nil, nil, fmt.Errorf("unexpected function")
+		},
+	}
+	arg.NodesListSplitter = &mock.NodesListSplitterStub{
+		GetDelegatedNodesCalled: func(delegationScAddress []byte) []nodesCoordinator.GenesisNodeInfoHandler {
+			if bytes.Equal(delegationScAddress, delegatorsOnlySc) {
+				return nil
+			}
+
+			return []nodesCoordinator.GenesisNodeInfoHandler{
+				&mock.GenesisNodeInfoHandlerMock{
+					AddressBytesValue: delegationScAddress,
+					PubKeyBytesValue:  append([]byte("pubkey of "), delegationScAddress...),
+				},
+			}
+		},
+	}
+	dp, _ := NewStandardDelegationProcessor(arg)
+
+	result, _, err := dp.ExecuteDelegation()
+	assert.Nil(t, err)
+
+	assert.Equal(t, []string{string(nodesOnlySc)}, result.ContractsWithoutDelegators)
+	assert.Equal(t, []string{string(delegatorsOnlySc)}, result.ContractsWithoutNodes)
+	assert.Equal(t, 2, result.NumTotalDelegated)
+	assert.Equal(t, 2, result.NumTotalStaked)
+}
+
+func TestStandardDelegationProcessor_PostImportReport(t *testing.T) {
+	t.Parallel()
+
+	delegationSc := []byte("delegation SC")
+	staker := &data.InitialAccount{
+		Delegation: &data.DelegationData{
+			Value: big.NewInt(2),
+		},
+	}
+	staker.SetAddressBytes([]byte("staker"))
+	staker.Delegation.SetAddressBytes(delegationSc)
+
+	numReportedNodes := int64(1)
+	errQuery := errors.New("query error")
+	var queryErr error
+	arg := createMockStandardDelegationProcessorArg()
+	arg.AccountsParser = &mock.AccountsParserStub{
+		GetInitialAccountsForDelegatedCalled: func(addressBytes []byte) []genesis.InitialAccountHandler {
+			return []genesis.InitialAccountHandler{staker}
+		},
+	}
+	arg.SmartContractParser = &mock.SmartContractParserStub{
+		InitialSmartContractsSplitOnOwnersShardsCalled: func(shardCoordinator sharding.Coordinator) (map[uint32][]genesis.InitialSmartContractHandler, error) {
+			sc := &data.InitialSmartContract{
+				Type: genesis.DelegationType,
+			}
+			sc.AddAddressBytes(delegationSc)
+			sc.AddAddress(string(delegationSc))
+			sc.SetOwnerBytes([]byte("owner"))
+
+			return map[uint32][]genesis.InitialSmartContractHandler{
+				0: {sc},
+			}, nil
+		},
+	}
+	arg.QueryService = &mock.QueryServiceStub{
+		ExecuteQueryCalled: func(query *process.SCQuery) (*vmcommon.VMOutput, common.BlockInfo, error) {
+			assert.Equal(t, delegationSc, query.ScAddress)
+
+			switch query.FuncName {
+			case "getUserStake":
+				return &vmcommon.VMOutput{
+					ReturnData: [][]byte{big.NewInt(2).Bytes()},
+				}, nil, nil
+			case "getNodeSignature":
+				return &vmcommon.VMOutput{
+					ReturnData: [][]byte{genesisSignature},
+				}, nil, nil
+			case "getNumNodes":
+				return &vmcommon.VMOutput{
+					ReturnData: [][]byte{big.NewInt(numReportedNodes).Bytes()},
+				}, nil, queryErr
+			case "getNumUsers":
+				return &vmcommon.VMOutput{
+					ReturnData: [][]byte{big.NewInt(1).Bytes()},
+				}, nil, nil
+			}
+
+			return nil, nil, fmt.Errorf("unexpected function")
+		},
+	}
+	arg.NodesListSplitter = &mock.NodesListSplitterStub{
+		GetDelegatedNodesCalled: func(delegationScAddress []byte) []nodesCoordinator.GenesisNodeInfoHandler {
+			return []nodesCoordinator.GenesisNodeInfoHandler{
+				&mock.GenesisNodeInfoHandlerMock{
+					AddressBytesValue: delegationScAddress,
+					PubKeyBytesValue:  []byte("pubkey1"),
+				},
+				&mock.GenesisNodeInfoHandlerMock{
+					AddressBytesValue: delegationScAddress,
+					PubKeyBytesValue:  []byte("pubkey2"),
+				},
+			}
+		},
+	}
+	dp, _ := NewStandardDelegationProcessor(arg)
+
+	discrepancies, err := dp.PostImportReport()
+	assert.Nil(t, err)
+	assert.Empty(t, discrepancies, "no contract should be checked before the delegation execution")
+
+	_, _, err = dp.ExecuteDelegation()
+	assert.Nil(t, err)
+
+	discrepancies, err = dp.PostImportReport()
+	assert.Nil(t, err)
+	expectedDiscrepancies := []genesis.DelegationDiscrepancy{
+		{
+			Address:  string(delegationSc),
+			Owner:    "",
+			Check:    genesis.DelegationNodesCheck,
+			Sent:     2,
+			Reported: "1",
+		},
+	}
+	assert.Equal(t, expectedDiscrepancies, discrepancies)
+
+	numReportedNodes = 2
+	discrepancies, err = dp.PostImportReport()
+	assert.Nil(t, err)
+	assert.Empty(t, discrepancies)
+
+	queryErr = errQuery
+	discrepancies, err = dp.PostImportReport()
+	assert.True(t, errors.Is(err, errQuery))
+	assert.Nil(t, discrepancies)
+}
+
+func TestStandardDelegationProcessor_CheckNodePriceAgainstStakingSystem(t *testing.T) {
+	t.Parallel()
+
+	nodePriceFunction := "getNodePrice"
+	createProcessor := func(checkNodePrice bool, stakingNodePrice *big.Int, queryErr error, numCalls *int) *standardDelegationProcessor {
+		arg := createMockStandardDelegationProcessorArg()
+		arg.CheckNodePrice = checkNodePrice
+		arg.NodePriceFunction = nodePriceFunction
+		arg.QueryService = &mock.QueryServiceStub{
+			ExecuteQueryCalled: func(query *process.SCQuery) (*vmcommon.VMOutput, common.BlockInfo, error) {
+				*numCalls++
+				assert.Equal(t, vm.ValidatorSCAddress, query.ScAddress)
+				assert.Equal(t, nodePriceFunction, query.FuncName)
+
+				return &vmcommon.VMOutput{ReturnData: [][]byte{stakingNodePrice.Bytes()}}, nil, queryErr
+			},
+		}
+		dp, _ := NewStandardDelegationProcessor(arg)
+
+		return dp
+	}
+
+	t.Run("inactive check should not query", func(t *testing.T) {
+		t.Parallel()
+
+		numCalls := 0
+		dp := createProcessor(false, big.NewInt(11), nil, &numCalls)
+
+		err := dp.checkNodePriceAgainstStakingSystem()
+		assert.Nil(t, err)
+		assert.Zero(t, numCalls)
+	})
+	t.Run("matching node price should work", func(t *testing.T) {
+		t.Parallel()
+
+		numCalls := 0
+		dp := createProcessor(true, big.NewInt(10), nil, &numCalls)
+
+		err := dp.checkNodePriceAgainstStakingSystem()
+		assert.Nil(t, err)
+		assert.Equal(t, 1, numCalls)
+	})
+	t.Run("mismatching node price should error", func(t *testing.T) {
+		t.Parallel()
+
+		numCalls := 0
+		dp := createProcessor(true, big.NewInt(11), nil, &numCalls)
+
+		err := dp.checkNodePriceAgainstStakingSystem()
+		assert.True(t, errors.Is(err, genesis.ErrNodePriceMismatch))
+		assert.True(t, strings.Contains(err.Error(), "staking system 11, delegation 10"))
+		assert.Equal(t, 1, numCalls)
+	})
+	t.Run("mismatching node price should not send any transaction", func(t *testing.T) {
+		t.Parallel()
+
+		delegationSc := []byte("delegation SC")
+		arg := createMockStandardDelegationProcessorArg()
+		arg.CheckNodePrice = true
+		arg.NodePriceFunction = nodePriceFunction
+		arg.Executor = &mock.TxExecutionProcessorStub{
+			ExecuteTransactionCalled: func(nonce uint64, sndAddr []byte, rcvAddress []byte, value *big.Int, data []byte) error {
+				assert.Fail(t, "should not have sent transactions")
+				return nil
+			},
+		}
+		arg.NodesListSplitter = &mock.NodesListSplitterStub{
+			GetDelegatedNodesCalled: func(delegationScAddress []byte) []nodesCoordinator.GenesisNodeInfoHandler {
+				return []nodesCoordinator.GenesisNodeInfoHandler{
+					&mock.GenesisNodeInfoHandlerMock{
+						AddressBytesValue: delegationScAddress,
+						PubKeyBytesValue:  []byte("pubkey"),
+					},
+				}
+			},
+		}
+		arg.QueryService = &mock.QueryServiceStub{
+			ExecuteQueryCalled: func(query *process.SCQuery) (*vmcommon.VMOutput, common.BlockInfo, error) {
+				return &vmcommon.VMOutput{ReturnData: [][]byte{big.NewInt(11).Bytes()}}, nil, nil
+			},
+		}
+		dp, _ := NewStandardDelegationProcessor(arg)
+
+		sc := &data.InitialSmartContract{
+			Type: genesis.DelegationType,
+		}
+		sc.AddAddressBytes(delegationSc)
+		result, err := dp.executeDelegationOnContracts([]genesis.InitialSmartContractHandler{sc})
+		assert.True(t, errors.Is(err, genesis.ErrNodePriceMismatch))
+		assert.False(t, result.HadContracts)
+	})
+	t.Run("query error should error", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		numCalls := 0
+		dp := createProcessor(true, big.NewInt(10), expectedErr, &numCalls)
+
+		err := dp.checkNodePriceAgainstStakingSystem()
+		assert.True(t, errors.Is(err, expectedErr))
+	})
 }
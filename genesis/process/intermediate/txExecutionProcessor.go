@@ -69,6 +69,20 @@ func (tep *txExecutionProcessor) GetExecutedTransactions() []data.TransactionHan
 	return tep.txs
 }
 
+// NumExecutedTransactions returns the number of cached transactions
+func (tep *txExecutionProcessor) NumExecutedTransactions() int {
+	return len(tep.txs)
+}
+
+// RevertExecutedTransactions drops the cached transactions executed after the provided number of transactions
+func (tep *txExecutionProcessor) RevertExecutedTransactions(numTransactions int) {
+	if numTransactions < 0 || numTransactions >= len(tep.txs) {
+		return
+	}
+
+	tep.txs = tep.txs[:numTransactions]
+}
+
 // GetNonce returns the current nonce of the provided sender account
 func (tep *txExecutionProcessor) GetNonce(senderBytes []byte) (uint64, error) {
 	accnt, err := tep.accounts.LoadAccount(senderBytes)
@@ -78,6 +78,30 @@ func TestTxExecutionProcessor_ExecuteTransaction(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+//------- RevertExecutedTransactions
+
+func TestTxExecutionProcessor_RevertExecutedTransactions(t *testing.T) {
+	t.Parallel()
+
+	tep, _ := intermediate.NewTxExecutionProcessor(&testscommon.TxProcessorStub{}, &stateMock.AccountsStub{})
+	for i := 0; i < 3; i++ {
+		_ = tep.ExecuteTransaction(uint64(i), []byte("sender"), []byte("receiver"), big.NewInt(0), nil)
+	}
+	assert.Equal(t, 3, tep.NumExecutedTransactions())
+
+	tep.RevertExecutedTransactions(-1)
+	assert.Equal(t, 3, tep.NumExecutedTransactions())
+
+	tep.RevertExecutedTransactions(4)
+	assert.Equal(t, 3, tep.NumExecutedTransactions())
+
+	tep.RevertExecutedTransactions(1)
+	assert.Equal(t, 1, tep.NumExecutedTransactions())
+	txs := tep.GetExecutedTransactions()
+	assert.Equal(t, 1, len(txs))
+	assert.Equal(t, uint64(0), txs[0].GetNonce())
+}
+
 //------- GetNonce
 
 func TestTxExecutionProcessor_GetNonceAccountsErrShouldErr(t *testing.T) {
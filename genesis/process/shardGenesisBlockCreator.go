@@ -6,6 +6,7 @@ import (
 	"math"
 	"math/big"
 	"sync"
+	"time"
 
 	"github.com/multiversx/mx-chain-core-go/core/check"
 	"github.com/multiversx/mx-chain-core-go/data"
@@ -46,6 +47,7 @@ import (
 )
 
 const unreachableEpoch = ^uint32(0)
+const delegationQueryTimeout = time.Minute
 
 var log = logger.GetOrCreate("genesis/process")
 var zero = big.NewInt(0)
@@ -154,6 +156,7 @@ func CreateShardGenesisBlock(
 		"num other SC deployed", deployMetrics.numOtherTypes,
 		"num set balances", numSetBalances,
 		"num staked directly", numStaked,
+		"had delegation SC", delegationResult.HadContracts,
 		"total staked on a delegation SC", delegationResult.NumTotalStaked,
 		"total delegation nodes", delegationResult.NumTotalDelegated,
 		"cross shard delegation calls", numCrossShardDelegations,
@@ -811,6 +814,11 @@ func executeDelegation(
 		NodesListSplitter:   nodesListSplitter,
 		QueryService:        processors.queryService,
 		NodePrice:           arg.GenesisNodePrice,
+		QueryTimeout:        delegationQueryTimeout,
+		TotalStakeFunction:  arg.DelegationTotalStakeFunction,
+	}
+	if arg.DelegationTransactionalMode {
+		argDP.Accounts = arg.Accounts
 	}
 
 	delegationProcessor, err := intermediate.NewStandardDelegationProcessor(argDP)
@@ -85,3 +85,18 @@ var ErrNilManagedPeersHolder = errors.New("nil managed peers holder")
 
 // ErrInvalidConfiguration signals that an invalid configuration has been provided
 var ErrInvalidConfiguration = errors.New("invalid configuration")
+
+// ErrHandlerExecutionPanicked signals that the execution of a handler panicked
+var ErrHandlerExecutionPanicked = errors.New("handler execution panicked")
+
+// ErrNilSenderHandler signals that a nil sender handler was provided
+var ErrNilSenderHandler = errors.New("nil sender handler")
+
+// ErrEmptyHandlerID signals that an empty handler identifier was provided
+var ErrEmptyHandlerID = errors.New("empty handler identifier")
+
+// ErrDuplicatedHandlerID signals that a handler with the same identifier was already registered
+var ErrDuplicatedHandlerID = errors.New("duplicated handler identifier")
+
+// ErrHandlerNotFound signals that no handler was registered with the provided identifier
+var ErrHandlerNotFound = errors.New("handler not found")
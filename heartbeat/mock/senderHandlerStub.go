@@ -5,6 +5,7 @@ import "time"
 // SenderHandlerStub -
 type SenderHandlerStub struct {
 	ExecutionReadyChannelCalled func() <-chan time.Time
+	ExecutionIntervalCalled     func() time.Duration
 	ExecuteCalled               func()
 	CloseCalled                 func()
 }
@@ -18,6 +19,15 @@ func (stub *SenderHandlerStub) ExecutionReadyChannel() <-chan time.Time {
 	return nil
 }
 
+// ExecutionInterval -
+func (stub *SenderHandlerStub) ExecutionInterval() time.Duration {
+	if stub.ExecutionIntervalCalled != nil {
+		return stub.ExecutionIntervalCalled()
+	}
+
+	return 0
+}
+
 // Execute -
 func (stub *SenderHandlerStub) Execute() {
 	if stub.ExecuteCalled != nil {
@@ -108,6 +108,11 @@ func (bs *baseSender) computeRandomDuration(baseDuration time.Duration) time.Dur
 	return ret
 }
 
+// ExecutionInterval returns the declared duration between two consecutive sends
+func (bs *baseSender) ExecutionInterval() time.Duration {
+	return bs.timeBetweenSends
+}
+
 func (bs *baseSender) getCurrentPrivateAndPublicKeys() (crypto.PrivateKey, crypto.PublicKey) {
 	shouldUseOriginalKeys := !bs.redundancy.IsRedundancyNode() || (bs.redundancy.IsRedundancyNode() && !bs.redundancy.IsMainMachineActive())
 	if shouldUseOriginalKeys {
@@ -0,0 +1,17 @@
+package sender
+
+import "time"
+
+// realClock is the clockHandler implementation backed by the system time
+type realClock struct {
+}
+
+// Now returns the current local time
+func (clock *realClock) Now() time.Time {
+	return time.Now()
+}
+
+// After waits for the duration to elapse and then sends the current time on the returned channel
+func (clock *realClock) After(duration time.Duration) <-chan time.Time {
+	return time.After(duration)
+}
@@ -15,6 +15,11 @@ func (sender *disabledSenderHandler) ExecutionReadyChannel() <-chan time.Time {
 	return make(chan time.Time)
 }
 
+// ExecutionInterval returns 0
+func (sender *disabledSenderHandler) ExecutionInterval() time.Duration {
+	return 0
+}
+
 // Execute does nothing
 func (sender *disabledSenderHandler) Execute() {
 }
@@ -8,6 +8,7 @@ import (
 
 type senderHandler interface {
 	ExecutionReadyChannel() <-chan time.Time
+	ExecutionInterval() time.Duration
 	Execute()
 	Close()
 	IsInterfaceNil() bool
@@ -34,3 +35,8 @@ type timerHandler interface {
 	ExecutionReadyChannel() <-chan time.Time
 	Close()
 }
+
+type clockHandler interface {
+	Now() time.Time
+	After(duration time.Duration) <-chan time.Time
+}
@@ -209,6 +209,11 @@ func (sender *multikeyPeerAuthenticationSender) sendData(pkBytes []byte, data []
 		"next send is scheduled on", nextTimeToCheck)
 }
 
+// ExecutionInterval returns the duration between two consecutive checks of the managed keys
+func (sender *multikeyPeerAuthenticationSender) ExecutionInterval() time.Duration {
+	return sender.timeBetweenChecks
+}
+
 // ShouldTriggerHardfork signals when hardfork message should be sent
 func (sender *multikeyPeerAuthenticationSender) ShouldTriggerHardfork() <-chan struct{} {
 	return sender.hardforkTrigger.NotifyTriggerReceivedV2()
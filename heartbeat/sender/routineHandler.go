@@ -2,73 +2,364 @@ package sender
 
 import (
 	"context"
+	"fmt"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-go/heartbeat"
 	logger "github.com/multiversx/mx-chain-logger-go"
 )
 
 var log = logger.GetOrCreate("heartbeat/sender")
 
+const (
+	// PeerAuthenticationSenderID is the identifier of the peer authentication sender handler
+	PeerAuthenticationSenderID = "peerAuthentication"
+	// HeartbeatSenderID is the identifier of the heartbeat sender handler
+	HeartbeatSenderID = "heartbeat"
+	// HardforkSenderID is the identifier of the hardfork sender handler
+	HardforkSenderID = "hardfork"
+)
+
+const maxBufferedErrors = 10
+
+// HandlerStats holds the execution statistics of a sender handler
+type HandlerStats struct {
+	NumExecutions uint64
+	NumPanics     uint32
+	LastExecution time.Time
+}
+
+// registeredHandler is a sender handler registered under an identifier, along with its execution statistics
+type registeredHandler struct {
+	sender    senderHandler
+	cancel    func()
+	executed  chan struct{}
+	stats     HandlerStats
+	closeOnce sync.Once
+}
+
+// close closes the sender handler. It is safe to be called more than once, the sender being closed only once
+func (entry *registeredHandler) close() {
+	entry.closeOnce.Do(entry.sender.Close)
+}
+
 type routineHandler struct {
-	peerAuthenticationSender           senderHandler
-	heartbeatSender                    senderHandler
 	hardforkSender                     hardforkHandler
 	delayAfterHardforkMessageBroadcast time.Duration
+	ctx                                context.Context
 	cancel                             func()
+	clock                              clockHandler
+	mutHandlers                        sync.RWMutex
+	handlers                           map[string]*registeredHandler
+	readyHandlers                      chan string
+	numPanics                          uint32
+	errorsChan                         chan error
+	closeOnce                          sync.Once
 }
 
 func newRoutineHandler(peerAuthenticationSender senderHandler, heartbeatSender senderHandler, hardforkSender hardforkHandler) *routineHandler {
+	return newRoutineHandlerWithClock(peerAuthenticationSender, heartbeatSender, hardforkSender, &realClock{})
+}
+
+// newRoutineHandlerWithClock creates a routine handler which uses the provided clock for all its time related operations
+func newRoutineHandlerWithClock(
+	peerAuthenticationSender senderHandler,
+	heartbeatSender senderHandler,
+	hardforkSender hardforkHandler,
+	clock clockHandler,
+) *routineHandler {
 	handler := &routineHandler{
-		peerAuthenticationSender:           peerAuthenticationSender,
-		heartbeatSender:                    heartbeatSender,
 		hardforkSender:                     hardforkSender,
 		delayAfterHardforkMessageBroadcast: time.Minute,
+		clock:                              clock,
+		handlers:                           make(map[string]*registeredHandler),
+		readyHandlers:                      make(chan string),
+		errorsChan:                         make(chan error, maxBufferedErrors),
 	}
+	handler.ctx, handler.cancel = context.WithCancel(context.Background())
 
-	var ctx context.Context
-	ctx, handler.cancel = context.WithCancel(context.Background())
-	go handler.processLoop(ctx)
+	// the initial handlers are executed once by the process loop, in this order, before waiting for them to be ready
+	initialHandlerIDs := []string{PeerAuthenticationSenderID, HeartbeatSenderID}
+	handler.handlers[PeerAuthenticationSenderID] = newRegisteredHandler(peerAuthenticationSender)
+	handler.handlers[HeartbeatSenderID] = newRegisteredHandler(heartbeatSender)
+
+	go handler.processLoop(initialHandlerIDs)
 
 	return handler
 }
 
-func (handler *routineHandler) processLoop(ctx context.Context) {
+func newRegisteredHandler(sender senderHandler) *registeredHandler {
+	return &registeredHandler{
+		sender:   sender,
+		executed: make(chan struct{}, 1),
+	}
+}
+
+// AddHandler registers the provided sender handler under the provided identifier. The handler is executed as soon as
+// possible and then each time it signals it is ready. The identifiers are unique: registering a handler with an
+// identifier already in use errors
+func (handler *routineHandler) AddHandler(handlerID string, sender senderHandler) error {
+	if len(handlerID) == 0 {
+		return heartbeat.ErrEmptyHandlerID
+	}
+	if check.IfNil(sender) {
+		return heartbeat.ErrNilSenderHandler
+	}
+
+	handler.mutHandlers.Lock()
+	defer handler.mutHandlers.Unlock()
+
+	_, found := handler.handlers[handlerID]
+	if found || handlerID == HardforkSenderID {
+		return fmt.Errorf("%w: %s", heartbeat.ErrDuplicatedHandlerID, handlerID)
+	}
+
+	entry := newRegisteredHandler(sender)
+	handler.handlers[handlerID] = entry
+	handler.startWaitingForHandler(handlerID, entry, true)
+
+	return nil
+}
+
+// RemoveHandler stops and closes the handler registered under the provided identifier
+func (handler *routineHandler) RemoveHandler(handlerID string) error {
+	handler.mutHandlers.Lock()
+	entry, found := handler.handlers[handlerID]
+	if !found {
+		handler.mutHandlers.Unlock()
+		return fmt.Errorf("%w: %s", heartbeat.ErrHandlerNotFound, handlerID)
+	}
+	delete(handler.handlers, handlerID)
+	handler.mutHandlers.Unlock()
+
+	if entry.cancel != nil {
+		entry.cancel()
+	}
+	entry.close()
+
+	return nil
+}
+
+// HandlerIDs returns the sorted identifiers of the registered handlers
+func (handler *routineHandler) HandlerIDs() []string {
+	handler.mutHandlers.RLock()
+	handlerIDs := make([]string, 0, len(handler.handlers))
+	for handlerID := range handler.handlers {
+		handlerIDs = append(handlerIDs, handlerID)
+	}
+	handler.mutHandlers.RUnlock()
+
+	sort.Strings(handlerIDs)
+
+	return handlerIDs
+}
+
+// Stats returns the execution statistics of the handler registered under the provided identifier. Returns false if
+// no such handler is registered
+func (handler *routineHandler) Stats(handlerID string) (HandlerStats, bool) {
+	handler.mutHandlers.RLock()
+	defer handler.mutHandlers.RUnlock()
+
+	entry, found := handler.handlers[handlerID]
+	if !found {
+		return HandlerStats{}, false
+	}
+
+	return entry.stats, true
+}
+
+// startWaitingForHandler starts the go routine forwarding the readiness of the provided handler to the process loop.
+// Should be called under the handlers mutex
+func (handler *routineHandler) startWaitingForHandler(handlerID string, entry *registeredHandler, executeImmediately bool) {
+	var ctx context.Context
+	ctx, entry.cancel = context.WithCancel(handler.ctx)
+
+	go handler.waitForHandler(ctx, handlerID, entry, executeImmediately)
+}
+
+// waitForHandler signals the process loop each time the provided handler is ready and then waits for its execution,
+// as the execution renews the channel on which the handler signals its readiness
+func (handler *routineHandler) waitForHandler(ctx context.Context, handlerID string, entry *registeredHandler, executeImmediately bool) {
+	for {
+		if !executeImmediately {
+			select {
+			case <-entry.sender.ExecutionReadyChannel():
+			case <-ctx.Done():
+				return
+			}
+		}
+		executeImmediately = false
+
+		select {
+		case handler.readyHandlers <- handlerID:
+		case <-ctx.Done():
+			return
+		}
+
+		select {
+		case <-entry.executed:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (handler *routineHandler) processLoop(initialHandlerIDs []string) {
 	defer func() {
 		log.Debug("heartbeat's routine handler is closing...")
 
-		handler.peerAuthenticationSender.Close()
-		handler.heartbeatSender.Close()
+		handler.closeHandlers()
 		handler.hardforkSender.Close()
+		close(handler.errorsChan)
 	}()
 
-	handler.peerAuthenticationSender.Execute()
-	handler.heartbeatSender.Execute()
+	// no routine waits yet for the initial executions, so they should not be signaled
+	for _, handlerID := range initialHandlerIDs {
+		handler.executeHandler(handlerID, false)
+	}
+
+	handler.mutHandlers.Lock()
+	for _, handlerID := range initialHandlerIDs {
+		entry, found := handler.handlers[handlerID]
+		if found {
+			handler.startWaitingForHandler(handlerID, entry, false)
+		}
+	}
+	handler.mutHandlers.Unlock()
 
 	for {
 		select {
-		case <-handler.peerAuthenticationSender.ExecutionReadyChannel():
-			handler.peerAuthenticationSender.Execute()
-		case <-handler.heartbeatSender.ExecutionReadyChannel():
-			handler.heartbeatSender.Execute()
+		case handlerID := <-handler.readyHandlers:
+			handler.executeHandler(handlerID, true)
 		case <-handler.hardforkSender.ShouldTriggerHardfork():
-			handler.hardforkSender.Execute()
-			handler.waitAfterHarforkBroadcast(ctx)
-		case <-ctx.Done():
+			handler.executeWithRecover(HardforkSenderID, handler.hardforkSender.Execute)
+			handler.waitAfterHarforkBroadcast(handler.ctx)
+		case <-handler.ctx.Done():
 			return
 		}
 	}
 }
 
-func (handler *routineHandler) waitAfterHarforkBroadcast(ctx context.Context) {
-	timer := time.NewTimer(handler.delayAfterHardforkMessageBroadcast)
-	defer timer.Stop()
+// closeHandlers closes all the registered handlers, in the order of their identifiers
+func (handler *routineHandler) closeHandlers() {
+	for _, handlerID := range handler.HandlerIDs() {
+		handler.mutHandlers.RLock()
+		entry, found := handler.handlers[handlerID]
+		handler.mutHandlers.RUnlock()
+		if found {
+			entry.close()
+		}
+	}
+}
+
+// executeHandler executes the handler registered under the provided identifier, if it is still registered, and
+// updates its statistics. If required, the execution is signaled to the routine waiting for the handler
+func (handler *routineHandler) executeHandler(handlerID string, signalExecution bool) {
+	handler.mutHandlers.RLock()
+	entry, found := handler.handlers[handlerID]
+	handler.mutHandlers.RUnlock()
+	if !found {
+		return
+	}
+
+	panicked := handler.executeWithRecover(handlerID, entry.sender.Execute)
+
+	handler.mutHandlers.Lock()
+	entry.stats.NumExecutions++
+	entry.stats.LastExecution = handler.clock.Now()
+	if panicked {
+		entry.stats.NumPanics++
+	}
+	handler.mutHandlers.Unlock()
+
+	if !signalExecution {
+		return
+	}
+
+	select {
+	case entry.executed <- struct{}{}:
+	default:
+	}
+}
 
+// executeWithRecover calls the provided execute function, recovering from any panic so the process loop, and
+// implicitly the other handlers, will continue to work. Returns true if the execution panicked
+func (handler *routineHandler) executeWithRecover(handlerID string, executeFunc func()) (panicked bool) {
+	defer func() {
+		r := recover()
+		if r != nil {
+			panicked = true
+			atomic.AddUint32(&handler.numPanics, 1)
+			log.Error("heartbeat's routine handler recovered from panic",
+				"handler", handlerID,
+				"panic", r,
+				"stack", string(debug.Stack()))
+			handler.notifyError(fmt.Errorf("%w for handler %s: %v", heartbeat.ErrHandlerExecutionPanicked, handlerID, r))
+		}
+	}()
+
+	executeFunc()
+
+	return false
+}
+
+// notifyError pushes the provided error on the errors channel without blocking. If the buffer is full, the oldest
+// error is dropped. Should only be called from the process loop, which is the only writer of the channel
+func (handler *routineHandler) notifyError(err error) {
+	for {
+		select {
+		case handler.errorsChan <- err:
+			return
+		default:
+		}
+
+		select {
+		case <-handler.errorsChan:
+		default:
+		}
+	}
+}
+
+// Errors returns the channel on which the failures of the handlers' executions are emitted. The channel is closed
+// when the process loop ends
+func (handler *routineHandler) Errors() <-chan error {
+	return handler.errorsChan
+}
+
+// NumPanics returns the number of panics recovered while executing the handlers
+func (handler *routineHandler) NumPanics() uint32 {
+	return atomic.LoadUint32(&handler.numPanics)
+}
+
+// NextExecution returns the moment when the handler with the provided identifier is expected to be executed again,
+// computed from its last execution and its declared interval. Returns false if the handler was not executed yet
+func (handler *routineHandler) NextExecution(handlerID string) (time.Time, bool) {
+	handler.mutHandlers.RLock()
+	entry, found := handler.handlers[handlerID]
+	if !found || entry.stats.NumExecutions == 0 {
+		handler.mutHandlers.RUnlock()
+		return time.Time{}, false
+	}
+	lastExecution := entry.stats.LastExecution
+	handler.mutHandlers.RUnlock()
+
+	return lastExecution.Add(entry.sender.ExecutionInterval()), true
+}
+
+func (handler *routineHandler) waitAfterHarforkBroadcast(ctx context.Context) {
 	select {
-	case <-timer.C:
+	case <-handler.clock.After(handler.delayAfterHardforkMessageBroadcast):
 	case <-ctx.Done():
 	}
 }
 
+// closeProcessLoop ends the process loop, which will close all the handlers. It is safe to be called more than once,
+// including concurrently, the handlers being closed only once
 func (handler *routineHandler) closeProcessLoop() {
-	handler.cancel()
+	handler.closeOnce.Do(handler.cancel)
 }
@@ -1,14 +1,142 @@
 package sender
 
 import (
+	"errors"
+	"fmt"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/multiversx/mx-chain-go/heartbeat"
 	"github.com/multiversx/mx-chain-go/heartbeat/mock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+const waitTimeout = time.Second * 5
+
+// fakeClock is a clockHandler which only moves forward when Advance is called
+type fakeClock struct {
+	mut         sync.Mutex
+	now         time.Time
+	waiters     []*fakeClockWaiter
+	afterCalled chan time.Duration
+}
+
+type fakeClockWaiter struct {
+	deadline time.Time
+	ch       chan time.Time
+}
+
+func newFakeClock(now time.Time) *fakeClock {
+	return &fakeClock{
+		now:         now,
+		afterCalled: make(chan time.Duration, 10),
+	}
+}
+
+// Now -
+func (clock *fakeClock) Now() time.Time {
+	clock.mut.Lock()
+	defer clock.mut.Unlock()
+
+	return clock.now
+}
+
+// After -
+func (clock *fakeClock) After(duration time.Duration) <-chan time.Time {
+	clock.mut.Lock()
+	waiter := &fakeClockWaiter{
+		deadline: clock.now.Add(duration),
+		ch:       make(chan time.Time, 1),
+	}
+	clock.waiters = append(clock.waiters, waiter)
+	clock.mut.Unlock()
+
+	clock.afterCalled <- duration
+
+	return waiter.ch
+}
+
+// Advance moves the clock forward, notifying all the waiters whose deadline was reached
+func (clock *fakeClock) Advance(duration time.Duration) {
+	clock.mut.Lock()
+	defer clock.mut.Unlock()
+
+	clock.now = clock.now.Add(duration)
+	remainingWaiters := make([]*fakeClockWaiter, 0, len(clock.waiters))
+	for _, waiter := range clock.waiters {
+		if clock.now.Before(waiter.deadline) {
+			remainingWaiters = append(remainingWaiters, waiter)
+			continue
+		}
+
+		waiter.ch <- clock.now
+	}
+	clock.waiters = remainingWaiters
+}
+
+func waitForSignal(t *testing.T, ch <-chan struct{}, message string) {
+	select {
+	case <-ch:
+	case <-time.After(waitTimeout):
+		require.Fail(t, "timeout: "+message)
+	}
+}
+
+func createNotifyingSenderHandler(readyCh chan time.Time, executedCh chan struct{}) *mock.SenderHandlerStub {
+	return &mock.SenderHandlerStub{
+		ExecutionReadyChannelCalled: func() <-chan time.Time {
+			return readyCh
+		},
+		ExecuteCalled: func() {
+			executedCh <- struct{}{}
+		},
+	}
+}
+
+// renewingSenderHandler is a sender handler which renews its execution ready channel on each execution, as the
+// timer based senders do
+type renewingSenderHandler struct {
+	*mock.SenderHandlerStub
+	mut     sync.RWMutex
+	readyCh chan time.Time
+}
+
+func createRenewingSenderHandler(executedCh chan struct{}) *renewingSenderHandler {
+	handler := &renewingSenderHandler{
+		readyCh: make(chan time.Time, 1),
+	}
+	handler.SenderHandlerStub = &mock.SenderHandlerStub{
+		ExecutionReadyChannelCalled: func() <-chan time.Time {
+			handler.mut.RLock()
+			defer handler.mut.RUnlock()
+
+			return handler.readyCh
+		},
+		ExecuteCalled: func() {
+			// widen the window in which a waiting routine could still observe the old channel
+			time.Sleep(time.Millisecond * 10)
+
+			handler.mut.Lock()
+			handler.readyCh = make(chan time.Time, 1)
+			handler.mut.Unlock()
+
+			executedCh <- struct{}{}
+		},
+	}
+
+	return handler
+}
+
+func (handler *renewingSenderHandler) signalReady() {
+	handler.mut.RLock()
+	defer handler.mut.RUnlock()
+
+	handler.readyCh <- time.Now()
+}
+
 func TestRoutineHandler_ShouldWork(t *testing.T) {
 	t.Parallel()
 
@@ -18,114 +146,99 @@ func TestRoutineHandler_ShouldWork(t *testing.T) {
 		ch1 := make(chan time.Time)
 		ch2 := make(chan time.Time)
 		ch3 := make(chan struct{})
+		executed1 := make(chan struct{}, 10)
+		executed2 := make(chan struct{}, 10)
+		executed3 := make(chan struct{}, 10)
 
-		numExecuteCalled1 := uint32(0)
-		numExecuteCalled2 := uint32(0)
-		numExecuteCalled3 := uint32(0)
-
-		handler1 := &mock.SenderHandlerStub{
-			ExecutionReadyChannelCalled: func() <-chan time.Time {
-				return ch1
-			},
-			ExecuteCalled: func() {
-				atomic.AddUint32(&numExecuteCalled1, 1)
-			},
-		}
-		handler2 := &mock.SenderHandlerStub{
-			ExecutionReadyChannelCalled: func() <-chan time.Time {
-				return ch2
-			},
-			ExecuteCalled: func() {
-				atomic.AddUint32(&numExecuteCalled2, 1)
-			},
-		}
+		handler1 := createNotifyingSenderHandler(ch1, executed1)
+		handler2 := createNotifyingSenderHandler(ch2, executed2)
 		handler3 := &mock.HardforkHandlerStub{
 			ShouldTriggerHardforkCalled: func() <-chan struct{} {
 				return ch3
 			},
 			ExecuteCalled: func() {
-				atomic.AddUint32(&numExecuteCalled3, 1)
+				executed3 <- struct{}{}
 			},
 		}
 
-		handler := newRoutineHandler(handler1, handler2, handler3)
-		handler.delayAfterHardforkMessageBroadcast = time.Second
-		time.Sleep(time.Second) // wait for the go routine start
+		clock := newFakeClock(time.Unix(1000, 0))
+		handler := newRoutineHandlerWithClock(handler1, handler2, handler3, clock)
+		defer handler.closeProcessLoop()
+
+		waitForSignal(t, executed1, "initial call of handler 1")
+		waitForSignal(t, executed2, "initial call of handler 2")
 
-		assert.Equal(t, uint32(1), atomic.LoadUint32(&numExecuteCalled1)) // initial call
-		assert.Equal(t, uint32(1), atomic.LoadUint32(&numExecuteCalled2)) // initial call
+		ch1 <- time.Now()
+		waitForSignal(t, executed1, "second call of handler 1")
+		ch2 <- time.Now()
+		waitForSignal(t, executed2, "second call of handler 2")
+		ch3 <- struct{}{}
+		waitForSignal(t, executed3, "call of hardfork handler")
 
-		go func() {
-			time.Sleep(time.Millisecond * 100)
-			ch1 <- time.Now()
-		}()
-		go func() {
-			time.Sleep(time.Millisecond * 100)
-			ch2 <- time.Now()
-		}()
-		go func() {
-			time.Sleep(time.Millisecond * 100)
-			ch3 <- struct{}{}
-		}()
+		assert.Equal(t, handler.delayAfterHardforkMessageBroadcast, <-clock.afterCalled)
+		clock.Advance(handler.delayAfterHardforkMessageBroadcast)
 
-		time.Sleep(time.Second * 3) // wait for the iteration
+		// the loop continues after the hardfork delay elapsed
+		ch1 <- time.Now()
+		waitForSignal(t, executed1, "call of handler 1 after hardfork delay")
 
-		assert.Equal(t, uint32(2), atomic.LoadUint32(&numExecuteCalled1))
-		assert.Equal(t, uint32(2), atomic.LoadUint32(&numExecuteCalled2))
-		assert.Equal(t, uint32(1), atomic.LoadUint32(&numExecuteCalled3))
+		assert.Equal(t, 0, len(executed1))
+		assert.Equal(t, 0, len(executed2))
+		assert.Equal(t, 0, len(executed3))
 	})
 	t.Run("close should work", func(t *testing.T) {
 		t.Parallel()
 
-		ch1 := make(chan time.Time)
-		ch2 := make(chan time.Time)
-
-		numExecuteCalled1 := uint32(0)
-		numExecuteCalled2 := uint32(0)
+		executed1 := make(chan struct{}, 10)
+		executed2 := make(chan struct{}, 10)
+		closed := make(chan struct{}, 10)
 
-		numCloseCalled1 := uint32(0)
-		numCloseCalled2 := uint32(0)
-
-		handler1 := &mock.SenderHandlerStub{
-			ExecutionReadyChannelCalled: func() <-chan time.Time {
-				return ch1
-			},
-			ExecuteCalled: func() {
-				atomic.AddUint32(&numExecuteCalled1, 1)
-			},
-			CloseCalled: func() {
-				atomic.AddUint32(&numCloseCalled1, 1)
-			},
+		handler1 := createNotifyingSenderHandler(make(chan time.Time), executed1)
+		handler1.CloseCalled = func() {
+			closed <- struct{}{}
 		}
-		handler2 := &mock.SenderHandlerStub{
-			ExecutionReadyChannelCalled: func() <-chan time.Time {
-				return ch2
-			},
-			ExecuteCalled: func() {
-				atomic.AddUint32(&numExecuteCalled2, 1)
-			},
-			CloseCalled: func() {
-				atomic.AddUint32(&numCloseCalled2, 1)
-			},
+		handler2 := createNotifyingSenderHandler(make(chan time.Time), executed2)
+		handler2.CloseCalled = func() {
+			closed <- struct{}{}
 		}
 		handler3 := &mock.HardforkHandlerStub{}
 
-		rh := newRoutineHandler(handler1, handler2, handler3)
-		time.Sleep(time.Second) // wait for the go routine start
-
-		assert.Equal(t, uint32(1), atomic.LoadUint32(&numExecuteCalled1)) // initial call
-		assert.Equal(t, uint32(1), atomic.LoadUint32(&numExecuteCalled2)) // initial call
-		assert.Equal(t, uint32(0), atomic.LoadUint32(&numCloseCalled1))
-		assert.Equal(t, uint32(0), atomic.LoadUint32(&numCloseCalled2))
+		rh := newRoutineHandlerWithClock(handler1, handler2, handler3, newFakeClock(time.Unix(1000, 0)))
+		waitForSignal(t, executed1, "initial call of handler 1")
+		waitForSignal(t, executed2, "initial call of handler 2")
+		assert.Equal(t, 0, len(closed))
 
 		rh.closeProcessLoop()
 
-		time.Sleep(time.Second) // wait for the go routine to stop
+		waitForSignal(t, closed, "close of handler 1")
+		waitForSignal(t, closed, "close of handler 2")
+		assert.Equal(t, 0, len(executed1))
+		assert.Equal(t, 0, len(executed2))
+	})
+	t.Run("handlers renewing their channel on each execution should be called each time they are ready", func(t *testing.T) {
+		t.Parallel()
+
+		executed1 := make(chan struct{}, 10)
+		executed2 := make(chan struct{}, 10)
+
+		handler1 := createRenewingSenderHandler(executed1)
+		handler2 := createRenewingSenderHandler(executed2)
+
+		handler := newRoutineHandlerWithClock(handler1, handler2, &mock.HardforkHandlerStub{}, newFakeClock(time.Unix(1000, 0)))
+		defer handler.closeProcessLoop()
+
+		waitForSignal(t, executed1, "initial call of handler 1")
+		waitForSignal(t, executed2, "initial call of handler 2")
+
+		for i := 0; i < 3; i++ {
+			handler1.signalReady()
+			waitForSignal(t, executed1, fmt.Sprintf("call %d of handler 1", i+2))
+			handler2.signalReady()
+			waitForSignal(t, executed2, fmt.Sprintf("call %d of handler 2", i+2))
+		}
 
-		assert.Equal(t, uint32(1), atomic.LoadUint32(&numExecuteCalled1))
-		assert.Equal(t, uint32(1), atomic.LoadUint32(&numExecuteCalled2))
-		assert.Equal(t, uint32(1), atomic.LoadUint32(&numCloseCalled1))
-		assert.Equal(t, uint32(1), atomic.LoadUint32(&numCloseCalled2))
+		assert.Equal(t, 0, len(executed1))
+		assert.Equal(t, 0, len(executed2))
 	})
 }
 
@@ -135,66 +248,413 @@ func TestRoutineHandler_Close(t *testing.T) {
 	t.Run("Close() call should end the go routine", func(t *testing.T) {
 		t.Parallel()
 
-		numCloseCalled := uint32(0)
+		closed := make(chan struct{}, 10)
 		handler1 := &mock.SenderHandlerStub{
 			CloseCalled: func() {
-				atomic.AddUint32(&numCloseCalled, 1)
+				closed <- struct{}{}
 			},
 		}
 		handler2 := &mock.SenderHandlerStub{
 			CloseCalled: func() {
-				atomic.AddUint32(&numCloseCalled, 1)
+				closed <- struct{}{}
 			},
 		}
 		handler3 := &mock.HardforkHandlerStub{
 			CloseCalled: func() {
-				atomic.AddUint32(&numCloseCalled, 1)
+				closed <- struct{}{}
 			},
 		}
 
-		rh := newRoutineHandler(handler1, handler2, handler3)
+		rh := newRoutineHandlerWithClock(handler1, handler2, handler3, newFakeClock(time.Unix(1000, 0)))
 
 		rh.closeProcessLoop()
-		time.Sleep(time.Second)
 
-		assert.Equal(t, uint32(3), atomic.LoadUint32(&numCloseCalled))
+		waitForSignal(t, closed, "first close")
+		waitForSignal(t, closed, "second close")
+		waitForSignal(t, closed, "third close")
 	})
 	t.Run("Close() call while hardfork handler is triggered should close immediately", func(t *testing.T) {
 		t.Parallel()
 
 		ch := make(chan struct{})
-		numCloseCalled := uint32(0)
+		closed := make(chan struct{}, 10)
 		handler1 := &mock.SenderHandlerStub{
 			CloseCalled: func() {
-				atomic.AddUint32(&numCloseCalled, 1)
+				closed <- struct{}{}
 			},
 		}
 		handler2 := &mock.SenderHandlerStub{
 			CloseCalled: func() {
-				atomic.AddUint32(&numCloseCalled, 1)
+				closed <- struct{}{}
 			},
 		}
 		handler3 := &mock.HardforkHandlerStub{
 			CloseCalled: func() {
-				atomic.AddUint32(&numCloseCalled, 1)
+				closed <- struct{}{}
 			},
 			ShouldTriggerHardforkCalled: func() <-chan struct{} {
 				return ch
 			},
 		}
 
-		go func() {
-			ch <- struct{}{}
-		}()
+		clock := newFakeClock(time.Unix(1000, 0))
+		rh := newRoutineHandlerWithClock(handler1, handler2, handler3, clock)
+
+		ch <- struct{}{}
+		<-clock.afterCalled // the loop is now waiting after the hardfork broadcast
+
+		rh.closeProcessLoop()
+
+		// the clock is never advanced, so the loop must have ended because of the close call
+		waitForSignal(t, closed, "first close")
+		waitForSignal(t, closed, "second close")
+		waitForSignal(t, closed, "third close")
+	})
+	t.Run("multiple close calls should close each handler once", func(t *testing.T) {
+		t.Parallel()
+
+		var numCloses1, numCloses2, numCloses3, numCloses4 uint32
+		hardforkClosed := make(chan struct{}, 10)
+		handler1 := &mock.SenderHandlerStub{
+			CloseCalled: func() {
+				atomic.AddUint32(&numCloses1, 1)
+			},
+		}
+		handler2 := &mock.SenderHandlerStub{
+			CloseCalled: func() {
+				atomic.AddUint32(&numCloses2, 1)
+			},
+		}
+		handler3 := &mock.HardforkHandlerStub{
+			CloseCalled: func() {
+				atomic.AddUint32(&numCloses3, 1)
+				hardforkClosed <- struct{}{}
+			},
+		}
+		handler4 := &mock.SenderHandlerStub{
+			CloseCalled: func() {
+				atomic.AddUint32(&numCloses4, 1)
+			},
+		}
+
+		rh := newRoutineHandlerWithClock(handler1, handler2, handler3, newFakeClock(time.Unix(1000, 0)))
+		require.Nil(t, rh.AddHandler("handler4", handler4))
+
+		assert.NotPanics(t, func() {
+			wg := sync.WaitGroup{}
+			wg.Add(2)
+			for i := 0; i < 2; i++ {
+				go func() {
+					rh.closeProcessLoop()
+					wg.Done()
+				}()
+			}
+			wg.Wait()
+
+			rh.closeProcessLoop()
+		})
+
+		// the hardfork handler is the last one closed by the process loop
+		waitForSignal(t, hardforkClosed, "hardfork handler close")
+		_ = rh.RemoveHandler("handler4")
+
+		assert.Equal(t, uint32(1), atomic.LoadUint32(&numCloses1))
+		assert.Equal(t, uint32(1), atomic.LoadUint32(&numCloses2))
+		assert.Equal(t, uint32(1), atomic.LoadUint32(&numCloses3))
+		assert.Equal(t, uint32(1), atomic.LoadUint32(&numCloses4))
+		assert.Equal(t, 0, len(hardforkClosed))
+	})
+}
+
+func TestRoutineHandler_NextExecution(t *testing.T) {
+	t.Parallel()
+
+	clock := newFakeClock(time.Unix(1000, 0))
+	ch2 := make(chan time.Time)
+	executed2 := make(chan struct{}, 10)
+	closed := make(chan struct{}, 10)
+	handler1 := &mock.SenderHandlerStub{
+		ExecutionIntervalCalled: func() time.Duration {
+			return time.Minute
+		},
+		ExecuteCalled: func() {
+			// each call moves the clock 10 seconds ahead
+			clock.Advance(time.Second * 10)
+		},
+		CloseCalled: func() {
+			closed <- struct{}{}
+		},
+	}
+	handler2 := &mock.SenderHandlerStub{
+		ExecutionReadyChannelCalled: func() <-chan time.Time {
+			return ch2
+		},
+		ExecutionIntervalCalled: func() time.Duration {
+			return time.Second * 30
+		},
+		ExecuteCalled: func() {
+			clock.Advance(time.Second * 10)
+			executed2 <- struct{}{}
+		},
+		CloseCalled: func() {
+			closed <- struct{}{}
+		},
+	}
+
+	rh := newRoutineHandlerWithClock(handler1, handler2, &mock.HardforkHandlerStub{}, clock)
+
+	waitForSignal(t, executed2, "initial call of handler 2")
+	ch2 <- time.Now()
+	waitForSignal(t, executed2, "second call of handler 2")
+
+	// closing the loop makes sure that all the executions were recorded
+	rh.closeProcessLoop()
+	waitForSignal(t, closed, "close of handler 1")
+	waitForSignal(t, closed, "close of handler 2")
+
+	nextExecution, found := rh.NextExecution(PeerAuthenticationSenderID)
+	assert.True(t, found)
+	assert.Equal(t, time.Unix(1010, 0).Add(time.Minute), nextExecution)
+
+	nextExecution, found = rh.NextExecution(HeartbeatSenderID)
+	assert.True(t, found)
+	assert.Equal(t, time.Unix(1030, 0).Add(time.Second*30), nextExecution)
+
+	nextExecution, found = rh.NextExecution("unknown")
+	assert.False(t, found)
+	assert.True(t, nextExecution.IsZero())
+}
+
+func TestRoutineHandler_PanicInHandlerShouldNotStopTheLoop(t *testing.T) {
+	t.Parallel()
+
+	ch1 := make(chan time.Time)
+	ch2 := make(chan time.Time)
+	executed1 := make(chan struct{}, 10)
+	executed2 := make(chan struct{}, 10)
+
+	numExecuteCalled1 := uint32(0)
+	handler1 := &mock.SenderHandlerStub{
+		ExecutionReadyChannelCalled: func() <-chan time.Time {
+			return ch1
+		},
+		ExecuteCalled: func() {
+			executed1 <- struct{}{}
+			if atomic.AddUint32(&numExecuteCalled1, 1) == 1 {
+				panic("first execute panics")
+			}
+		},
+	}
+	handler2 := createNotifyingSenderHandler(ch2, executed2)
+
+	rh := newRoutineHandlerWithClock(handler1, handler2, &mock.HardforkHandlerStub{}, newFakeClock(time.Unix(1000, 0)))
+	defer rh.closeProcessLoop()
+
+	waitForSignal(t, executed1, "initial call of handler 1, panicked")
+	waitForSignal(t, executed2, "initial call of handler 2")
+	assert.Equal(t, uint32(1), rh.NumPanics())
+
+	ch2 <- time.Now()
+	waitForSignal(t, executed2, "second call of handler 2")
+	ch1 <- time.Now()
+	waitForSignal(t, executed1, "second call of handler 1")
+	ch2 <- time.Now() // makes sure the second call of handler 1 completed
+	waitForSignal(t, executed2, "third call of handler 2")
+
+	assert.Equal(t, uint32(2), atomic.LoadUint32(&numExecuteCalled1))
+	assert.Equal(t, uint32(1), rh.NumPanics())
+}
+
+func TestRoutineHandler_Errors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("failing handler should emit the error", func(t *testing.T) {
+		t.Parallel()
+
+		ch1 := make(chan time.Time)
+		handler1 := &mock.SenderHandlerStub{
+			ExecutionReadyChannelCalled: func() <-chan time.Time {
+				return ch1
+			},
+			ExecuteCalled: func() {
+				panic("execute failed")
+			},
+		}
+
+		rh := newRoutineHandlerWithClock(handler1, &mock.SenderHandlerStub{}, &mock.HardforkHandlerStub{}, newFakeClock(time.Unix(1000, 0)))
+		defer rh.closeProcessLoop()
+
+		select {
+		case err := <-rh.Errors():
+			assert.True(t, errors.Is(err, heartbeat.ErrHandlerExecutionPanicked))
+			assert.Contains(t, err.Error(), PeerAuthenticationSenderID)
+			assert.Contains(t, err.Error(), "execute failed")
+		case <-time.After(waitTimeout):
+			require.Fail(t, "timeout while waiting for the error")
+		}
+	})
+	t.Run("full buffer should drop the oldest errors", func(t *testing.T) {
+		t.Parallel()
 
-		rh := newRoutineHandler(handler1, handler2, handler3)
+		rh := &routineHandler{
+			errorsChan: make(chan error, maxBufferedErrors),
+		}
+		for i := 0; i < maxBufferedErrors+2; i++ {
+			rh.notifyError(fmt.Errorf("error %d", i))
+		}
 
-		time.Sleep(time.Second)
+		require.Equal(t, maxBufferedErrors, len(rh.Errors()))
+		assert.Equal(t, "error 2", (<-rh.Errors()).Error())
+	})
+	t.Run("the channel should be closed on shutdown", func(t *testing.T) {
+		t.Parallel()
 
+		rh := newRoutineHandlerWithClock(&mock.SenderHandlerStub{}, &mock.SenderHandlerStub{}, &mock.HardforkHandlerStub{}, newFakeClock(time.Unix(1000, 0)))
 		rh.closeProcessLoop()
-		time.Sleep(time.Second)
 
-		assert.Equal(t, uint32(3), atomic.LoadUint32(&numCloseCalled))
+		select {
+		case _, ok := <-rh.Errors():
+			assert.False(t, ok)
+		case <-time.After(waitTimeout):
+			require.Fail(t, "timeout while waiting for the channel to close")
+		}
+	})
+}
+
+func TestRoutineHandler_HandlersRegistration(t *testing.T) {
+	t.Parallel()
+
+	t.Run("initial handlers should be registered", func(t *testing.T) {
+		t.Parallel()
+
+		rh := newRoutineHandlerWithClock(&mock.SenderHandlerStub{}, &mock.SenderHandlerStub{}, &mock.HardforkHandlerStub{}, newFakeClock(time.Unix(1000, 0)))
+		defer rh.closeProcessLoop()
+
+		assert.Equal(t, []string{HeartbeatSenderID, PeerAuthenticationSenderID}, rh.HandlerIDs())
+	})
+	t.Run("invalid or duplicated handlers should be rejected", func(t *testing.T) {
+		t.Parallel()
+
+		rh := newRoutineHandlerWithClock(&mock.SenderHandlerStub{}, &mock.SenderHandlerStub{}, &mock.HardforkHandlerStub{}, newFakeClock(time.Unix(1000, 0)))
+		defer rh.closeProcessLoop()
+
+		err := rh.AddHandler("", &mock.SenderHandlerStub{})
+		assert.Equal(t, heartbeat.ErrEmptyHandlerID, err)
+
+		err = rh.AddHandler("custom", nil)
+		assert.Equal(t, heartbeat.ErrNilSenderHandler, err)
+
+		for _, handlerID := range []string{PeerAuthenticationSenderID, HeartbeatSenderID, HardforkSenderID} {
+			err = rh.AddHandler(handlerID, &mock.SenderHandlerStub{})
+			assert.True(t, errors.Is(err, heartbeat.ErrDuplicatedHandlerID))
+			assert.Contains(t, err.Error(), handlerID)
+		}
+
+		err = rh.AddHandler("custom", &mock.SenderHandlerStub{})
+		assert.Nil(t, err)
+		err = rh.AddHandler("custom", &mock.SenderHandlerStub{})
+		assert.True(t, errors.Is(err, heartbeat.ErrDuplicatedHandlerID))
+
+		assert.Equal(t, []string{"custom", HeartbeatSenderID, PeerAuthenticationSenderID}, rh.HandlerIDs())
+	})
+	t.Run("added handler should be executed and looked up by its identifier", func(t *testing.T) {
+		t.Parallel()
+
+		clock := newFakeClock(time.Unix(1000, 0))
+		rh := newRoutineHandlerWithClock(&mock.SenderHandlerStub{}, &mock.SenderHandlerStub{}, &mock.HardforkHandlerStub{}, clock)
+		defer rh.closeProcessLoop()
+
+		readyCh := make(chan time.Time)
+		executed := make(chan struct{}, 10)
+		customHandler := createNotifyingSenderHandler(readyCh, executed)
+		customHandler.ExecutionIntervalCalled = func() time.Duration {
+			return time.Minute
+		}
+
+		_, found := rh.Stats("custom")
+		assert.False(t, found)
+
+		err := rh.AddHandler("custom", customHandler)
+		require.Nil(t, err)
+		waitForSignal(t, executed, "initial call of the custom handler")
+
+		clock.Advance(time.Second)
+		readyCh <- time.Now()
+		waitForSignal(t, executed, "second call of the custom handler")
+		readyCh <- time.Now() // makes sure the statistics of the second call were recorded
+		waitForSignal(t, executed, "third call of the custom handler")
+
+		assert.Eventually(t, func() bool {
+			stats, _ := rh.Stats("custom")
+			return stats.NumExecutions == 3
+		}, waitTimeout, time.Millisecond)
+
+		stats, found := rh.Stats("custom")
+		assert.True(t, found)
+		assert.Equal(t, HandlerStats{NumExecutions: 3, LastExecution: time.Unix(1001, 0)}, stats)
+
+		nextExecution, found := rh.NextExecution("custom")
+		assert.True(t, found)
+		assert.Equal(t, time.Unix(1001, 0).Add(time.Minute), nextExecution)
+	})
+	t.Run("removed handler should be closed and not executed anymore", func(t *testing.T) {
+		t.Parallel()
+
+		rh := newRoutineHandlerWithClock(&mock.SenderHandlerStub{}, &mock.SenderHandlerStub{}, &mock.HardforkHandlerStub{}, newFakeClock(time.Unix(1000, 0)))
+		defer rh.closeProcessLoop()
+
+		readyCh := make(chan time.Time, 1)
+		executed := make(chan struct{}, 10)
+		closed := make(chan struct{}, 10)
+		customHandler := createNotifyingSenderHandler(readyCh, executed)
+		customHandler.CloseCalled = func() {
+			closed <- struct{}{}
+		}
+
+		err := rh.AddHandler("custom", customHandler)
+		require.Nil(t, err)
+		waitForSignal(t, executed, "initial call of the custom handler")
+
+		err = rh.RemoveHandler("custom")
+		assert.Nil(t, err)
+		waitForSignal(t, closed, "close of the custom handler")
+
+		readyCh <- time.Now()
+		select {
+		case <-executed:
+			assert.Fail(t, "removed handler should not be executed")
+		case <-time.After(time.Millisecond * 100):
+		}
+
+		_, found := rh.Stats("custom")
+		assert.False(t, found)
+
+		err = rh.RemoveHandler("custom")
+		assert.True(t, errors.Is(err, heartbeat.ErrHandlerNotFound))
 	})
+	t.Run("panics should be counted per handler", func(t *testing.T) {
+		t.Parallel()
 
+		executed := make(chan struct{}, 10)
+		handler1 := &mock.SenderHandlerStub{
+			ExecuteCalled: func() {
+				panic("execute failed")
+			},
+		}
+		handler2 := createNotifyingSenderHandler(make(chan time.Time), executed)
+
+		rh := newRoutineHandlerWithClock(handler1, handler2, &mock.HardforkHandlerStub{}, newFakeClock(time.Unix(1000, 0)))
+		defer rh.closeProcessLoop()
+
+		waitForSignal(t, executed, "initial call of handler 2")
+		assert.Eventually(t, func() bool {
+			stats, _ := rh.Stats(HeartbeatSenderID)
+			return stats.NumExecutions == 1
+		}, waitTimeout, time.Millisecond)
+
+		stats, _ := rh.Stats(PeerAuthenticationSenderID)
+		assert.Equal(t, uint32(1), stats.NumPanics)
+		stats, _ = rh.Stats(HeartbeatSenderID)
+		assert.Equal(t, uint32(0), stats.NumPanics)
+	})
 }
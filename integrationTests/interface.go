@@ -119,5 +119,6 @@ type Facade interface {
 	GetWaitingManagedKeys() ([]string, error)
 	GetWaitingEpochsLeftForPublicKey(publicKey string) (uint32, error)
 	GetSCRsByTxHash(txHash string, scrHash string) ([]*transaction.ApiSmartContractResult, error)
+	GetTransactionDetails(txHash string, originalSender string) (*common.ApiTransactionDetails, error)
 	IsInterfaceNil() bool
 }
@@ -1,6 +1,8 @@
 package logs
 
 import (
+	"fmt"
+
 	"github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-core-go/core/check"
 	"github.com/multiversx/mx-chain-core-go/marshal"
@@ -12,6 +14,13 @@ type ArgsNewLogsFacade struct {
 	StorageService  dataRetriever.StorageService
 	Marshaller      marshal.Marshalizer
 	PubKeyConverter core.PubkeyConverter
+	// MaxTopicSize is the maximum size of an event topic returned by the API, the larger topics being truncated. The
+	// truncation is opt-in and lossy: the dropped bytes cannot be fetched through the API, the truncation being only
+	// flagged on the logs details. Zero disables the truncation
+	MaxTopicSize uint32
+	// EventDataDecoders holds the decoders of the events data, by event identifier. The decoded data is returned on the
+	// logs details, the raw data of the events being kept as it is
+	EventDataDecoders map[string]EventDataDecoder
 }
 
 func (args *ArgsNewLogsFacade) check() error {
@@ -24,6 +33,11 @@ func (args *ArgsNewLogsFacade) check() error {
 	if check.IfNil(args.PubKeyConverter) {
 		return core.ErrNilPubkeyConverter
 	}
+	for identifier, decoder := range args.EventDataDecoders {
+		if decoder == nil {
+			return fmt.Errorf("%w for identifier %s", errNilEventDataDecoder, identifier)
+		}
+	}
 
 	return nil
 }
@@ -5,3 +5,5 @@ import "errors"
 var errCannotCreateLogsFacade = errors.New("cannot create logs facade")
 var errCannotLoadLogs = errors.New("cannot load log(s)")
 var errCannotUnmarshalLog = errors.New("cannot unmarshal log")
+var errNilEventDataDecoder = errors.New("nil event data decoder")
+var errCannotDecodeEventData = errors.New("cannot decode event data")
@@ -0,0 +1,13 @@
+package logs
+
+import (
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-go/common"
+)
+
+// LogsConverter defines the actions of a component able to convert transaction logs into API resources
+type LogsConverter interface {
+	TxLogToApiResource(logKey []byte, log *transaction.Log) *transaction.ApiLogs
+	TxLogToApiLogsDetails(logKey []byte, log *transaction.Log) *common.ApiLogsDetails
+	IsInterfaceNil() bool
+}
@@ -1,30 +1,127 @@
 package logs
 
 import (
+	"encoding/hex"
+	"fmt"
+	"sync"
+
 	"github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-go/common"
 )
 
+// EventDataDecoder decodes the data field of an event into a structured map
+type EventDataDecoder func(data []byte) (map[string]interface{}, error)
+
 type logsConverter struct {
 	pubKeyConverter core.PubkeyConverter
+	mutDecoders     sync.RWMutex
+	eventDecoders   map[string]EventDataDecoder
+	maxTopicSize    uint32
 }
 
-func newLogsConverter(pubKeyConverter core.PubkeyConverter) *logsConverter {
+func newLogsConverter(pubKeyConverter core.PubkeyConverter, maxTopicSize uint32) *logsConverter {
 	return &logsConverter{
 		pubKeyConverter: pubKeyConverter,
+		eventDecoders:   make(map[string]EventDataDecoder),
+		maxTopicSize:    maxTopicSize,
+	}
+}
+
+// RegisterEventDataDecoder registers the decoder to be used for the data field of the events with the provided identifier
+func (converter *logsConverter) RegisterEventDataDecoder(identifier string, decoder EventDataDecoder) error {
+	if decoder == nil {
+		return errNilEventDataDecoder
+	}
+
+	converter.mutDecoders.Lock()
+	converter.eventDecoders[identifier] = decoder
+	converter.mutDecoders.Unlock()
+
+	return nil
+}
+
+// DecodeEventData decodes the data field of the provided event, using the decoder registered for its identifier.
+// Returns false if no decoder was registered for the event's identifier
+func (converter *logsConverter) DecodeEventData(event *transaction.Events) (map[string]interface{}, bool, error) {
+	if event == nil {
+		return nil, false, nil
+	}
+
+	converter.mutDecoders.RLock()
+	decoder, found := converter.eventDecoders[event.Identifier]
+	converter.mutDecoders.RUnlock()
+	if !found {
+		return nil, false, nil
+	}
+
+	decodedData, err := decoder(event.Data)
+	if err != nil {
+		return nil, true, fmt.Errorf("%w for event %s: %v", errCannotDecodeEventData, event.Identifier, err)
+	}
+
+	return decodedData, true, nil
+}
+
+// TxLogToApiResource converts the provided transaction log into an API resource. The topics larger than the configured
+// max topic size are truncated, the truncation being flagged by TxLogToApiLogsDetails
+func (converter *logsConverter) TxLogToApiResource(_ []byte, log *transaction.Log) *transaction.ApiLogs {
+	apiLogs, _ := converter.txLogToApiResource(log)
+
+	return apiLogs
+}
+
+// TxLogToApiLogsDetails returns the details of the provided transaction log which are not available on the API resource.
+// The data of the events having a registered decoder is decoded, the raw data being left untouched on the API resource
+func (converter *logsConverter) TxLogToApiLogsDetails(logKey []byte, log *transaction.Log) *common.ApiLogsDetails {
+	apiLogs, topicsTruncated := converter.txLogToApiResource(log)
+
+	return &common.ApiLogsDetails{
+		TxHash:            logKeyToTxHash(logKey),
+		NumEvents:         len(apiLogs.Events),
+		TopicsTruncated:   topicsTruncated,
+		DecodedEventsData: converter.decodeEventsData(logKey, apiLogs.Events),
 	}
 }
 
-func (converter *logsConverter) txLogToApiResource(logKey []byte, log *transaction.Log) *transaction.ApiLogs {
+// decodeEventsData returns the decoded data of the provided events, aligned with the events. Nil is returned if none of
+// the events could be decoded
+func (converter *logsConverter) decodeEventsData(logKey []byte, events []*transaction.Events) []map[string]interface{} {
+	var decodedEventsData []map[string]interface{}
+	for i, event := range events {
+		decodedData, found, err := converter.DecodeEventData(event)
+		if err != nil {
+			log.Debug("logsConverter: cannot decode event data", "tx hash", logKeyToTxHash(logKey), "err", err)
+			continue
+		}
+		if !found {
+			continue
+		}
+
+		if decodedEventsData == nil {
+			decodedEventsData = make([]map[string]interface{}, len(events))
+		}
+		decodedEventsData[i] = decodedData
+	}
+
+	return decodedEventsData
+}
+
+// txLogToApiResource converts the provided transaction log into an API resource, returning true if any of the topics
+// was truncated
+func (converter *logsConverter) txLogToApiResource(log *transaction.Log) (*transaction.ApiLogs, bool) {
 	events := make([]*transaction.Events, len(log.Events))
+	truncated := false
 
 	for i, event := range log.Events {
 		eventAddress := converter.encodeAddress(event.Address)
+		topics, topicsTruncated := converter.truncateTopics(event.Topics)
+		truncated = truncated || topicsTruncated
 
 		events[i] = &transaction.Events{
 			Address:        eventAddress,
 			Identifier:     string(event.Identifier),
-			Topics:         event.Topics,
+			Topics:         topics,
 			Data:           event.Data,
 			AdditionalData: event.AdditionalData,
 		}
@@ -35,9 +132,49 @@ func (converter *logsConverter) txLogToApiResource(logKey []byte, log *transacti
 	return &transaction.ApiLogs{
 		Address: logAddress,
 		Events:  events,
+	}, truncated
+}
+
+// truncateTopics returns the provided topics, with the oversized ones truncated to the max topic size. The provided
+// slice is not altered: a new one is created only if at least one topic has to be truncated. A zero max topic size
+// disables the truncation
+func (converter *logsConverter) truncateTopics(topics [][]byte) ([][]byte, bool) {
+	if converter.maxTopicSize == 0 {
+		return topics, false
+	}
+
+	maxTopicSize := int(converter.maxTopicSize)
+	var truncatedTopics [][]byte
+	for i, topic := range topics {
+		if len(topic) <= maxTopicSize {
+			continue
+		}
+
+		if truncatedTopics == nil {
+			truncatedTopics = make([][]byte, len(topics))
+			copy(truncatedTopics, topics)
+		}
+		truncatedTopics[i] = topic[:maxTopicSize:maxTopicSize]
+	}
+
+	if truncatedTopics == nil {
+		return topics, false
 	}
+
+	return truncatedTopics, true
+}
+
+// logKeyToTxHash returns the hex encoded hash of the transaction or smart contract result which generated the log
+// stored under the provided key, as the logs are keyed by the hash of their originating transaction
+func logKeyToTxHash(logKey []byte) string {
+	return hex.EncodeToString(logKey)
 }
 
 func (converter *logsConverter) encodeAddress(pubkey []byte) string {
 	return converter.pubKeyConverter.SilentEncode(pubkey, log)
 }
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (converter *logsConverter) IsInterfaceNil() bool {
+	return converter == nil
+}
@@ -1,16 +1,19 @@
 package logs
 
 import (
+	"encoding/hex"
+	"errors"
 	"testing"
 
 	"github.com/multiversx/mx-chain-core-go/core/pubkeyConverter"
 	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-go/testscommon"
 	"github.com/stretchr/testify/require"
 )
 
 func TestLogsConverter_TxLogToApiResourceShouldWork(t *testing.T) {
 	pkConverter, _ := pubkeyConverter.NewBech32PubkeyConverter(32, "erd")
-	logsConverter := newLogsConverter(pkConverter)
+	logsConverter := newLogsConverter(pkConverter, 0)
 
 	contractAddressBech32 := "erd1qqqqqqqqqqqqqpgqxwakt2g7u9atsnr03gqcgmhcv38pt7mkd94q6shuwt"
 	contractAddress, _ := pkConverter.Decode(contractAddressBech32)
@@ -39,6 +42,213 @@ func TestLogsConverter_TxLogToApiResourceShouldWork(t *testing.T) {
 		},
 	}
 
-	apiResource := logsConverter.txLogToApiResource([]byte("aaaabbbb"), txLog)
+	apiResource := logsConverter.TxLogToApiResource([]byte("aaaabbbb"), txLog)
 	require.Equal(t, expectedApiResource, apiResource)
 }
+
+func TestLogsConverter_IsInterfaceNil(t *testing.T) {
+	t.Parallel()
+
+	var converter *logsConverter
+	require.True(t, converter.IsInterfaceNil())
+
+	converter = newLogsConverter(testscommon.NewPubkeyConverterMock(32), 0)
+	require.False(t, converter.IsInterfaceNil())
+}
+
+func TestLogsConverter_DecodeEventData(t *testing.T) {
+	t.Parallel()
+
+	customIdentifier := "customEvent"
+	decoder := func(data []byte) (map[string]interface{}, error) {
+		if len(data) == 0 {
+			return nil, errors.New("empty data")
+		}
+
+		return map[string]interface{}{
+			"value": string(data),
+		}, nil
+	}
+
+	t.Run("nil decoder should error", func(t *testing.T) {
+		t.Parallel()
+
+		converter := newLogsConverter(&testscommon.PubkeyConverterMock{}, 0)
+		err := converter.RegisterEventDataDecoder(customIdentifier, nil)
+		require.Equal(t, errNilEventDataDecoder, err)
+	})
+	t.Run("registered identifier should decode", func(t *testing.T) {
+		t.Parallel()
+
+		converter := newLogsConverter(&testscommon.PubkeyConverterMock{}, 0)
+		err := converter.RegisterEventDataDecoder(customIdentifier, decoder)
+		require.Nil(t, err)
+
+		event := &transaction.Events{
+			Identifier: customIdentifier,
+			Data:       []byte("data"),
+		}
+		decodedData, found, err := converter.DecodeEventData(event)
+		require.Nil(t, err)
+		require.True(t, found)
+		require.Equal(t, map[string]interface{}{"value": "data"}, decodedData)
+		require.Equal(t, []byte("data"), event.Data)
+	})
+	t.Run("decoder error should be returned", func(t *testing.T) {
+		t.Parallel()
+
+		converter := newLogsConverter(&testscommon.PubkeyConverterMock{}, 0)
+		_ = converter.RegisterEventDataDecoder(customIdentifier, decoder)
+
+		decodedData, found, err := converter.DecodeEventData(&transaction.Events{Identifier: customIdentifier})
+		require.True(t, errors.Is(err, errCannotDecodeEventData))
+		require.True(t, found)
+		require.Nil(t, decodedData)
+	})
+	t.Run("unknown identifier should pass through", func(t *testing.T) {
+		t.Parallel()
+
+		converter := newLogsConverter(&testscommon.PubkeyConverterMock{}, 0)
+		_ = converter.RegisterEventDataDecoder(customIdentifier, decoder)
+
+		decodedData, found, err := converter.DecodeEventData(&transaction.Events{Identifier: "foo", Data: []byte("data")})
+		require.Nil(t, err)
+		require.False(t, found)
+		require.Nil(t, decodedData)
+
+		decodedData, found, err = converter.DecodeEventData(nil)
+		require.Nil(t, err)
+		require.False(t, found)
+		require.Nil(t, decodedData)
+	})
+}
+
+func TestLogsConverter_TxLogToApiLogsDetails(t *testing.T) {
+	t.Parallel()
+
+	customIdentifier := "customEvent"
+	failingIdentifier := "failingEvent"
+	txLog := &transaction.Log{
+		Address: []byte("address"),
+		Events: []*transaction.Event{
+			{
+				Identifier: []byte("foo"),
+				Data:       []byte("foo data"),
+			},
+			{
+				Identifier: []byte(customIdentifier),
+				Data:       []byte("custom data"),
+			},
+			{
+				Identifier: []byte(failingIdentifier),
+				Data:       []byte("failing data"),
+			},
+		},
+	}
+
+	t.Run("no registered decoder should not return decoded data", func(t *testing.T) {
+		t.Parallel()
+
+		converter := newLogsConverter(&testscommon.PubkeyConverterMock{}, 0)
+		logDetails := converter.TxLogToApiLogsDetails([]byte("log key"), txLog)
+		require.Equal(t, hex.EncodeToString([]byte("log key")), logDetails.TxHash)
+		require.Equal(t, 3, logDetails.NumEvents)
+		require.Nil(t, logDetails.DecodedEventsData)
+	})
+	t.Run("registered decoder should decode the events data", func(t *testing.T) {
+		t.Parallel()
+
+		converter := newLogsConverter(&testscommon.PubkeyConverterMock{}, 0)
+		_ = converter.RegisterEventDataDecoder(customIdentifier, func(data []byte) (map[string]interface{}, error) {
+			return map[string]interface{}{"value": string(data)}, nil
+		})
+		_ = converter.RegisterEventDataDecoder(failingIdentifier, func(data []byte) (map[string]interface{}, error) {
+			return nil, errors.New("decode error")
+		})
+
+		logDetails := converter.TxLogToApiLogsDetails([]byte("log key"), txLog)
+		require.Equal(t, 3, logDetails.NumEvents)
+		expectedDecodedEventsData := []map[string]interface{}{
+			nil,
+			{"value": "custom data"},
+			nil,
+		}
+		require.Equal(t, expectedDecodedEventsData, logDetails.DecodedEventsData)
+
+		// the raw data should be kept on the API resource
+		apiResource := converter.TxLogToApiResource([]byte("log key"), txLog)
+		require.Equal(t, []byte("custom data"), apiResource.Events[1].Data)
+	})
+}
+
+func TestLogsConverter_TxLogToApiResourceWithMaxTopicSize(t *testing.T) {
+	t.Parallel()
+
+	oversizedTopic := []byte("oversized topic")
+	normalTopic := []byte("topic")
+	createTxLog := func() *transaction.Log {
+		return &transaction.Log{
+			Address: []byte("address"),
+			Events: []*transaction.Event{
+				{
+					Address:    []byte("address"),
+					Identifier: []byte("foo"),
+					Topics:     [][]byte{normalTopic, oversizedTopic},
+				},
+				{
+					Address:    []byte("address"),
+					Identifier: []byte("bar"),
+					Topics:     [][]byte{normalTopic},
+				},
+			},
+		}
+	}
+
+	t.Run("zero max topic size should not truncate", func(t *testing.T) {
+		t.Parallel()
+
+		converter := newLogsConverter(&testscommon.PubkeyConverterMock{}, 0)
+		apiResource, truncated := converter.txLogToApiResource(createTxLog())
+		require.False(t, truncated)
+		require.Equal(t, [][]byte{normalTopic, oversizedTopic}, apiResource.Events[0].Topics)
+		require.Equal(t, [][]byte{normalTopic}, apiResource.Events[1].Topics)
+		require.False(t, converter.TxLogToApiLogsDetails([]byte("log key"), createTxLog()).TopicsTruncated)
+	})
+	t.Run("oversized topic should be truncated", func(t *testing.T) {
+		t.Parallel()
+
+		converter := newLogsConverter(&testscommon.PubkeyConverterMock{}, uint32(len(normalTopic)))
+		txLog := createTxLog()
+		apiResource, truncated := converter.txLogToApiResource(txLog)
+		require.True(t, truncated)
+		require.Equal(t, [][]byte{normalTopic, []byte("overs")}, apiResource.Events[0].Topics)
+		require.Equal(t, [][]byte{normalTopic}, apiResource.Events[1].Topics)
+
+		// the source log should not be altered
+		require.Equal(t, createTxLog(), txLog)
+
+		apiResource = converter.TxLogToApiResource([]byte("log key"), createTxLog())
+		require.Equal(t, [][]byte{normalTopic, []byte("overs")}, apiResource.Events[0].Topics)
+
+		logDetails := converter.TxLogToApiLogsDetails([]byte("log key"), createTxLog())
+		require.True(t, logDetails.TopicsTruncated)
+	})
+	t.Run("well sized topics should be left untouched", func(t *testing.T) {
+		t.Parallel()
+
+		converter := newLogsConverter(&testscommon.PubkeyConverterMock{}, uint32(len(oversizedTopic)))
+		apiResource, truncated := converter.txLogToApiResource(createTxLog())
+		require.False(t, truncated)
+		require.Equal(t, [][]byte{normalTopic, oversizedTopic}, apiResource.Events[0].Topics)
+		require.Equal(t, [][]byte{normalTopic}, apiResource.Events[1].Topics)
+		require.False(t, converter.TxLogToApiLogsDetails([]byte("log key"), createTxLog()).TopicsTruncated)
+	})
+}
+
+func TestLogKeyToTxHash(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "", logKeyToTxHash(nil))
+	require.Equal(t, "0a0bff", logKeyToTxHash([]byte{0xa, 0xb, 0xff}))
+	require.Equal(t, "747848617368", logKeyToTxHash([]byte("txHash")))
+}
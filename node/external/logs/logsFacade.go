@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-go/common"
 	logger "github.com/multiversx/mx-chain-logger-go"
 )
 
@@ -11,7 +12,7 @@ var log = logger.GetOrCreate("node/external/logs")
 
 type logsFacade struct {
 	repository *logsRepository
-	converter  *logsConverter
+	converter  LogsConverter
 }
 
 // NewLogsFacade creates a new logs facade
@@ -22,7 +23,13 @@ func NewLogsFacade(args ArgsNewLogsFacade) (*logsFacade, error) {
 	}
 
 	repository := newLogsRepository(args.StorageService, args.Marshaller)
-	converter := newLogsConverter(args.PubKeyConverter)
+	converter := newLogsConverter(args.PubKeyConverter, args.MaxTopicSize)
+	for identifier, decoder := range args.EventDataDecoders {
+		err = converter.RegisterEventDataDecoder(identifier, decoder)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", errCannotCreateLogsFacade, err)
+		}
+	}
 
 	return &logsFacade{
 		repository: repository,
@@ -37,11 +44,21 @@ func (facade *logsFacade) GetLog(logKey []byte, epoch uint32) (*transaction.ApiL
 		return nil, err
 	}
 
-	apiResource := facade.converter.txLogToApiResource(logKey, txLog)
+	apiResource := facade.converter.TxLogToApiResource(logKey, txLog)
 
 	return apiResource, nil
 }
 
+// GetLogDetails loads a transaction log (from storage) and returns its details which are not available on the API logs
+func (facade *logsFacade) GetLogDetails(logKey []byte, epoch uint32) (*common.ApiLogsDetails, error) {
+	txLog, err := facade.repository.getLog(logKey, epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	return facade.converter.TxLogToApiLogsDetails(logKey, txLog), nil
+}
+
 // IncludeLogsInTransactions loads transaction logs from storage and includes them in the provided transaction objects
 // Note: the transaction objects MUST have the field "HashBytes" set in advance.
 func (facade *logsFacade) IncludeLogsInTransactions(txs []*transaction.ApiTransactionResult, logsKeys [][]byte, epoch uint32) error {
@@ -54,7 +71,7 @@ func (facade *logsFacade) IncludeLogsInTransactions(txs []*transaction.ApiTransa
 		key := tx.HashBytes
 		txLog, ok := logsByKey[string(key)]
 		if ok {
-			tx.Logs = facade.converter.txLogToApiResource(key, txLog)
+			tx.Logs = facade.converter.TxLogToApiResource(key, txLog)
 		}
 	}
 
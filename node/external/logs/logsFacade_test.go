@@ -51,6 +51,22 @@ func TestNewLogsFacade(t *testing.T) {
 		require.ErrorContains(t, err, core.ErrNilPubkeyConverter.Error())
 		require.Nil(t, facade)
 	})
+
+	t.Run("NilEventDataDecoder", func(t *testing.T) {
+		arguments := ArgsNewLogsFacade{
+			StorageService:  genericMocks.NewChainStorerMock(7),
+			Marshaller:      marshallerMock.MarshalizerMock{},
+			PubKeyConverter: testscommon.NewPubkeyConverterMock(32),
+			EventDataDecoders: map[string]EventDataDecoder{
+				"customEvent": nil,
+			},
+		}
+
+		facade, err := NewLogsFacade(arguments)
+		require.ErrorIs(t, err, errCannotCreateLogsFacade)
+		require.ErrorContains(t, err, errNilEventDataDecoder.Error())
+		require.Nil(t, facade)
+	})
 }
 
 func TestLogsFacade_GetLogShouldWork(t *testing.T) {
@@ -91,6 +107,89 @@ func TestLogsFacade_GetLogShouldWork(t *testing.T) {
 	require.Equal(t, []byte("Hello World!"), logOnApi.Events[0].Data)
 }
 
+func TestLogsFacade_GetLogShouldUseTheConverter(t *testing.T) {
+	storageService := genericMocks.NewChainStorerMock(7)
+	marshaller := &marshal.GogoProtoMarshalizer{}
+
+	arguments := ArgsNewLogsFacade{
+		StorageService:  storageService,
+		Marshaller:      marshaller,
+		PubKeyConverter: testscommon.NewPubkeyConverterMock(32),
+	}
+
+	logKey := []byte("hello")
+	testLog := &transaction.Log{Address: []byte{0xab, 0xba}}
+	logBytes, err := marshaller.Marshal(testLog)
+	require.Nil(t, err)
+	_ = storageService.Logs.Put(logKey, logBytes)
+
+	expectedApiLogs := &transaction.ApiLogs{Address: "converted"}
+	facade, _ := NewLogsFacade(arguments)
+	facade.converter = &testscommon.LogsConverterStub{
+		TxLogToApiResourceCalled: func(key []byte, txLog *transaction.Log) *transaction.ApiLogs {
+			require.Equal(t, logKey, key)
+			require.Equal(t, testLog.Address, txLog.Address)
+
+			return expectedApiLogs
+		},
+	}
+
+	logOnApi, err := facade.GetLog(logKey, 7)
+	require.Nil(t, err)
+	require.True(t, expectedApiLogs == logOnApi) // pointer testing
+}
+
+func TestLogsFacade_GetLogDetailsShouldWork(t *testing.T) {
+	storageService := genericMocks.NewChainStorerMock(7)
+	marshaller := &marshal.GogoProtoMarshalizer{}
+
+	arguments := ArgsNewLogsFacade{
+		StorageService:  storageService,
+		Marshaller:      marshaller,
+		PubKeyConverter: testscommon.NewPubkeyConverterMock(32),
+		EventDataDecoders: map[string]EventDataDecoder{
+			"customEvent": func(data []byte) (map[string]interface{}, error) {
+				return map[string]interface{}{"value": string(data)}, nil
+			},
+		},
+	}
+
+	testLog := &transaction.Log{
+		Address: []byte{0xab, 0xba},
+		Events: []*transaction.Event{
+			{
+				Identifier: []byte("helloWorld"),
+				Data:       []byte("Hello World!"),
+			},
+			{
+				Identifier: []byte("customEvent"),
+				Data:       []byte("custom data"),
+			},
+		},
+	}
+
+	logKey := []byte("hello")
+	logBytes, err := marshaller.Marshal(testLog)
+	require.Nil(t, err)
+	_ = storageService.Logs.Put(logKey, logBytes)
+
+	facade, _ := NewLogsFacade(arguments)
+	logDetails, err := facade.GetLogDetails(logKey, 7)
+	require.Nil(t, err)
+	require.Equal(t, "68656c6c6f", logDetails.TxHash)
+	require.Equal(t, 2, logDetails.NumEvents)
+	require.Equal(t, []map[string]interface{}{nil, {"value": "custom data"}}, logDetails.DecodedEventsData)
+
+	// the raw data should be kept on the API logs
+	logOnApi, err := facade.GetLog(logKey, 7)
+	require.Nil(t, err)
+	require.Equal(t, []byte("custom data"), logOnApi.Events[1].Data)
+
+	logDetails, err = facade.GetLogDetails([]byte("missing"), 7)
+	require.NotNil(t, err)
+	require.Nil(t, logDetails)
+}
+
 func TestLogsFacade_IncludeLogsInTransactionsShouldWork(t *testing.T) {
 	storageService := genericMocks.NewChainStorerMock(7)
 	marshaller := &marshal.GogoProtoMarshalizer{}
@@ -194,6 +194,11 @@ func (nar *nodeApiResolver) GetSCRsByTxHash(txHash string, scrHash string) ([]*t
 	return nar.apiTransactionHandler.GetSCRsByTxHash(txHash, scrHash)
 }
 
+// GetTransactionDetails will return the transaction with the provided hash, together with the details of its smart contract results
+func (nar *nodeApiResolver) GetTransactionDetails(txHash string, originalSender string) (*common.ApiTransactionDetails, error) {
+	return nar.apiTransactionHandler.GetTransactionDetails(txHash, originalSender)
+}
+
 // GetTransactionsPool will return a structure containing the transactions pool that is to be returned on API calls
 func (nar *nodeApiResolver) GetTransactionsPool(fields string) (*common.TransactionsPoolAPIResponse, error) {
 	return nar.apiTransactionHandler.GetTransactionsPool(fields)
@@ -30,4 +30,9 @@ type ArgAPITransactionProcessor struct {
 	DataFieldParser          DataFieldParser
 	TxMarshaller             marshal.Marshalizer
 	EnableEpochsHandler      common.EnableEpochsHandler
+	// RefundDetector is optional, the built-in refund detector is used when not provided
+	RefundDetector RefundDetector
+	// MaxEventsPerLog limits the number of events attached to the logs of a transaction and of each of its smart
+	// contract results. Zero means no limit
+	MaxEventsPerLog uint32
 }
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
 	"github.com/multiversx/mx-chain-core-go/data"
 	"github.com/multiversx/mx-chain-core-go/data/block"
 	rewardTxData "github.com/multiversx/mx-chain-core-go/data/rewardTx"
@@ -43,7 +44,7 @@ type apiTransactionProcessor struct {
 	txTypeHandler               process.TxTypeHandler
 	txUnmarshaller              *txUnmarshaller
 	transactionResultsProcessor *apiTransactionResultsProcessor
-	refundDetector              *refundDetector
+	refundDetector              RefundDetector
 	gasUsedAndFeeProcessor      *gasUsedAndFeeProcessor
 	enableEpochsHandler         common.EnableEpochsHandler
 }
@@ -55,6 +56,11 @@ func NewAPITransactionProcessor(args *ArgAPITransactionProcessor) (*apiTransacti
 		return nil, err
 	}
 
+	refundDetectorInstance := args.RefundDetector
+	if check.IfNil(refundDetectorInstance) {
+		refundDetectorInstance = NewRefundDetector()
+	}
+
 	txUnmarshalerAndPreparer := newTransactionUnmarshaller(args.Marshalizer, args.AddressPubKeyConverter, args.DataFieldParser, args.ShardCoordinator)
 	txResultsProc := newAPITransactionResultProcessor(
 		args.AddressPubKeyConverter,
@@ -65,9 +71,12 @@ func NewAPITransactionProcessor(args *ArgAPITransactionProcessor) (*apiTransacti
 		args.LogsFacade,
 		args.ShardCoordinator,
 		args.DataFieldParser,
+		args.EnableEpochsHandler,
+		refundDetectorInstance,
+		args.TxMarshaller,
+		args.MaxEventsPerLog,
 	)
 
-	refundDetectorInstance := NewRefundDetector()
 	gasUsedAndFeeProc := newGasUsedAndFeeProcessor(
 		args.FeeComputer,
 		args.AddressPubKeyConverter,
@@ -139,6 +148,55 @@ func (atp *apiTransactionProcessor) GetSCRsByTxHash(txHash string, scrHash strin
 	return scrsAPI, nil
 }
 
+// GetTransactionDetails returns the transaction with the provided hash, together with the details of the smart contract
+// results it generated. If an original sender is provided, only the smart contract results having that original sender
+// are returned. The details can only be computed from the history, so the dblookupext should be enabled
+func (atp *apiTransactionProcessor) GetTransactionDetails(txHash string, originalSender string) (*common.ApiTransactionDetails, error) {
+	if !atp.historyRepository.IsEnabled() {
+		return nil, fmt.Errorf("cannot return transaction details: %w", ErrDBLookExtensionIsNotEnabled)
+	}
+
+	var decodedOriginalSender []byte
+	if len(originalSender) > 0 {
+		var err error
+		decodedOriginalSender, err = atp.addressPubKeyConverter.Decode(originalSender)
+		if err != nil {
+			return nil, fmt.Errorf("%w for the original sender", err)
+		}
+	}
+
+	tx, err := atp.GetTransaction(txHash, true)
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := hex.DecodeString(txHash)
+	if err != nil {
+		return nil, err
+	}
+
+	txDetails, err := atp.transactionResultsProcessor.computeTransactionDetails(hash, tx, decodedOriginalSender)
+	if err != nil {
+		return nil, err
+	}
+
+	atp.putTimestampsInSmartContractResultsDetails(txDetails.SmartContractResults)
+
+	return txDetails, nil
+}
+
+func (atp *apiTransactionProcessor) putTimestampsInSmartContractResultsDetails(scrsDetails []*common.ApiSmartContractResultDetails) {
+	for _, scrDetails := range scrsDetails {
+		scrHash, err := hex.DecodeString(scrDetails.Hash)
+		if err != nil {
+			log.Trace("putTimestampsInSmartContractResultsDetails()", "hash", scrDetails.Hash, "err", err)
+			continue
+		}
+
+		scrDetails.Timestamp = atp.computeSmartContractResultTimestamp(scrHash)
+	}
+}
+
 // GetTransaction gets the transaction based on the given hash. It will search in the cache and the storage and
 // will return the transaction in a format which can be respected by all types of transactions (normal, reward or unsigned)
 func (atp *apiTransactionProcessor) GetTransaction(txHash string, withResults bool) (*transaction.ApiTransactionResult, error) {
@@ -523,6 +581,22 @@ func (atp *apiTransactionProcessor) computeTimestampForRound(round uint64) int64
 	return timestamp.Unix()
 }
 
+// computeSmartContractResultTimestamp returns the timestamp of the block that included the provided smart contract
+// result, based on the round from the history repository. Returns 0 if the information is not available
+func (atp *apiTransactionProcessor) computeSmartContractResultTimestamp(scrHash []byte) int64 {
+	if !atp.historyRepository.IsEnabled() {
+		return 0
+	}
+
+	miniblockMetadata, err := atp.historyRepository.GetMiniblockMetadataByTxHash(scrHash)
+	if err != nil || miniblockMetadata == nil {
+		log.Trace("computeSmartContractResultTimestamp()", "hash", scrHash, "err", err)
+		return 0
+	}
+
+	return atp.computeTimestampForRound(miniblockMetadata.Round)
+}
+
 func (atp *apiTransactionProcessor) lookupHistoricalTransaction(hash []byte, withResults bool) (*transaction.ApiTransactionResult, error) {
 	miniblockMetadata, err := atp.historyRepository.GetMiniblockMetadataByTxHash(hash)
 	if err != nil {
@@ -16,6 +16,7 @@ import (
 	"github.com/multiversx/mx-chain-core-go/core/pubkeyConverter"
 	"github.com/multiversx/mx-chain-core-go/data"
 	"github.com/multiversx/mx-chain-core-go/data/block"
+	"github.com/multiversx/mx-chain-core-go/data/receipt"
 	"github.com/multiversx/mx-chain-core-go/data/rewardTx"
 	"github.com/multiversx/mx-chain-core-go/data/smartContractResult"
 	"github.com/multiversx/mx-chain-core-go/data/transaction"
@@ -604,6 +605,256 @@ func TestNode_GetTransactionWithResultsFromStorage(t *testing.T) {
 	require.Equal(t, expectedTx, apiTx)
 }
 
+func createTransactionDetailsProcessor(
+	t *testing.T,
+	tx *transaction.Transaction,
+	unsignedTxs map[string]interface{},
+	resultsHashes map[string]*dblookupext.ResultsHashesByTxHash,
+) (*apiTransactionProcessor, *dblookupextMock.HistoryRepositoryStub) {
+	marshalizer := &mock.MarshalizerFake{}
+	chainStorer := &storageStubs.ChainStorerStub{
+		GetStorerCalled: func(unitType dataRetriever.UnitType) (storage.Storer, error) {
+			switch unitType {
+			case dataRetriever.TransactionUnit:
+				return &storageStubs.StorerStub{
+					GetFromEpochCalled: func(key []byte, epoch uint32) ([]byte, error) {
+						return marshalizer.Marshal(tx)
+					},
+				}, nil
+			case dataRetriever.UnsignedTransactionUnit:
+				return &storageStubs.StorerStub{
+					GetFromEpochCalled: func(key []byte, epoch uint32) ([]byte, error) {
+						unsignedTx, found := unsignedTxs[string(key)]
+						if !found {
+							return nil, storage.ErrKeyNotFound
+						}
+
+						return marshalizer.Marshal(unsignedTx)
+					},
+				}, nil
+			default:
+				return &storageStubs.StorerStub{
+					GetFromEpochCalled: func(key []byte, epoch uint32) ([]byte, error) {
+						return nil, storage.ErrKeyNotFound
+					},
+				}, nil
+			}
+		},
+	}
+
+	historyRepo := &dblookupextMock.HistoryRepositoryStub{
+		GetMiniblockMetadataByTxHashCalled: func(hash []byte) (*dblookupext.MiniblockMetadata, error) {
+			return &dblookupext.MiniblockMetadata{}, nil
+		},
+		GetEventsHashesByTxHashCalled: func(hash []byte, epoch uint32) (*dblookupext.ResultsHashesByTxHash, error) {
+			results, found := resultsHashes[string(hash)]
+			if !found {
+				return nil, dblookupext.ErrNotFoundInStorage
+			}
+
+			return results, nil
+		},
+	}
+
+	args := createMockArgAPITransactionProcessor()
+	args.Marshalizer = marshalizer
+	args.StorageService = chainStorer
+	args.HistoryRepository = historyRepo
+	args.DataPool = dataRetrieverMock.NewPoolsHolderMock()
+	args.ShardCoordinator = &mock.ShardCoordinatorMock{
+		ComputeIdCalled: func(address []byte) uint32 {
+			if bytes.Equal(address, []byte("alice")) {
+				return 1
+			}
+			return 0
+		},
+	}
+	apiTransactionProc, err := NewAPITransactionProcessor(args)
+	require.Nil(t, err)
+
+	return apiTransactionProc, historyRepo
+}
+
+func TestApiTransactionProcessor_GetTransactionDetails(t *testing.T) {
+	t.Parallel()
+
+	txHash := hex.EncodeToString([]byte("txHash"))
+	tx := &transaction.Transaction{Nonce: 7, SndAddr: []byte("alice"), RcvAddr: []byte("bob")}
+	rec := &receipt.Receipt{
+		TxHash:  []byte("scHash2"),
+		Data:    []byte("refund"),
+		Value:   big.NewInt(37),
+		SndAddr: []byte("bob"),
+	}
+	unsignedTxs := map[string]interface{}{
+		"scHash1": &smartContractResult.SmartContractResult{
+			OriginalTxHash: []byte("txHash"),
+			PrevTxHash:     []byte("txHash"),
+			OriginalSender: []byte("alice"),
+		},
+		"scHash2": &smartContractResult.SmartContractResult{
+			OriginalTxHash: []byte("otherTxHash"),
+			PrevTxHash:     []byte("scHash1"),
+			Value:          big.NewInt(1000),
+			Data:           []byte("@6f6b"),
+		},
+		"receiptHash": rec,
+	}
+	resultsHashes := map[string]*dblookupext.ResultsHashesByTxHash{
+		"txHash": {
+			ScResultsHashesAndEpoch: []*dblookupext.ScResultsHashesAndEpoch{
+				{
+					Epoch:           0,
+					ScResultsHashes: [][]byte{[]byte("scHash1"), []byte("scHash2")},
+				},
+			},
+		},
+		"scHash2": {
+			ReceiptsHash: []byte("receiptHash"),
+		},
+	}
+
+	t.Run("dblookupext disabled should error", func(t *testing.T) {
+		t.Parallel()
+
+		apiTransactionProc, historyRepo := createTransactionDetailsProcessor(t, tx, unsignedTxs, resultsHashes)
+		historyRepo.IsEnabledCalled = func() bool {
+			return false
+		}
+
+		txDetails, err := apiTransactionProc.GetTransactionDetails(txHash, "")
+		require.True(t, errors.Is(err, ErrDBLookExtensionIsNotEnabled))
+		require.Nil(t, txDetails)
+	})
+	t.Run("missing smart contract result should error", func(t *testing.T) {
+		t.Parallel()
+
+		missingResultsHashes := map[string]*dblookupext.ResultsHashesByTxHash{
+			"txHash": {
+				ScResultsHashesAndEpoch: []*dblookupext.ScResultsHashesAndEpoch{
+					{
+						Epoch:           0,
+						ScResultsHashes: [][]byte{[]byte("missing")},
+					},
+				},
+			},
+		}
+		apiTransactionProc, _ := createTransactionDetailsProcessor(t, tx, unsignedTxs, missingResultsHashes)
+
+		txDetails, err := apiTransactionProc.GetTransactionDetails(txHash, "")
+		require.True(t, errors.Is(err, errCannotLoadContractResults))
+		require.Nil(t, txDetails)
+	})
+	t.Run("missing receipt should error", func(t *testing.T) {
+		t.Parallel()
+
+		missingReceiptResultsHashes := map[string]*dblookupext.ResultsHashesByTxHash{
+			"txHash":  resultsHashes["txHash"],
+			"scHash2": {ReceiptsHash: []byte("missing")},
+		}
+		apiTransactionProc, _ := createTransactionDetailsProcessor(t, tx, unsignedTxs, missingReceiptResultsHashes)
+
+		txDetails, err := apiTransactionProc.GetTransactionDetails(txHash, "")
+		require.True(t, errors.Is(err, errCannotLoadReceipts))
+		require.Nil(t, txDetails)
+	})
+	t.Run("no smart contract results should return zero refund", func(t *testing.T) {
+		t.Parallel()
+
+		apiTransactionProc, _ := createTransactionDetailsProcessor(t, tx, unsignedTxs, map[string]*dblookupext.ResultsHashesByTxHash{})
+
+		txDetails, err := apiTransactionProc.GetTransactionDetails(txHash, "")
+		require.Nil(t, err)
+		require.Empty(t, txDetails.SmartContractResults)
+		require.Equal(t, "0", txDetails.TotalRefund)
+		require.Equal(t, 0, txDetails.NumRefunds)
+	})
+	t.Run("invalid original sender should error", func(t *testing.T) {
+		t.Parallel()
+
+		apiTransactionProc, _ := createTransactionDetailsProcessor(t, tx, unsignedTxs, resultsHashes)
+
+		txDetails, err := apiTransactionProc.GetTransactionDetails(txHash, "not hex")
+		require.NotNil(t, err)
+		require.Nil(t, txDetails)
+	})
+	t.Run("should filter by the original sender", func(t *testing.T) {
+		t.Parallel()
+
+		apiTransactionProc, _ := createTransactionDetailsProcessor(t, tx, unsignedTxs, resultsHashes)
+
+		txDetails, err := apiTransactionProc.GetTransactionDetails(txHash, hex.EncodeToString([]byte("alice")))
+		require.Nil(t, err)
+		require.Equal(t, 1, len(txDetails.Transaction.SmartContractResults))
+		require.Equal(t, hex.EncodeToString([]byte("scHash1")), txDetails.Transaction.SmartContractResults[0].Hash)
+		require.Equal(t, 1, len(txDetails.SmartContractResults))
+		require.Equal(t, hex.EncodeToString([]byte("scHash1")), txDetails.SmartContractResults[0].Hash)
+		require.Equal(t, 0, txDetails.SmartContractResults[0].Depth)
+		require.Equal(t, "0", txDetails.TotalRefund)
+		require.Equal(t, 0, txDetails.NumRefunds)
+		require.Equal(t, 2, txDetails.StatusSummary.NumSCRs)
+		require.True(t, txDetails.StatusSummary.HadRefund)
+
+		txDetails, err = apiTransactionProc.GetTransactionDetails(txHash, hex.EncodeToString([]byte("carol")))
+		require.Nil(t, err)
+		require.Empty(t, txDetails.Transaction.SmartContractResults)
+		require.Empty(t, txDetails.SmartContractResults)
+	})
+	t.Run("should return the smart contract results details", func(t *testing.T) {
+		t.Parallel()
+
+		apiTransactionProc, historyRepo := createTransactionDetailsProcessor(t, tx, unsignedTxs, resultsHashes)
+		apiTransactionProc.genesisTime = getTime(t, "1596117600")
+		apiTransactionProc.roundDuration = 6000
+		historyRepo.GetMiniblockMetadataByTxHashCalled = func(hash []byte) (*dblookupext.MiniblockMetadata, error) {
+			if bytes.Equal(hash, []byte("scHash1")) {
+				return &dblookupext.MiniblockMetadata{
+					DestinationShardID:                1,
+					HeaderNonce:                       10,
+					NotarizedAtDestinationInMetaNonce: 12,
+					Round:                             4837403,
+				}, nil
+			}
+
+			return &dblookupext.MiniblockMetadata{}, nil
+		}
+
+		txDetails, err := apiTransactionProc.GetTransactionDetails(txHash, "")
+		require.Nil(t, err)
+		require.Equal(t, txHash, txDetails.Transaction.Hash)
+		require.Equal(t, 2, len(txDetails.Transaction.SmartContractResults))
+		require.Equal(t, "1000", txDetails.TotalRefund)
+		require.Equal(t, 1, txDetails.NumRefunds)
+		require.Equal(t, 2, txDetails.StatusSummary.NumSCRs)
+		require.True(t, txDetails.StatusSummary.HadRefund)
+		require.Equal(t, []*common.ApiSmartContractResultDetails{
+			{
+				Hash:                   hex.EncodeToString([]byte("scHash1")),
+				OriginalTxNonce:        7,
+				OriginalSenderShard:    1,
+				NotarizedAtDestination: true,
+				Timestamp:              1625142018,
+				Depth:                  0,
+				Category:               string(SCRCategoryOther),
+			},
+			{
+				Hash:                hex.EncodeToString([]byte("scHash2")),
+				OriginalTxNonce:     0,
+				OriginalSenderShard: 0,
+				Depth:               1,
+				IsGasRefund:         true,
+				Category:            string(SCRCategoryGasRefund),
+				Receipt: &transaction.ApiReceipt{
+					Value:   rec.Value,
+					SndAddr: hex.EncodeToString(rec.SndAddr),
+					Data:    string(rec.Data),
+					TxHash:  hex.EncodeToString(rec.TxHash),
+				},
+			},
+		}, txDetails.SmartContractResults)
+	})
+}
+
 func TestNode_lookupHistoricalTransaction(t *testing.T) {
 	t.Parallel()
 
@@ -1270,6 +1521,52 @@ func TestNode_ComputeTimestampForRound(t *testing.T) {
 	require.Equal(t, int64(1625142018), res)
 }
 
+func TestApiTransactionProcessor_ComputeSmartContractResultTimestamp(t *testing.T) {
+	t.Parallel()
+
+	knownScrHash := []byte("known")
+	createProcessor := func(isHistoryEnabled bool) *apiTransactionProcessor {
+		args := createMockArgAPITransactionProcessor()
+		args.HistoryRepository = &dblookupextMock.HistoryRepositoryStub{
+			IsEnabledCalled: func() bool {
+				return isHistoryEnabled
+			},
+			GetMiniblockMetadataByTxHashCalled: func(hash []byte) (*dblookupext.MiniblockMetadata, error) {
+				if bytes.Equal(hash, knownScrHash) {
+					return &dblookupext.MiniblockMetadata{Round: 4837403}, nil
+				}
+				return nil, dblookupext.ErrNotFoundInStorage
+			},
+		}
+
+		n, err := NewAPITransactionProcessor(args)
+		require.Nil(t, err)
+		n.genesisTime = getTime(t, "1596117600")
+		n.roundDuration = 6000
+
+		return n
+	}
+
+	t.Run("known block should return its timestamp", func(t *testing.T) {
+		t.Parallel()
+
+		n := createProcessor(true)
+		require.Equal(t, int64(1625142018), n.computeSmartContractResultTimestamp(knownScrHash))
+	})
+	t.Run("unknown block should return 0", func(t *testing.T) {
+		t.Parallel()
+
+		n := createProcessor(true)
+		require.Equal(t, int64(0), n.computeSmartContractResultTimestamp([]byte("unknown")))
+	})
+	t.Run("history repository disabled should return 0", func(t *testing.T) {
+		t.Parallel()
+
+		n := createProcessor(false)
+		require.Equal(t, int64(0), n.computeSmartContractResultTimestamp(knownScrHash))
+	})
+}
+
 func getTime(t *testing.T, timestamp string) time.Time {
 	i, err := strconv.ParseInt(timestamp, 10, 64)
 	if err != nil {
@@ -1392,3 +1689,223 @@ func TestApiTransactionProcessor_PopulateComputedFields(t *testing.T) {
 	require.Equal(t, "SCDeployment", apiTx.ProcessingTypeOnDestination)
 	require.Equal(t, "1000", apiTx.InitiallyPaidFee)
 }
+
+type refundDetectorStub struct {
+	IsRefundCalled func(input RefundDetectorInput) bool
+}
+
+// IsRefund -
+func (stub *refundDetectorStub) IsRefund(input RefundDetectorInput) bool {
+	if stub.IsRefundCalled != nil {
+		return stub.IsRefundCalled(input)
+	}
+
+	return false
+}
+
+// IsInterfaceNil -
+func (stub *refundDetectorStub) IsInterfaceNil() bool {
+	return stub == nil
+}
+
+type refundClassifierStub struct {
+	refundDetectorStub
+	IsGasRefundCalled   func(input RefundDetectorInput) bool
+	IsValueReturnCalled func(input RefundDetectorInput) bool
+}
+
+// IsGasRefund -
+func (stub *refundClassifierStub) IsGasRefund(input RefundDetectorInput) bool {
+	if stub.IsGasRefundCalled != nil {
+		return stub.IsGasRefundCalled(input)
+	}
+
+	return false
+}
+
+// IsValueReturn -
+func (stub *refundClassifierStub) IsValueReturn(input RefundDetectorInput) bool {
+	if stub.IsValueReturnCalled != nil {
+		return stub.IsValueReturnCalled(input)
+	}
+
+	return false
+}
+
+// IsInterfaceNil -
+func (stub *refundClassifierStub) IsInterfaceNil() bool {
+	return stub == nil
+}
+
+func TestApiTransactionProcessor_PopulateComputedFieldsWithCustomRefundDetector(t *testing.T) {
+	t.Parallel()
+
+	var receivedInput RefundDetectorInput
+	arguments := createMockArgAPITransactionProcessor()
+	arguments.RefundDetector = &refundDetectorStub{
+		IsRefundCalled: func(input RefundDetectorInput) bool {
+			receivedInput = input
+			return true
+		},
+	}
+
+	processor, err := NewAPITransactionProcessor(arguments)
+	require.Nil(t, err)
+
+	apiTx := &transaction.ApiTransactionResult{
+		Type:          string(transaction.TxTypeUnsigned),
+		Value:         "0",
+		Data:          []byte("data"),
+		ReturnMessage: "message",
+		GasLimit:      10,
+	}
+	processor.PopulateComputedFields(apiTx)
+
+	require.True(t, apiTx.IsRefund)
+	require.Equal(t, RefundDetectorInput{
+		Value:         "0",
+		Data:          []byte("data"),
+		ReturnMessage: "message",
+		GasLimit:      10,
+	}, receivedInput)
+}
+
+func TestApiTransactionProcessor_GetTransactionDetailsWithCustomRefundDetector(t *testing.T) {
+	t.Parallel()
+
+	txHash := hex.EncodeToString([]byte("txHash"))
+	tx := &transaction.Transaction{Nonce: 7, SndAddr: []byte("alice"), RcvAddr: []byte("bob")}
+	unsignedTxs := map[string]interface{}{
+		"scHash": &smartContractResult.SmartContractResult{
+			OriginalTxHash: []byte("txHash"),
+			PrevTxHash:     []byte("txHash"),
+			Value:          big.NewInt(1000),
+			Data:           []byte("custom refund"),
+		},
+	}
+	resultsHashes := map[string]*dblookupext.ResultsHashesByTxHash{
+		"txHash": {
+			ScResultsHashesAndEpoch: []*dblookupext.ScResultsHashesAndEpoch{
+				{
+					Epoch:           0,
+					ScResultsHashes: [][]byte{[]byte("scHash")},
+				},
+			},
+		},
+	}
+	isCustomRefund := func(input RefundDetectorInput) bool {
+		return string(input.Data) == "custom refund"
+	}
+
+	t.Run("detector without classification should use the built-in classification", func(t *testing.T) {
+		t.Parallel()
+
+		apiTransactionProc, _ := createTransactionDetailsProcessor(t, tx, unsignedTxs, resultsHashes)
+		detector := &refundDetectorStub{
+			IsRefundCalled: isCustomRefund,
+		}
+		apiTransactionProc.transactionResultsProcessor.refundDetector = detector
+		apiTransactionProc.transactionResultsProcessor.refundClassifier = getRefundClassifier(detector)
+
+		txDetails, err := apiTransactionProc.GetTransactionDetails(txHash, "")
+		require.Nil(t, err)
+		require.True(t, txDetails.Transaction.SmartContractResults[0].IsRefund)
+		require.Equal(t, "1000", txDetails.TotalRefund)
+		require.False(t, txDetails.SmartContractResults[0].IsGasRefund)
+		require.False(t, txDetails.SmartContractResults[0].IsValueReturn)
+	})
+	t.Run("detector with classification should be used for the classification", func(t *testing.T) {
+		t.Parallel()
+
+		apiTransactionProc, _ := createTransactionDetailsProcessor(t, tx, unsignedTxs, resultsHashes)
+		detector := &refundClassifierStub{
+			refundDetectorStub: refundDetectorStub{
+				IsRefundCalled: isCustomRefund,
+			},
+			IsValueReturnCalled: isCustomRefund,
+		}
+		apiTransactionProc.transactionResultsProcessor.refundDetector = detector
+		apiTransactionProc.transactionResultsProcessor.refundClassifier = getRefundClassifier(detector)
+
+		txDetails, err := apiTransactionProc.GetTransactionDetails(txHash, "")
+		require.Nil(t, err)
+		require.True(t, txDetails.Transaction.SmartContractResults[0].IsRefund)
+		require.False(t, txDetails.SmartContractResults[0].IsGasRefund)
+		require.True(t, txDetails.SmartContractResults[0].IsValueReturn)
+		require.Equal(t, string(SCRCategoryValueReturn), txDetails.SmartContractResults[0].Category)
+	})
+}
+
+func TestApiTransactionProcessor_GetTransactionDetailsWithLogs(t *testing.T) {
+	t.Parallel()
+
+	txHash := hex.EncodeToString([]byte("txHash"))
+	tx := &transaction.Transaction{Nonce: 7, SndAddr: []byte("alice"), RcvAddr: []byte("bob")}
+	unsignedTxs := map[string]interface{}{
+		"scHash1": &smartContractResult.SmartContractResult{
+			OriginalTxHash: []byte("txHash"),
+			PrevTxHash:     []byte("txHash"),
+		},
+		"scHash2": &smartContractResult.SmartContractResult{
+			OriginalTxHash: []byte("txHash"),
+			PrevTxHash:     []byte("txHash"),
+		},
+	}
+	resultsHashes := map[string]*dblookupext.ResultsHashesByTxHash{
+		"txHash": {
+			ScResultsHashesAndEpoch: []*dblookupext.ScResultsHashesAndEpoch{
+				{
+					Epoch:           0,
+					ScResultsHashes: [][]byte{[]byte("scHash1"), []byte("scHash2")},
+				},
+			},
+		},
+	}
+	txLogsDetails := &common.ApiLogsDetails{
+		NumEvents:         1,
+		DecodedEventsData: []map[string]interface{}{{"value": "tx"}},
+	}
+	scrLogsDetails := &common.ApiLogsDetails{
+		NumEvents: 2,
+	}
+
+	apiTransactionProc, _ := createTransactionDetailsProcessor(t, tx, unsignedTxs, resultsHashes)
+	apiTransactionProc.transactionResultsProcessor.logsFacade = &testscommon.LogsFacadeStub{
+		GetLogDetailsCalled: func(logKey []byte, epoch uint32) (*common.ApiLogsDetails, error) {
+			switch string(logKey) {
+			case "txHash":
+				return txLogsDetails, nil
+			case "scHash1":
+				return scrLogsDetails, nil
+			default:
+				return nil, errors.New("log not found")
+			}
+		},
+	}
+
+	txDetails, err := apiTransactionProc.GetTransactionDetails(txHash, "")
+	require.Nil(t, err)
+	require.Equal(t, txLogsDetails, txDetails.Logs)
+	require.Equal(t, 2, len(txDetails.SmartContractResults))
+	require.Equal(t, scrLogsDetails, txDetails.SmartContractResults[0].Logs)
+	require.Nil(t, txDetails.SmartContractResults[1].Logs)
+}
+
+func TestApiTransactionProcessor_PopulateComputedFieldsWithDefaultRefundDetector(t *testing.T) {
+	t.Parallel()
+
+	arguments := createMockArgAPITransactionProcessor()
+	arguments.RefundDetector = nil
+
+	processor, err := NewAPITransactionProcessor(arguments)
+	require.Nil(t, err)
+
+	apiTx := &transaction.ApiTransactionResult{
+		Type:  string(transaction.TxTypeUnsigned),
+		Value: "0",
+		Data:  []byte("@6f6b"),
+	}
+	processor.PopulateComputedFields(apiTx)
+
+	require.False(t, apiTx.IsRefund)
+}
@@ -1,6 +1,7 @@
 package transactionAPI
 
 import (
+	"bytes"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -9,9 +10,12 @@ import (
 	"github.com/multiversx/mx-chain-core-go/data/smartContractResult"
 	"github.com/multiversx/mx-chain-core-go/data/transaction"
 	"github.com/multiversx/mx-chain-core-go/marshal"
+	"github.com/multiversx/mx-chain-go/common"
 	"github.com/multiversx/mx-chain-go/dataRetriever"
 	"github.com/multiversx/mx-chain-go/dblookupext"
 	"github.com/multiversx/mx-chain-go/node/filters"
+	"github.com/multiversx/mx-chain-go/process"
+	"github.com/multiversx/mx-chain-go/process/smartContract"
 	"github.com/multiversx/mx-chain-go/sharding"
 )
 
@@ -23,8 +27,13 @@ type apiTransactionResultsProcessor struct {
 	marshalizer            marshal.Marshalizer
 	dataFieldParser        DataFieldParser
 	shardCoordinator       sharding.Coordinator
-	refundDetector         *refundDetector
+	refundDetector         RefundDetector
+	refundClassifier       RefundClassifier
+	txMarshaller           marshal.Marshalizer
+	argsParser             process.ArgumentsParser
 	logsFacade             LogsFacade
+	epochProvider          currentEpochProvider
+	maxEventsPerLog        uint32
 }
 
 func newAPITransactionResultProcessor(
@@ -36,9 +45,11 @@ func newAPITransactionResultProcessor(
 	logsFacade LogsFacade,
 	shardCoordinator sharding.Coordinator,
 	dataFieldParser DataFieldParser,
+	epochProvider currentEpochProvider,
+	refundDetector RefundDetector,
+	txMarshaller marshal.Marshalizer,
+	maxEventsPerLog uint32,
 ) *apiTransactionResultsProcessor {
-	refundDetector := NewRefundDetector()
-
 	return &apiTransactionResultsProcessor{
 		txUnmarshaller:         txUnmarshaller,
 		addressPubKeyConverter: addressPubKeyConverter,
@@ -47,12 +58,32 @@ func newAPITransactionResultProcessor(
 		marshalizer:            marshalizer,
 		shardCoordinator:       shardCoordinator,
 		refundDetector:         refundDetector,
+		refundClassifier:       getRefundClassifier(refundDetector),
+		txMarshaller:           txMarshaller,
+		argsParser:             smartContract.NewArgumentParser(),
 		logsFacade:             logsFacade,
 		dataFieldParser:        dataFieldParser,
+		epochProvider:          epochProvider,
+		maxEventsPerLog:        maxEventsPerLog,
 	}
 }
 
+// SelfShardID returns the shard ID of the shard coordinator used by the results processor
+func (arp *apiTransactionResultsProcessor) SelfShardID() uint32 {
+	return arp.shardCoordinator.SelfId()
+}
+
+// NumShards returns the number of shards of the shard coordinator used by the results processor
+func (arp *apiTransactionResultsProcessor) NumShards() uint32 {
+	return arp.shardCoordinator.NumberOfShards()
+}
+
 func (arp *apiTransactionResultsProcessor) putResultsInTransaction(hash []byte, tx *transaction.ApiTransactionResult, epoch uint32) error {
+	err := arp.checkEpoch(epoch)
+	if err != nil {
+		return err
+	}
+
 	// TODO: Note that the following call produces an effect even if the function "putResultsInTransaction" results in an error.
 	// TODO: Refactor this package to use less functions with side-effects.
 	arp.loadLogsIntoTransaction(hash, tx, epoch)
@@ -73,6 +104,17 @@ func (arp *apiTransactionResultsProcessor) putResultsInTransaction(hash []byte,
 	return arp.putSmartContractResultsInTransaction(tx, resultsHashes.ScResultsHashesAndEpoch)
 }
 
+// checkEpoch rejects the epochs that are too far in the future, as the lookups in such epochs would always end up with
+// a not found error which would be further treated as "no results"
+func (arp *apiTransactionResultsProcessor) checkEpoch(epoch uint32) error {
+	currentEpoch := arp.epochProvider.GetCurrentEpoch()
+	if uint64(epoch) > uint64(currentEpoch)+maxEpochsAheadOfCurrentEpoch {
+		return fmt.Errorf("%w: provided epoch %d, current epoch %d", ErrEpochInTheFuture, epoch, currentEpoch)
+	}
+
+	return nil
+}
+
 func (arp *apiTransactionResultsProcessor) putReceiptInTransaction(tx *transaction.ApiTransactionResult, receiptHash []byte, epoch uint32) error {
 	rec, err := arp.getReceiptFromStorage(receiptHash, epoch)
 	if err != nil {
@@ -83,6 +125,29 @@ func (arp *apiTransactionResultsProcessor) putReceiptInTransaction(tx *transacti
 	return nil
 }
 
+// getContractResultReceipt returns the receipt generated by the provided smart contract result, if any. A nil receipt
+// (and no error) is returned if the smart contract result does not have a receipt. As the lookup requires extra storage
+// accesses, it is only done when computing the transaction details
+func (arp *apiTransactionResultsProcessor) getContractResultReceipt(scrHash []byte, epoch uint32) (*transaction.ApiReceipt, error) {
+	resultsHashes, err := arp.historyRepository.GetResultsHashesByTxHash(scrHash, epoch)
+	if err != nil {
+		if errors.Is(err, dblookupext.ErrNotFoundInStorage) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if len(resultsHashes.ReceiptsHash) == 0 {
+		return nil, nil
+	}
+
+	rec, err := arp.getReceiptFromStorage(resultsHashes.ReceiptsHash, epoch)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v, hash = %s", errCannotLoadReceipts, err, hex.EncodeToString(resultsHashes.ReceiptsHash))
+	}
+
+	return rec, nil
+}
+
 func (arp *apiTransactionResultsProcessor) getReceiptFromStorage(hash []byte, epoch uint32) (*transaction.ApiReceipt, error) {
 	receiptsStorer, err := arp.storageService.GetStorer(dataRetriever.UnsignedTransactionUnit)
 	if err != nil {
@@ -115,6 +180,43 @@ func (arp *apiTransactionResultsProcessor) putSmartContractResultsInTransaction(
 	return nil
 }
 
+// filterSmartContractResultsByOriginalSender returns only the smart contract results having the provided original sender.
+// The original sender is encoded in the same way as for the API smart contract results
+func (arp *apiTransactionResultsProcessor) filterSmartContractResultsByOriginalSender(
+	scrs []*transaction.ApiSmartContractResult,
+	originalSender []byte,
+) ([]*transaction.ApiSmartContractResult, error) {
+	encodedOriginalSender, err := arp.addressPubKeyConverter.Encode(originalSender)
+	if err != nil {
+		return nil, err
+	}
+
+	filteredScrs := make([]*transaction.ApiSmartContractResult, 0, len(scrs))
+	for _, scr := range scrs {
+		if scr.OriginalSender == encodedOriginalSender {
+			filteredScrs = append(filteredScrs, scr)
+		}
+	}
+
+	return filteredScrs, nil
+}
+
+// isSmartContractResultNotarizedAtDestination returns true if the miniblock holding the provided smart contract result
+// was notarized by the metachain in the destination shard. Returns false if the information is not known
+func (arp *apiTransactionResultsProcessor) isSmartContractResultNotarizedAtDestination(scrHash []byte) bool {
+	miniblockMetadata, err := arp.historyRepository.GetMiniblockMetadataByTxHash(scrHash)
+	if err != nil || miniblockMetadata == nil {
+		return false
+	}
+
+	// the metachain blocks are not notarized by other blocks, so being included in a metachain block is enough
+	if miniblockMetadata.DestinationShardID == core.MetachainShardId {
+		return miniblockMetadata.HeaderNonce > 0
+	}
+
+	return miniblockMetadata.NotarizedAtDestinationInMetaNonce > 0
+}
+
 func (arp *apiTransactionResultsProcessor) getSmartContractResultsInTransactionByHashesAndEpoch(scrsHashes [][]byte, epoch uint32) ([]*transaction.ApiSmartContractResult, error) {
 	scrsAPI := make([]*transaction.ApiSmartContractResult, 0, len(scrsHashes))
 	for _, scrHash := range scrsHashes {
@@ -124,7 +226,6 @@ func (arp *apiTransactionResultsProcessor) getSmartContractResultsInTransactionB
 		}
 
 		scrAPI := arp.adaptSmartContractResult(scrHash, scr)
-
 		arp.loadLogsIntoContractResults(scrHash, epoch, scrAPI)
 
 		scrsAPI = append(scrsAPI, scrAPI)
@@ -140,6 +241,12 @@ func (arp *apiTransactionResultsProcessor) loadLogsIntoTransaction(hash []byte,
 	if err != nil {
 		log.Trace("loadLogsIntoTransaction()", "hash", hash, "epoch", epoch, "err", err)
 	}
+
+	var truncated bool
+	tx.Logs, truncated = arp.truncateLogEvents(tx.Logs)
+	if truncated {
+		log.Debug("loadLogsIntoTransaction(): truncated log events", "hash", hash, "max events", arp.maxEventsPerLog)
+	}
 }
 
 func (arp *apiTransactionResultsProcessor) loadLogsIntoContractResults(scrHash []byte, epoch uint32, scr *transaction.ApiSmartContractResult) {
@@ -149,6 +256,60 @@ func (arp *apiTransactionResultsProcessor) loadLogsIntoContractResults(scrHash [
 	if err != nil {
 		log.Trace("loadLogsIntoContractResults()", "hash", scrHash, "epoch", epoch, "err", err)
 	}
+
+	var truncated bool
+	scr.Logs, truncated = arp.truncateLogEvents(scr.Logs)
+	if truncated {
+		log.Debug("loadLogsIntoContractResults(): truncated log events", "hash", scrHash, "max events", arp.maxEventsPerLog)
+	}
+}
+
+// getLogsDetails returns the details of the logs generated by the transaction or smart contract result with the provided
+// hash, or nil if there are no such logs. The details flag the logs whose events are truncated on the API resources
+func (arp *apiTransactionResultsProcessor) getLogsDetails(hash []byte, epoch uint32) *common.ApiLogsDetails {
+	logsDetails, err := arp.logsFacade.GetLogDetails(hash, epoch)
+	if err != nil {
+		log.Trace("getLogsDetails()", "hash", hash, "epoch", epoch, "err", err)
+		return nil
+	}
+
+	return arp.truncateLogsDetailsEvents(logsDetails)
+}
+
+// truncateLogsDetailsEvents flags the provided logs details if their events exceed maxEventsPerLog, keeping the decoded
+// data only for the events that are returned. The provided details are not altered, a truncated copy being returned instead
+func (arp *apiTransactionResultsProcessor) truncateLogsDetailsEvents(logsDetails *common.ApiLogsDetails) *common.ApiLogsDetails {
+	if logsDetails == nil || arp.maxEventsPerLog == 0 {
+		return logsDetails
+	}
+	if logsDetails.NumEvents <= int(arp.maxEventsPerLog) {
+		return logsDetails
+	}
+
+	truncatedLogsDetails := *logsDetails
+	truncatedLogsDetails.EventsTruncated = true
+	if len(logsDetails.DecodedEventsData) > int(arp.maxEventsPerLog) {
+		truncatedLogsDetails.DecodedEventsData = logsDetails.DecodedEventsData[:arp.maxEventsPerLog]
+	}
+
+	return &truncatedLogsDetails
+}
+
+// truncateLogEvents keeps at most maxEventsPerLog events of the provided logs, returning true if events were dropped.
+// The provided logs are not altered, a truncated copy being returned instead. A zero limit disables the truncation. The
+// truncation is flagged on the logs details returned with the transaction details
+func (arp *apiTransactionResultsProcessor) truncateLogEvents(logs *transaction.ApiLogs) (*transaction.ApiLogs, bool) {
+	if logs == nil || arp.maxEventsPerLog == 0 {
+		return logs, false
+	}
+	if len(logs.Events) <= int(arp.maxEventsPerLog) {
+		return logs, false
+	}
+
+	truncatedLogs := *logs
+	truncatedLogs.Events = logs.Events[:arp.maxEventsPerLog]
+
+	return &truncatedLogs, true
 }
 
 func (arp *apiTransactionResultsProcessor) getScrFromStorage(hash []byte, epoch uint32) (*smartContractResult.SmartContractResult, error) {
@@ -172,12 +333,7 @@ func (arp *apiTransactionResultsProcessor) getScrFromStorage(hash []byte, epoch
 }
 
 func (arp *apiTransactionResultsProcessor) adaptSmartContractResult(scrHash []byte, scr *smartContractResult.SmartContractResult) *transaction.ApiSmartContractResult {
-	isRefund := arp.refundDetector.IsRefund(RefundDetectorInput{
-		Value:         scr.Value.String(),
-		Data:          scr.Data,
-		ReturnMessage: string(scr.ReturnMessage),
-		GasLimit:      scr.GasLimit,
-	})
+	isRefund := arp.refundDetector.IsRefund(createRefundDetectorInput(scr))
 
 	apiSCR := &transaction.ApiSmartContractResult{
 		Hash:           hex.EncodeToString(scrHash),
@@ -218,3 +374,178 @@ func (arp *apiTransactionResultsProcessor) adaptSmartContractResult(scrHash []by
 
 	return apiSCR
 }
+
+// computeTransactionDetails returns the provided transaction together with the details of the smart contract results it
+// generated. The transaction should be already fetched from storage along with its smart contract results, which are
+// reused for the details, its epoch being used for the results lookup. If an original sender is provided, both the
+// returned transaction's results and the details are restricted to the smart contract results having that original
+// sender, the totals being computed only on the returned smart contract results. The provided transaction is not
+// altered. The status summary and the depths always describe the whole transaction
+func (arp *apiTransactionResultsProcessor) computeTransactionDetails(
+	hash []byte,
+	tx *transaction.ApiTransactionResult,
+	originalSender []byte,
+) (*common.ApiTransactionDetails, error) {
+	statusSummary := arp.computeStatusSummary(tx)
+	scrsDepths := computeSCRsDepths(hex.EncodeToString(hash), tx.SmartContractResults)
+
+	apiScrs := tx.SmartContractResults
+	returnedTx := tx
+	if len(originalSender) > 0 {
+		filteredScrs, err := arp.filterSmartContractResultsByOriginalSender(tx.SmartContractResults, originalSender)
+		if err != nil {
+			return nil, err
+		}
+
+		apiScrs = filteredScrs
+		filteredTx := *tx
+		filteredTx.SmartContractResults = filteredScrs
+		returnedTx = &filteredTx
+	}
+
+	txDetails := &common.ApiTransactionDetails{
+		Transaction:          returnedTx,
+		SmartContractResults: make([]*common.ApiSmartContractResultDetails, 0, len(apiScrs)),
+		TotalRefund:          "0",
+		StatusSummary:        statusSummary,
+		Logs:                 arp.getLogsDetails(hash, tx.Epoch),
+	}
+	if len(apiScrs) == 0 {
+		return txDetails, nil
+	}
+
+	scrsEpochs, err := arp.getSmartContractResultsEpochs(hash, tx.Epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	scrs := make([]*smartContractResult.SmartContractResult, 0, len(apiScrs))
+	for _, apiScr := range apiScrs {
+		scrHash, errDecode := hex.DecodeString(apiScr.Hash)
+		if errDecode != nil {
+			return nil, fmt.Errorf("%w: %v, hash = %s", errCannotLoadContractResults, errDecode, apiScr.Hash)
+		}
+		scr, errRehydrate := RehydrateSmartContractResult(apiScr, arp.addressPubKeyConverter)
+		if errRehydrate != nil {
+			return nil, fmt.Errorf("%w: %v, hash = %s", errCannotLoadContractResults, errRehydrate, apiScr.Hash)
+		}
+
+		epoch, found := scrsEpochs[apiScr.Hash]
+		if !found {
+			epoch = tx.Epoch
+		}
+
+		scrDetails, errCompute := arp.computeSmartContractResultDetails(scrHash, scr, epoch, hash, tx)
+		if errCompute != nil {
+			return nil, errCompute
+		}
+		scrDetails.Depth = getSCRDepth(scrsDepths, scrDetails.Hash)
+
+		scrs = append(scrs, scr)
+		txDetails.SmartContractResults = append(txDetails.SmartContractResults, scrDetails)
+	}
+
+	totalRefund, numRefunds := computeTotalRefund(arp.refundDetector, scrs)
+	txDetails.TotalRefund = totalRefund.String()
+	txDetails.NumRefunds = numRefunds
+
+	return txDetails, nil
+}
+
+// getSmartContractResultsEpochs returns the epoch each smart contract result of the provided transaction was stored in,
+// keyed by the hex encoded smart contract result hash, as found in the Hash field of the API smart contract results
+func (arp *apiTransactionResultsProcessor) getSmartContractResultsEpochs(hash []byte, epoch uint32) (map[string]uint32, error) {
+	scrsEpochs := make(map[string]uint32)
+	resultsHashes, err := arp.historyRepository.GetResultsHashesByTxHash(hash, epoch)
+	if err != nil {
+		// It's perfectly normal to have transactions without SCRs.
+		if errors.Is(err, dblookupext.ErrNotFoundInStorage) {
+			return scrsEpochs, nil
+		}
+		return nil, err
+	}
+
+	for _, scrHashesE := range resultsHashes.ScResultsHashesAndEpoch {
+		for _, scrHash := range scrHashesE.ScResultsHashes {
+			scrsEpochs[hex.EncodeToString(scrHash)] = scrHashesE.Epoch
+		}
+	}
+
+	return scrsEpochs, nil
+}
+
+func (arp *apiTransactionResultsProcessor) computeSmartContractResultDetails(
+	scrHash []byte,
+	scr *smartContractResult.SmartContractResult,
+	epoch uint32,
+	originalTxHash []byte,
+	originalTx *transaction.ApiTransactionResult,
+) (*common.ApiSmartContractResultDetails, error) {
+	rec, err := arp.getContractResultReceipt(scrHash, epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	originalInfo := arp.getOriginalTxInfo(scr, originalTxHash, originalTx.Nonce)
+	refundDetectorInput := createRefundDetectorInput(scr)
+	isGasRefund := arp.refundClassifier.IsGasRefund(refundDetectorInput)
+	isValueReturn := arp.refundClassifier.IsValueReturn(refundDetectorInput)
+
+	return &common.ApiSmartContractResultDetails{
+		Hash:                   hex.EncodeToString(scrHash),
+		OriginalTxNonce:        originalInfo.nonce,
+		OriginalSenderShard:    originalInfo.senderShard,
+		Receipt:                rec,
+		NotarizedAtDestination: arp.isSmartContractResultNotarizedAtDestination(scrHash),
+		Epoch:                  epoch,
+		IsGasRefund:            isGasRefund,
+		IsValueReturn:          isValueReturn,
+		Category:               string(arp.computeSCRCategory(scr, isGasRefund, isValueReturn)),
+		InnerTransaction:       arp.getRelayedInnerTransaction(scrHash, scr),
+		Logs:                   arp.getLogsDetails(scrHash, epoch),
+	}, nil
+}
+
+// getRelayedInnerTransaction returns the inner transaction embedded in the data of the provided smart contract result, or
+// nil if the smart contract result is not relayed or its relayed data is malformed
+func (arp *apiTransactionResultsProcessor) getRelayedInnerTransaction(
+	scrHash []byte,
+	scr *smartContractResult.SmartContractResult,
+) *transaction.ApiTransactionResult {
+	if !isRelayedTxData(scr.Data) {
+		return nil
+	}
+
+	innerTx, err := decodeRelayedInnerTransaction(scr, arp.argsParser, arp.txMarshaller)
+	if err != nil {
+		log.Trace("getRelayedInnerTransaction()", "hash", scrHash, "err", err)
+		return nil
+	}
+
+	return arp.txUnmarshaller.prepareNormalTx(innerTx)
+}
+
+// originalTxInfo holds the details about the transaction that originated a smart contract result
+type originalTxInfo struct {
+	nonce       uint64
+	senderShard uint32
+}
+
+// getOriginalTxInfo returns the original transaction's nonce and the original sender's shard for the provided smart contract
+// result. The nonce is only known if the provided original transaction hash matches the one from the smart contract result,
+// otherwise it is left on 0. The same goes for the sender's shard, in case the smart contract result has no original sender.
+func (arp *apiTransactionResultsProcessor) getOriginalTxInfo(
+	scr *smartContractResult.SmartContractResult,
+	originalTxHash []byte,
+	originalTxNonce uint64,
+) originalTxInfo {
+	info := originalTxInfo{}
+	if len(scr.OriginalSender) > 0 {
+		info.senderShard = arp.shardCoordinator.ComputeId(scr.OriginalSender)
+	}
+	if len(originalTxHash) > 0 && bytes.Equal(scr.OriginalTxHash, originalTxHash) {
+		info.nonce = originalTxNonce
+	}
+
+	return info
+}
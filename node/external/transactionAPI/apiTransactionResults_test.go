@@ -8,15 +8,18 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-core-go/data/receipt"
 	"github.com/multiversx/mx-chain-core-go/data/smartContractResult"
 	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-go/common"
 	"github.com/multiversx/mx-chain-go/dataRetriever"
 	"github.com/multiversx/mx-chain-go/dblookupext"
 	"github.com/multiversx/mx-chain-go/node/mock"
 	"github.com/multiversx/mx-chain-go/storage"
 	"github.com/multiversx/mx-chain-go/testscommon"
 	dbLookupExtMock "github.com/multiversx/mx-chain-go/testscommon/dblookupext"
+	"github.com/multiversx/mx-chain-go/testscommon/enableEpochsHandlerMock"
 	"github.com/multiversx/mx-chain-go/testscommon/genericMocks"
 	"github.com/multiversx/mx-chain-go/testscommon/marshallerMock"
 	storageStubs "github.com/multiversx/mx-chain-go/testscommon/storage"
@@ -64,7 +67,7 @@ func TestPutEventsInTransactionReceipt(t *testing.T) {
 	}
 	shardCoordinator := mock.NewOneShardCoordinatorMock()
 	txUnmarshalerAndPreparer := newTransactionUnmarshaller(marshalizerdMock, pubKeyConverter, dataFieldParser, shardCoordinator)
-	n := newAPITransactionResultProcessor(pubKeyConverter, historyRepo, dataStore, marshalizerdMock, txUnmarshalerAndPreparer, logsFacade, shardCoordinator, dataFieldParser)
+	n := newAPITransactionResultProcessor(pubKeyConverter, historyRepo, dataStore, marshalizerdMock, txUnmarshalerAndPreparer, logsFacade, shardCoordinator, dataFieldParser, enableEpochsHandlerMock.NewEnableEpochsHandlerStub(), NewRefundDetector(), &mock.MarshalizerFake{}, 0)
 
 	epoch := uint32(0)
 
@@ -111,6 +114,10 @@ func TestApiTransactionProcessor_PutResultsInTransactionWhenNoResultsShouldWork(
 		&testscommon.LogsFacadeStub{},
 		shardCoordinator,
 		dataFieldParser,
+		enableEpochsHandlerMock.NewEnableEpochsHandlerStub(),
+		NewRefundDetector(),
+		&mock.MarshalizerFake{},
+		0,
 	)
 
 	tx := &transaction.ApiTransactionResult{}
@@ -218,7 +225,7 @@ func TestPutEventsInTransactionSmartContractResults(t *testing.T) {
 	shardCoordinator := mock.NewOneShardCoordinatorMock()
 	pubKeyConverter := testscommon.NewPubkeyConverterMock(3)
 	txUnmarshalerAndPreparer := newTransactionUnmarshaller(marshalizerdMock, pubKeyConverter, dataFieldParser, shardCoordinator)
-	n := newAPITransactionResultProcessor(pubKeyConverter, historyRepo, dataStore, marshalizerdMock, txUnmarshalerAndPreparer, logsFacade, shardCoordinator, dataFieldParser)
+	n := newAPITransactionResultProcessor(pubKeyConverter, historyRepo, dataStore, marshalizerdMock, txUnmarshalerAndPreparer, logsFacade, shardCoordinator, dataFieldParser, enableEpochsHandlerMock.NewEnableEpochsHandlerStub(), NewRefundDetector(), &mock.MarshalizerFake{}, 0)
 
 	encodedSndAddr, err := pubKeyConverter.Encode(scr1.SndAddr)
 	require.Nil(t, err)
@@ -316,7 +323,12 @@ func TestPutLogsInTransaction(t *testing.T) {
 	shardCoordinator := mock.NewOneShardCoordinatorMock()
 	pubKeyConverter := &testscommon.PubkeyConverterMock{}
 	txUnmarshalerAndPreparer := newTransactionUnmarshaller(marshalizerMock, pubKeyConverter, dataFieldParser, shardCoordinator)
-	n := newAPITransactionResultProcessor(pubKeyConverter, historyRepo, dataStore, marshalizerMock, txUnmarshalerAndPreparer, logsFacade, shardCoordinator, dataFieldParser)
+	epochProvider := &enableEpochsHandlerMock.EnableEpochsHandlerStub{
+		GetCurrentEpochCalled: func() uint32 {
+			return testEpoch
+		},
+	}
+	n := newAPITransactionResultProcessor(pubKeyConverter, historyRepo, dataStore, marshalizerMock, txUnmarshalerAndPreparer, logsFacade, shardCoordinator, dataFieldParser, epochProvider, NewRefundDetector(), &mock.MarshalizerFake{}, 0)
 
 	tx := &transaction.ApiTransactionResult{}
 	err := n.putResultsInTransaction(testTxHash, tx, testEpoch)
@@ -325,3 +337,557 @@ func TestPutLogsInTransaction(t *testing.T) {
 	require.Errorf(t, err, "local err")
 	require.Equal(t, logs, tx.Logs)
 }
+
+func TestApiTransactionResultsProcessor_GetOriginalTxInfo(t *testing.T) {
+	t.Parallel()
+
+	originalTxHash := []byte("originalTxHash")
+	originalSender := []byte("originalSender")
+	shardCoordinator := mock.NewMultiShardsCoordinatorMock(3)
+	shardCoordinator.ComputeIdCalled = func(address []byte) uint32 {
+		if bytes.Equal(address, originalSender) {
+			return 2
+		}
+		return 0
+	}
+	n := newAPITransactionResultProcessor(
+		&testscommon.PubkeyConverterMock{},
+		&dbLookupExtMock.HistoryRepositoryStub{},
+		&storageStubs.ChainStorerStub{},
+		&mock.MarshalizerFake{},
+		nil,
+		&testscommon.LogsFacadeStub{},
+		shardCoordinator,
+		&testscommon.DataFieldParserStub{},
+		enableEpochsHandlerMock.NewEnableEpochsHandlerStub(),
+		NewRefundDetector(),
+		&mock.MarshalizerFake{},
+		0,
+	)
+
+	t.Run("cross-shard smart contract result should populate the fields", func(t *testing.T) {
+		t.Parallel()
+
+		scr := &smartContractResult.SmartContractResult{
+			OriginalTxHash: originalTxHash,
+			OriginalSender: originalSender,
+		}
+
+		info := n.getOriginalTxInfo(scr, originalTxHash, 37)
+		require.Equal(t, uint64(37), info.nonce)
+		require.Equal(t, uint32(2), info.senderShard)
+	})
+	t.Run("different original transaction should not populate the nonce", func(t *testing.T) {
+		t.Parallel()
+
+		scr := &smartContractResult.SmartContractResult{
+			OriginalTxHash: []byte("another hash"),
+			OriginalSender: originalSender,
+		}
+
+		info := n.getOriginalTxInfo(scr, originalTxHash, 37)
+		require.Equal(t, uint64(0), info.nonce)
+		require.Equal(t, uint32(2), info.senderShard)
+	})
+	t.Run("missing info should leave the fields on zero values", func(t *testing.T) {
+		t.Parallel()
+
+		info := n.getOriginalTxInfo(&smartContractResult.SmartContractResult{}, nil, 37)
+		require.Equal(t, originalTxInfo{}, info)
+	})
+}
+
+func TestApiTransactionResultsProcessor_GetContractResultReceipt(t *testing.T) {
+	t.Parallel()
+
+	epoch := uint32(3)
+	scrWithReceiptHash := []byte("scrWithReceipt")
+	scrWithoutReceiptHash := []byte("scrWithoutReceipt")
+	receiptHash := []byte("receiptHash")
+	rec := &receipt.Receipt{
+		TxHash:  scrWithReceiptHash,
+		Data:    []byte("refund"),
+		Value:   big.NewInt(37),
+		SndAddr: []byte("sndAddr"),
+	}
+
+	marshaller := &mock.MarshalizerFake{}
+	pubKeyConverter := &testscommon.PubkeyConverterMock{}
+	dataFieldParser := &testscommon.DataFieldParserStub{}
+	shardCoordinator := mock.NewOneShardCoordinatorMock()
+	dataStore := &storageStubs.ChainStorerStub{
+		GetStorerCalled: func(unitType dataRetriever.UnitType) (storage.Storer, error) {
+			return &storageStubs.StorerStub{
+				GetFromEpochCalled: func(key []byte, e uint32) ([]byte, error) {
+					require.Equal(t, receiptHash, key)
+					require.Equal(t, epoch, e)
+
+					return marshaller.Marshal(rec)
+				},
+			}, nil
+		},
+	}
+	historyRepo := &dbLookupExtMock.HistoryRepositoryStub{
+		GetEventsHashesByTxHashCalled: func(hash []byte, e uint32) (*dblookupext.ResultsHashesByTxHash, error) {
+			if bytes.Equal(hash, scrWithReceiptHash) {
+				return &dblookupext.ResultsHashesByTxHash{ReceiptsHash: receiptHash}, nil
+			}
+
+			return nil, dblookupext.ErrNotFoundInStorage
+		},
+	}
+	txUnmarshaller := newTransactionUnmarshaller(marshaller, pubKeyConverter, dataFieldParser, shardCoordinator)
+	n := newAPITransactionResultProcessor(pubKeyConverter, historyRepo, dataStore, marshaller, txUnmarshaller, &testscommon.LogsFacadeStub{}, shardCoordinator, dataFieldParser, enableEpochsHandlerMock.NewEnableEpochsHandlerStub(), NewRefundDetector(), &mock.MarshalizerFake{}, 0)
+
+	t.Run("smart contract result with receipt", func(t *testing.T) {
+		t.Parallel()
+
+		apiReceipt, err := n.getContractResultReceipt(scrWithReceiptHash, epoch)
+		require.Nil(t, err)
+		require.Equal(t, &transaction.ApiReceipt{
+			Value:   rec.Value,
+			SndAddr: hex.EncodeToString(rec.SndAddr),
+			Data:    string(rec.Data),
+			TxHash:  hex.EncodeToString(scrWithReceiptHash),
+		}, apiReceipt)
+	})
+	t.Run("smart contract result without receipt", func(t *testing.T) {
+		t.Parallel()
+
+		apiReceipt, err := n.getContractResultReceipt(scrWithoutReceiptHash, epoch)
+		require.Nil(t, err)
+		require.Nil(t, apiReceipt)
+	})
+}
+
+func TestApiTransactionResultsProcessor_PutResultsInTransactionEpochValidation(t *testing.T) {
+	t.Parallel()
+
+	currentEpoch := uint32(10)
+	createProcessor := func(numGetResultsCalls *int) *apiTransactionResultsProcessor {
+		historyRepo := &dbLookupExtMock.HistoryRepositoryStub{
+			GetEventsHashesByTxHashCalled: func(hash []byte, epoch uint32) (*dblookupext.ResultsHashesByTxHash, error) {
+				*numGetResultsCalls++
+				return nil, dblookupext.ErrNotFoundInStorage
+			},
+		}
+		epochProvider := &enableEpochsHandlerMock.EnableEpochsHandlerStub{
+			GetCurrentEpochCalled: func() uint32 {
+				return currentEpoch
+			},
+		}
+
+		return newAPITransactionResultProcessor(
+			&testscommon.PubkeyConverterMock{},
+			historyRepo,
+			&storageStubs.ChainStorerStub{},
+			&mock.MarshalizerFake{},
+			nil,
+			&testscommon.LogsFacadeStub{},
+			mock.NewOneShardCoordinatorMock(),
+			&testscommon.DataFieldParserStub{},
+			epochProvider,
+			NewRefundDetector(),
+			&mock.MarshalizerFake{},
+			0,
+		)
+	}
+
+	t.Run("valid epoch should work", func(t *testing.T) {
+		t.Parallel()
+
+		numGetResultsCalls := 0
+		n := createProcessor(&numGetResultsCalls)
+
+		tx := &transaction.ApiTransactionResult{}
+		err := n.putResultsInTransaction([]byte("txHash"), tx, currentEpoch)
+		require.Nil(t, err)
+		require.Equal(t, 1, numGetResultsCalls)
+
+		err = n.putResultsInTransaction([]byte("txHash"), tx, currentEpoch+maxEpochsAheadOfCurrentEpoch)
+		require.Nil(t, err)
+		require.Equal(t, 2, numGetResultsCalls)
+	})
+	t.Run("future epoch should error", func(t *testing.T) {
+		t.Parallel()
+
+		numGetResultsCalls := 0
+		n := createProcessor(&numGetResultsCalls)
+
+		tx := &transaction.ApiTransactionResult{}
+		err := n.putResultsInTransaction([]byte("txHash"), tx, currentEpoch+100)
+		require.True(t, errors.Is(err, ErrEpochInTheFuture))
+		require.Equal(t, 0, numGetResultsCalls)
+	})
+}
+
+func TestApiTransactionResultsProcessor_IsSmartContractResultNotarizedAtDestination(t *testing.T) {
+	t.Parallel()
+
+	notarizedScrHash := []byte("notarized")
+	notNotarizedScrHash := []byte("not notarized")
+	notarizedOnMetaScrHash := []byte("notarized on meta")
+	historyRepo := &dbLookupExtMock.HistoryRepositoryStub{
+		GetMiniblockMetadataByTxHashCalled: func(hash []byte) (*dblookupext.MiniblockMetadata, error) {
+			switch string(hash) {
+			case string(notarizedScrHash):
+				return &dblookupext.MiniblockMetadata{
+					DestinationShardID:                1,
+					HeaderNonce:                       10,
+					NotarizedAtDestinationInMetaNonce: 12,
+				}, nil
+			case string(notNotarizedScrHash):
+				return &dblookupext.MiniblockMetadata{
+					DestinationShardID: 1,
+					HeaderNonce:        10,
+				}, nil
+			case string(notarizedOnMetaScrHash):
+				return &dblookupext.MiniblockMetadata{
+					DestinationShardID: core.MetachainShardId,
+					HeaderNonce:        10,
+				}, nil
+			default:
+				return nil, errors.New("not found")
+			}
+		},
+	}
+	n := newAPITransactionResultProcessor(
+		&testscommon.PubkeyConverterMock{},
+		historyRepo,
+		&storageStubs.ChainStorerStub{},
+		&mock.MarshalizerFake{},
+		nil,
+		&testscommon.LogsFacadeStub{},
+		mock.NewOneShardCoordinatorMock(),
+		&testscommon.DataFieldParserStub{},
+		enableEpochsHandlerMock.NewEnableEpochsHandlerStub(),
+		NewRefundDetector(),
+		&mock.MarshalizerFake{},
+		0,
+	)
+
+	require.True(t, n.isSmartContractResultNotarizedAtDestination(notarizedScrHash))
+	require.False(t, n.isSmartContractResultNotarizedAtDestination(notNotarizedScrHash))
+	require.True(t, n.isSmartContractResultNotarizedAtDestination(notarizedOnMetaScrHash))
+	require.False(t, n.isSmartContractResultNotarizedAtDestination([]byte("unknown")))
+}
+
+func TestApiTransactionResultsProcessor_FilterSmartContractResultsByOriginalSender(t *testing.T) {
+	t.Parallel()
+
+	alice := []byte("alice")
+	bob := []byte("bob")
+	n := newAPITransactionResultProcessor(
+		&testscommon.PubkeyConverterMock{},
+		&dbLookupExtMock.HistoryRepositoryStub{},
+		&storageStubs.ChainStorerStub{},
+		&mock.MarshalizerFake{},
+		nil,
+		&testscommon.LogsFacadeStub{},
+		mock.NewOneShardCoordinatorMock(),
+		&testscommon.DataFieldParserStub{
+			ParseCalled: func(dataField []byte, sender, receiver []byte, _ uint32) *datafield.ResponseParseData {
+				return &datafield.ResponseParseData{}
+			},
+		},
+		enableEpochsHandlerMock.NewEnableEpochsHandlerStub(),
+		NewRefundDetector(),
+		&mock.MarshalizerFake{},
+		0,
+	)
+
+	scrs := []*transaction.ApiSmartContractResult{
+		n.adaptSmartContractResult([]byte("scr1"), &smartContractResult.SmartContractResult{Value: big.NewInt(1), OriginalSender: alice}),
+		n.adaptSmartContractResult([]byte("scr2"), &smartContractResult.SmartContractResult{Value: big.NewInt(2), OriginalSender: bob}),
+		n.adaptSmartContractResult([]byte("scr3"), &smartContractResult.SmartContractResult{Value: big.NewInt(3), OriginalSender: alice}),
+		n.adaptSmartContractResult([]byte("scr4"), &smartContractResult.SmartContractResult{Value: big.NewInt(4)}),
+	}
+
+	filteredScrs, err := n.filterSmartContractResultsByOriginalSender(scrs, alice)
+	require.Nil(t, err)
+	require.Equal(t, []*transaction.ApiSmartContractResult{scrs[0], scrs[2]}, filteredScrs)
+
+	filteredScrs, err = n.filterSmartContractResultsByOriginalSender(scrs, bob)
+	require.Nil(t, err)
+	require.Equal(t, []*transaction.ApiSmartContractResult{scrs[1]}, filteredScrs)
+
+	filteredScrs, err = n.filterSmartContractResultsByOriginalSender(scrs, []byte("carol"))
+	require.Nil(t, err)
+	require.Empty(t, filteredScrs)
+}
+
+func TestApiTransactionResultsProcessor_ComputeTransactionDetailsShouldNotAlterTheTransaction(t *testing.T) {
+	t.Parallel()
+
+	alice := []byte("alice")
+	bob := []byte("bob")
+	txHash := []byte("txHash")
+	n := newAPITransactionResultProcessor(
+		testscommon.NewPubkeyConverterMock(5),
+		&dbLookupExtMock.HistoryRepositoryStub{
+			GetEventsHashesByTxHashCalled: func(hash []byte, epoch uint32) (*dblookupext.ResultsHashesByTxHash, error) {
+				return nil, dblookupext.ErrNotFoundInStorage
+			},
+		},
+		&storageStubs.ChainStorerStub{
+			GetStorerCalled: func(unitType dataRetriever.UnitType) (storage.Storer, error) {
+				require.Fail(t, "the smart contract results should not be loaded again from storage")
+				return nil, nil
+			},
+		},
+		&mock.MarshalizerFake{},
+		nil,
+		&testscommon.LogsFacadeStub{},
+		mock.NewOneShardCoordinatorMock(),
+		&testscommon.DataFieldParserStub{
+			ParseCalled: func(dataField []byte, sender, receiver []byte, _ uint32) *datafield.ResponseParseData {
+				return &datafield.ResponseParseData{}
+			},
+		},
+		enableEpochsHandlerMock.NewEnableEpochsHandlerStub(),
+		NewRefundDetector(),
+		&mock.MarshalizerFake{},
+		0,
+	)
+
+	scrs := []*transaction.ApiSmartContractResult{
+		n.adaptSmartContractResult([]byte("scr1"), &smartContractResult.SmartContractResult{Value: big.NewInt(1), OriginalSender: alice, PrevTxHash: txHash}),
+		n.adaptSmartContractResult([]byte("scr2"), &smartContractResult.SmartContractResult{Value: big.NewInt(2), OriginalSender: bob, PrevTxHash: txHash}),
+	}
+	tx := &transaction.ApiTransactionResult{
+		Epoch:                1,
+		SmartContractResults: scrs,
+	}
+
+	txDetails, err := n.computeTransactionDetails(txHash, tx, alice)
+	require.Nil(t, err)
+	require.Equal(t, []*transaction.ApiSmartContractResult{scrs[0]}, txDetails.Transaction.SmartContractResults)
+	require.Len(t, txDetails.SmartContractResults, 1)
+	require.Equal(t, hex.EncodeToString([]byte("scr1")), txDetails.SmartContractResults[0].Hash)
+	require.Equal(t, uint32(1), txDetails.SmartContractResults[0].Epoch)
+
+	require.Equal(t, scrs, tx.SmartContractResults)
+	require.Len(t, tx.SmartContractResults, 2)
+}
+
+func TestApiTransactionResultsProcessor_ShardCoordinatorAccessors(t *testing.T) {
+	t.Parallel()
+
+	shardCoordinator := mock.NewMultiShardsCoordinatorMock(3)
+	shardCoordinator.CurrentShard = 2
+
+	n := newAPITransactionResultProcessor(
+		&testscommon.PubkeyConverterMock{},
+		&dbLookupExtMock.HistoryRepositoryStub{},
+		&storageStubs.ChainStorerStub{},
+		&mock.MarshalizerFake{},
+		nil,
+		&testscommon.LogsFacadeStub{},
+		shardCoordinator,
+		&testscommon.DataFieldParserStub{},
+		enableEpochsHandlerMock.NewEnableEpochsHandlerStub(),
+		NewRefundDetector(),
+		&mock.MarshalizerFake{},
+		0,
+	)
+
+	require.Equal(t, uint32(2), n.SelfShardID())
+	require.Equal(t, uint32(3), n.NumShards())
+}
+
+func TestApiTransactionResultsProcessor_LogEventsTruncation(t *testing.T) {
+	t.Parallel()
+
+	createLogs := func() *transaction.ApiLogs {
+		return &transaction.ApiLogs{
+			Address: "erd1contract",
+			Events: []*transaction.Events{
+				{Identifier: "first"},
+				{Identifier: "second"},
+				{Identifier: "third"},
+			},
+		}
+	}
+	createLogsDetails := func() *common.ApiLogsDetails {
+		return &common.ApiLogsDetails{
+			NumEvents: 3,
+			DecodedEventsData: []map[string]interface{}{
+				{"value": "first"},
+				nil,
+				{"value": "third"},
+			},
+		}
+	}
+	createProcessor := func(maxEventsPerLog uint32) *apiTransactionResultsProcessor {
+		logsFacade := &testscommon.LogsFacadeStub{
+			GetLogCalled: func(txHash []byte, epoch uint32) (*transaction.ApiLogs, error) {
+				return createLogs(), nil
+			},
+			GetLogDetailsCalled: func(logKey []byte, epoch uint32) (*common.ApiLogsDetails, error) {
+				return createLogsDetails(), nil
+			},
+		}
+
+		return newAPITransactionResultProcessor(
+			&testscommon.PubkeyConverterMock{},
+			&dbLookupExtMock.HistoryRepositoryStub{},
+			&storageStubs.ChainStorerStub{},
+			&mock.MarshalizerFake{},
+			nil,
+			logsFacade,
+			mock.NewOneShardCoordinatorMock(),
+			&testscommon.DataFieldParserStub{},
+			enableEpochsHandlerMock.NewEnableEpochsHandlerStub(),
+			NewRefundDetector(),
+			&mock.MarshalizerFake{},
+			maxEventsPerLog,
+		)
+	}
+
+	t.Run("zero limit should not truncate", func(t *testing.T) {
+		t.Parallel()
+
+		n := createProcessor(0)
+
+		tx := &transaction.ApiTransactionResult{}
+		n.loadLogsIntoTransaction([]byte("txHash"), tx, 0)
+		require.Equal(t, createLogs(), tx.Logs)
+
+		scr := &transaction.ApiSmartContractResult{}
+		n.loadLogsIntoContractResults([]byte("scrHash"), 0, scr)
+		require.Equal(t, createLogs(), scr.Logs)
+
+		logsDetails := n.getLogsDetails([]byte("txHash"), 0)
+		require.False(t, logsDetails.EventsTruncated)
+		require.Equal(t, createLogsDetails(), logsDetails)
+	})
+	t.Run("limit not exceeded should not truncate", func(t *testing.T) {
+		t.Parallel()
+
+		n := createProcessor(3)
+
+		logs, truncated := n.truncateLogEvents(createLogs())
+		require.False(t, truncated)
+		require.Equal(t, createLogs(), logs)
+
+		logs, truncated = n.truncateLogEvents(nil)
+		require.False(t, truncated)
+		require.Nil(t, logs)
+
+		logsDetails := n.getLogsDetails([]byte("txHash"), 0)
+		require.False(t, logsDetails.EventsTruncated)
+		require.Equal(t, createLogsDetails(), logsDetails)
+
+		require.Nil(t, n.truncateLogsDetailsEvents(nil))
+	})
+	t.Run("limit exceeded should truncate", func(t *testing.T) {
+		t.Parallel()
+
+		n := createProcessor(2)
+		expectedLogs := createLogs()
+		expectedLogs.Events = expectedLogs.Events[:2]
+
+		providedLogs := createLogs()
+		logs, truncated := n.truncateLogEvents(providedLogs)
+		require.True(t, truncated)
+		require.Equal(t, expectedLogs, logs)
+		require.Len(t, providedLogs.Events, 3, "provided logs should not be altered")
+
+		tx := &transaction.ApiTransactionResult{}
+		n.loadLogsIntoTransaction([]byte("txHash"), tx, 0)
+		require.Equal(t, expectedLogs, tx.Logs)
+
+		scr := &transaction.ApiSmartContractResult{}
+		n.loadLogsIntoContractResults([]byte("scrHash"), 0, scr)
+		require.Equal(t, expectedLogs, scr.Logs)
+
+		expectedLogsDetails := &common.ApiLogsDetails{
+			NumEvents:       3,
+			EventsTruncated: true,
+			DecodedEventsData: []map[string]interface{}{
+				{"value": "first"},
+				nil,
+			},
+		}
+		providedLogsDetails := createLogsDetails()
+		logsDetails := n.truncateLogsDetailsEvents(providedLogsDetails)
+		require.Equal(t, expectedLogsDetails, logsDetails)
+		require.Equal(t, createLogsDetails(), providedLogsDetails, "provided logs details should not be altered")
+		require.Equal(t, expectedLogsDetails, n.getLogsDetails([]byte("scrHash"), 0))
+	})
+}
+
+func TestApiTransactionResultsProcessor_SmartContractResultsEpochs(t *testing.T) {
+	t.Parallel()
+
+	txHash := []byte("txHash")
+	scrHashEpoch1 := []byte("scrHashEpoch1")
+	scrHashEpoch2a := []byte("scrHashEpoch2a")
+	scrHashEpoch2b := []byte("scrHashEpoch2b")
+	scrsHashesAndEpochs := []*dblookupext.ScResultsHashesAndEpoch{
+		{
+			Epoch:           1,
+			ScResultsHashes: [][]byte{scrHashEpoch1},
+		},
+		{
+			Epoch:           2,
+			ScResultsHashes: [][]byte{scrHashEpoch2a, scrHashEpoch2b},
+		},
+	}
+	scrsEpochsInStorage := map[string]uint32{
+		string(scrHashEpoch1):  1,
+		string(scrHashEpoch2a): 2,
+		string(scrHashEpoch2b): 2,
+	}
+
+	marshaller := &mock.MarshalizerFake{}
+	dataStore := &storageStubs.ChainStorerStub{
+		GetStorerCalled: func(unitType dataRetriever.UnitType) (storage.Storer, error) {
+			return &storageStubs.StorerStub{
+				GetFromEpochCalled: func(key []byte, epoch uint32) ([]byte, error) {
+					storedEpoch, found := scrsEpochsInStorage[string(key)]
+					if !found || storedEpoch != epoch {
+						return nil, errors.New("not found")
+					}
+
+					return marshaller.Marshal(&smartContractResult.SmartContractResult{Nonce: uint64(epoch)})
+				},
+			}, nil
+		},
+	}
+	historyRepo := &dbLookupExtMock.HistoryRepositoryStub{
+		GetEventsHashesByTxHashCalled: func(hash []byte, epoch uint32) (*dblookupext.ResultsHashesByTxHash, error) {
+			if bytes.Equal(hash, txHash) {
+				return &dblookupext.ResultsHashesByTxHash{ScResultsHashesAndEpoch: scrsHashesAndEpochs}, nil
+			}
+
+			return nil, dblookupext.ErrNotFoundInStorage
+		},
+	}
+	dataFieldParser := &testscommon.DataFieldParserStub{
+		ParseCalled: func(dataField []byte, sender, receiver []byte, _ uint32) *datafield.ResponseParseData {
+			return &datafield.ResponseParseData{}
+		},
+	}
+	shardCoordinator := mock.NewOneShardCoordinatorMock()
+	pubKeyConverter := testscommon.NewPubkeyConverterMock(3)
+	txUnmarshaller := newTransactionUnmarshaller(marshaller, pubKeyConverter, dataFieldParser, shardCoordinator)
+	n := newAPITransactionResultProcessor(pubKeyConverter, historyRepo, dataStore, marshaller, txUnmarshaller, &testscommon.LogsFacadeStub{}, shardCoordinator, dataFieldParser, enableEpochsHandlerMock.NewEnableEpochsHandlerStub(), NewRefundDetector(), &mock.MarshalizerFake{}, 0)
+
+	tx := &transaction.ApiTransactionResult{Epoch: 1}
+	err := n.putResultsInTransaction(txHash, tx, 1)
+	require.Nil(t, err)
+
+	txDetails, err := n.computeTransactionDetails(txHash, tx, nil)
+	require.Nil(t, err)
+	require.Len(t, txDetails.SmartContractResults, 3)
+
+	expectedScrsEpochs := map[string]uint32{
+		hex.EncodeToString(scrHashEpoch1):  1,
+		hex.EncodeToString(scrHashEpoch2a): 2,
+		hex.EncodeToString(scrHashEpoch2b): 2,
+	}
+	for _, scrDetails := range txDetails.SmartContractResults {
+		require.Equal(t, expectedScrsEpochs[scrDetails.Hash], scrDetails.Epoch)
+	}
+}
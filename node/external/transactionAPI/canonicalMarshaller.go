@@ -0,0 +1,44 @@
+package transactionAPI
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+)
+
+// MarshalCanonical serializes the provided transaction, along with its smart contract results, logs and receipt, as
+// deterministic JSON: the object keys are sorted and the smart contract results are ordered by their hash
+func MarshalCanonical(tx *transaction.ApiTransactionResult) ([]byte, error) {
+	if tx == nil {
+		return nil, ErrNilTransaction
+	}
+
+	txCopy := *tx
+	if len(tx.SmartContractResults) > 0 {
+		txCopy.SmartContractResults = make([]*transaction.ApiSmartContractResult, len(tx.SmartContractResults))
+		copy(txCopy.SmartContractResults, tx.SmartContractResults)
+		sort.SliceStable(txCopy.SmartContractResults, func(i, j int) bool {
+			return txCopy.SmartContractResults[i].Hash < txCopy.SmartContractResults[j].Hash
+		})
+	}
+
+	txBytes, err := json.Marshal(&txCopy)
+	if err != nil {
+		return nil, err
+	}
+
+	// decoding in a generic structure and encoding it back sorts the keys of all the objects, as the maps are
+	// always encoded with sorted keys. The numbers are kept as they are, in order to not lose precision
+	decoder := json.NewDecoder(bytes.NewReader(txBytes))
+	decoder.UseNumber()
+
+	var genericTx interface{}
+	err = decoder.Decode(&genericTx)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(genericTx)
+}
@@ -0,0 +1,100 @@
+package transactionAPI
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func createTransactionForCanonicalMarshal() *transaction.ApiTransactionResult {
+	return &transaction.ApiTransactionResult{
+		Type:     "normal",
+		Hash:     "txHash",
+		Nonce:    7,
+		Value:    "123456789012345678901234567890",
+		Receiver: "erd1receiver",
+		Sender:   "erd1sender",
+		GasLimit: 50000,
+		Status:   transaction.TxStatusSuccess,
+		SmartContractResults: []*transaction.ApiSmartContractResult{
+			{Hash: "scrHash2", Value: big.NewInt(10), Data: "@6f6b"},
+			{Hash: "scrHash1", Value: big.NewInt(20), Data: "@6f6b"},
+			{Hash: "scrHash3", Value: big.NewInt(30), Data: "@6f6b"},
+		},
+		Logs: &transaction.ApiLogs{
+			Address: "erd1sender",
+			Events: []*transaction.Events{
+				{Identifier: "transferValueOnly", Topics: [][]byte{[]byte("topic")}},
+			},
+		},
+	}
+}
+
+func TestMarshalCanonical(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil transaction should error", func(t *testing.T) {
+		t.Parallel()
+
+		txBytes, err := MarshalCanonical(nil)
+		require.Nil(t, txBytes)
+		require.Equal(t, ErrNilTransaction, err)
+	})
+	t.Run("same transaction should produce the same bytes", func(t *testing.T) {
+		t.Parallel()
+
+		txBytes1, err := MarshalCanonical(createTransactionForCanonicalMarshal())
+		require.Nil(t, err)
+
+		txBytes2, err := MarshalCanonical(createTransactionForCanonicalMarshal())
+		require.Nil(t, err)
+		require.Equal(t, txBytes1, txBytes2)
+	})
+	t.Run("order of the smart contract results should not matter", func(t *testing.T) {
+		t.Parallel()
+
+		tx := createTransactionForCanonicalMarshal()
+		expectedBytes, err := MarshalCanonical(tx)
+		require.Nil(t, err)
+
+		shuffledTx := createTransactionForCanonicalMarshal()
+		scrs := shuffledTx.SmartContractResults
+		scrs[0], scrs[2] = scrs[2], scrs[0]
+		txBytes, err := MarshalCanonical(shuffledTx)
+		require.Nil(t, err)
+		require.Equal(t, expectedBytes, txBytes)
+
+		// the provided transaction should not be altered
+		require.Equal(t, "scrHash2", tx.SmartContractResults[0].Hash)
+	})
+	t.Run("should sort the keys and keep the numbers unaltered", func(t *testing.T) {
+		t.Parallel()
+
+		txBytes, err := MarshalCanonical(createTransactionForCanonicalMarshal())
+		require.Nil(t, err)
+
+		decoded := make(map[string]json.RawMessage)
+		err = json.Unmarshal(txBytes, &decoded)
+		require.Nil(t, err)
+		require.Equal(t, `"123456789012345678901234567890"`, string(decoded["value"]))
+
+		var scrs []map[string]interface{}
+		err = json.Unmarshal(decoded["smartContractResults"], &scrs)
+		require.Nil(t, err)
+		require.Len(t, scrs, 3)
+		require.Equal(t, "scrHash1", scrs[0]["hash"])
+		require.Equal(t, "scrHash2", scrs[1]["hash"])
+		require.Equal(t, "scrHash3", scrs[2]["hash"])
+
+		require.Less(t, indexOf(txBytes, `"gasLimit"`), indexOf(txBytes, `"hash"`))
+		require.Less(t, indexOf(txBytes, `"hash"`), indexOf(txBytes, `"nonce"`))
+	})
+}
+
+func indexOf(data []byte, substring string) int {
+	return strings.Index(string(data), substring)
+}
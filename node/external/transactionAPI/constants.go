@@ -3,4 +3,9 @@ package transactionAPI
 const (
 	okReturnCodeMarker                    = "@6f6b"
 	okReturnCodeMarkerBackwardsCompatible = "@ok"
+	argumentsSeparator                    = "@"
+
+	// maxEpochsAheadOfCurrentEpoch is the tolerance used when validating the epoch of a transaction, in order to cover
+	// the case when the epoch provider was not yet notified about an epoch change
+	maxEpochsAheadOfCurrentEpoch = 1
 )
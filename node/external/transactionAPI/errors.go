@@ -34,3 +34,15 @@ var ErrInvalidAddress = errors.New("invalid address")
 
 // ErrDBLookExtensionIsNotEnabled signals that the db look extension is not enabled
 var ErrDBLookExtensionIsNotEnabled = errors.New("db look extension is not enabled")
+
+// ErrEpochInTheFuture signals that the provided epoch is in the future, relative to the current epoch
+var ErrEpochInTheFuture = errors.New("epoch in the future")
+
+// ErrNilTransaction signals that a nil transaction has been provided
+var ErrNilTransaction = errors.New("nil transaction")
+
+// ErrNilSmartContractResult signals that a nil smart contract result has been provided
+var ErrNilSmartContractResult = errors.New("nil smart contract result")
+
+// ErrInvalidRelayedData signals that the data of a relayed transaction is not valid
+var ErrInvalidRelayedData = errors.New("invalid relayed data")
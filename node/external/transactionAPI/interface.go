@@ -4,6 +4,7 @@ import (
 	"math/big"
 
 	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-go/common"
 	datafield "github.com/multiversx/mx-chain-vm-common-go/parsers/dataField"
 )
 
@@ -15,6 +16,24 @@ type feeComputer interface {
 	IsInterfaceNil() bool
 }
 
+type currentEpochProvider interface {
+	GetCurrentEpoch() uint32
+	IsInterfaceNil() bool
+}
+
+// RefundDetector defines what a refund detector should be able to do
+type RefundDetector interface {
+	IsRefund(input RefundDetectorInput) bool
+	IsInterfaceNil() bool
+}
+
+// RefundClassifier defines a refund detector able to tell the gas refunds apart from the value returns. Implementing it
+// is optional, the built-in classification being used for the refund detectors that do not implement it
+type RefundClassifier interface {
+	IsGasRefund(input RefundDetectorInput) bool
+	IsValueReturn(input RefundDetectorInput) bool
+}
+
 // FeesProcessorHandler defines the interface for the transaction fees processor
 type FeesProcessorHandler interface {
 	IsInterfaceNil() bool
@@ -23,6 +42,7 @@ type FeesProcessorHandler interface {
 // LogsFacade defines the interface of a logs facade
 type LogsFacade interface {
 	GetLog(logKey []byte, epoch uint32) (*transaction.ApiLogs, error)
+	GetLogDetails(logKey []byte, epoch uint32) (*common.ApiLogsDetails, error)
 	IsInterfaceNil() bool
 }
 
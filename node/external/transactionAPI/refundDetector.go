@@ -2,9 +2,11 @@ package transactionAPI
 
 import (
 	"bytes"
+	"math/big"
 	"strings"
 
 	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/data/smartContractResult"
 )
 
 // RefundDetectorInput will contain the needed input
@@ -23,15 +25,84 @@ func NewRefundDetector() *refundDetector {
 	return &refundDetector{}
 }
 
-// IsRefund will verify if the provided input is a refund
+// IsRefund will verify if the provided input is a refund, either a gas refund or a value return
 // Also see: https://github.com/multiversx/mx-chain-es-indexer-go/blob/master/process/transactions/scrsDataToTransactions.go
 func (detector *refundDetector) IsRefund(input RefundDetectorInput) bool {
-	hasValue := input.Value != "0" && input.Value != ""
-	hasReturnCodeOK := detector.isReturnCodeOK(input.Data)
+	return detector.IsGasRefund(input) || detector.IsValueReturn(input)
+}
+
+// IsGasRefund will verify if the provided input returns the unused gas. A gas refund carries value and either the
+// relayer refund return message, or the ok return code without any gas limit, as the protocol does not forward gas
+// when returning the unused one
+func (detector *refundDetector) IsGasRefund(input RefundDetectorInput) bool {
+	if !hasRefundValue(input) {
+		return false
+	}
+
 	isRefundForRelayTxSender := strings.Contains(input.ReturnMessage, core.GasRefundForRelayerMessage)
-	isSuccessful := hasReturnCodeOK || isRefundForRelayTxSender
+	isUnusedGasReturn := detector.isReturnCodeOK(input.Data) && input.GasLimit == 0
+
+	return isRefundForRelayTxSender || isUnusedGasReturn
+}
+
+// IsValueReturn will verify if the provided input returns value as result of the execution. A value return carries
+// value and the ok return code along with a gas limit, the gas being forwarded for the further execution
+// (for example, the callback of an asynchronous call). The relayer gas refunds are never considered value returns
+func (detector *refundDetector) IsValueReturn(input RefundDetectorInput) bool {
+	if !hasRefundValue(input) {
+		return false
+	}
+
+	isRefundForRelayTxSender := strings.Contains(input.ReturnMessage, core.GasRefundForRelayerMessage)
+	isReturnWithGas := detector.isReturnCodeOK(input.Data) && input.GasLimit > 0
+
+	return isReturnWithGas && !isRefundForRelayTxSender
+}
+
+// getRefundClassifier returns the provided refund detector if it is also able to classify the refunds, or the built-in
+// refund detector otherwise
+func getRefundClassifier(detector RefundDetector) RefundClassifier {
+	classifier, ok := detector.(RefundClassifier)
+	if ok {
+		return classifier
+	}
 
-	return hasValue && isSuccessful
+	return NewRefundDetector()
+}
+
+func createRefundDetectorInput(scr *smartContractResult.SmartContractResult) RefundDetectorInput {
+	return RefundDetectorInput{
+		Value:         scr.Value.String(),
+		Data:          scr.Data,
+		ReturnMessage: string(scr.ReturnMessage),
+		GasLimit:      scr.GasLimit,
+	}
+}
+
+func hasRefundValue(input RefundDetectorInput) bool {
+	return input.Value != "0" && input.Value != ""
+}
+
+// computeTotalRefund will return the sum of the values of the provided smart contract results that the provided detector
+// classifies as refunds, along with the number of refunds found
+func computeTotalRefund(detector RefundDetector, scrs []*smartContractResult.SmartContractResult) (*big.Int, int) {
+	totalRefund := big.NewInt(0)
+	numRefunds := 0
+	for _, scr := range scrs {
+		if scr == nil || scr.Value == nil {
+			continue
+		}
+
+		isRefund := detector.IsRefund(createRefundDetectorInput(scr))
+		if !isRefund {
+			continue
+		}
+
+		totalRefund.Add(totalRefund, scr.Value)
+		numRefunds++
+	}
+
+	return totalRefund, numRefunds
 }
 
 // Also see: https://github.com/multiversx/mx-chain-es-indexer-go/blob/master/process/transactions/checkers.go
@@ -41,3 +112,8 @@ func (detector *refundDetector) isReturnCodeOK(resultData []byte) bool {
 
 	return containsOk || containsOkBackwardsCompatible
 }
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (detector *refundDetector) IsInterfaceNil() bool {
+	return detector == nil
+}
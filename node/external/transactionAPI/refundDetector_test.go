@@ -1,8 +1,10 @@
 package transactionAPI
 
 import (
+	"math/big"
 	"testing"
 
+	"github.com/multiversx/mx-chain-core-go/data/smartContractResult"
 	"github.com/stretchr/testify/require"
 )
 
@@ -59,3 +61,138 @@ func TestRefundDetector_IsRefundShouldDetectRefund(t *testing.T) {
 		GasLimit: 1,
 	}))
 }
+
+func TestComputeTotalRefund(t *testing.T) {
+	t.Parallel()
+
+	detector := NewRefundDetector()
+
+	t.Run("no smart contract results should return zero", func(t *testing.T) {
+		t.Parallel()
+
+		totalRefund, numRefunds := computeTotalRefund(detector, nil)
+		require.Equal(t, big.NewInt(0), totalRefund)
+		require.Equal(t, 0, numRefunds)
+	})
+	t.Run("should sum only the refunds", func(t *testing.T) {
+		t.Parallel()
+
+		scrs := []*smartContractResult.SmartContractResult{
+			{Value: big.NewInt(1000), Data: []byte("@ok@test")},
+			{Value: big.NewInt(500), Data: []byte("@6f6b")},
+			{Value: big.NewInt(200), Data: []byte("foobar"), ReturnMessage: []byte("gas refund for relayer")},
+			{Value: big.NewInt(3000), Data: []byte("transfer")},
+			{Value: big.NewInt(0), Data: []byte("@6f6b")},
+			{Data: []byte("@6f6b")},
+			nil,
+		}
+
+		totalRefund, numRefunds := computeTotalRefund(detector, scrs)
+		require.Equal(t, big.NewInt(1700), totalRefund)
+		require.Equal(t, 3, numRefunds)
+	})
+	t.Run("should use the provided detector", func(t *testing.T) {
+		t.Parallel()
+
+		customDetector := &refundDetectorStub{
+			IsRefundCalled: func(input RefundDetectorInput) bool {
+				return string(input.Data) == "custom refund"
+			},
+		}
+		scrs := []*smartContractResult.SmartContractResult{
+			{Value: big.NewInt(1000), Data: []byte("@6f6b")},
+			{Value: big.NewInt(300), Data: []byte("custom refund")},
+			{Value: big.NewInt(400), Data: []byte("custom refund")},
+		}
+
+		totalRefund, numRefunds := computeTotalRefund(customDetector, scrs)
+		require.Equal(t, big.NewInt(700), totalRefund)
+		require.Equal(t, 2, numRefunds)
+	})
+}
+
+func TestRefundDetector_GasRefundAndValueReturn(t *testing.T) {
+	t.Parallel()
+
+	detector := NewRefundDetector()
+
+	t.Run("gas refund should not be a value return", func(t *testing.T) {
+		t.Parallel()
+
+		input := RefundDetectorInput{
+			Value:    "1000",
+			Data:     []byte("@6f6b"),
+			GasLimit: 0,
+		}
+		require.True(t, detector.IsGasRefund(input))
+		require.False(t, detector.IsValueReturn(input))
+		require.True(t, detector.IsRefund(input))
+	})
+	t.Run("gas refund for relayer should not be a value return", func(t *testing.T) {
+		t.Parallel()
+
+		input := RefundDetectorInput{
+			Value:         "1000",
+			Data:          []byte("@6f6b"),
+			ReturnMessage: "gas refund for relayer",
+			GasLimit:      10,
+		}
+		require.True(t, detector.IsGasRefund(input))
+		require.False(t, detector.IsValueReturn(input))
+		require.True(t, detector.IsRefund(input))
+	})
+	t.Run("value return should not be a gas refund", func(t *testing.T) {
+		t.Parallel()
+
+		input := RefundDetectorInput{
+			Value:    "1000",
+			Data:     []byte("@ok@test"),
+			GasLimit: 1,
+		}
+		require.False(t, detector.IsGasRefund(input))
+		require.True(t, detector.IsValueReturn(input))
+		require.True(t, detector.IsRefund(input))
+	})
+	t.Run("without value should be neither", func(t *testing.T) {
+		t.Parallel()
+
+		input := RefundDetectorInput{
+			Value: "0",
+			Data:  []byte("@6f6b"),
+		}
+		require.False(t, detector.IsGasRefund(input))
+		require.False(t, detector.IsValueReturn(input))
+		require.False(t, detector.IsRefund(input))
+	})
+	t.Run("without ok return code should be neither", func(t *testing.T) {
+		t.Parallel()
+
+		input := RefundDetectorInput{
+			Value:    "1000",
+			Data:     []byte("foobar"),
+			GasLimit: 1,
+		}
+		require.False(t, detector.IsGasRefund(input))
+		require.False(t, detector.IsValueReturn(input))
+		require.False(t, detector.IsRefund(input))
+	})
+}
+
+func TestGetRefundClassifier(t *testing.T) {
+	t.Parallel()
+
+	t.Run("detector without classification should return the built-in detector", func(t *testing.T) {
+		t.Parallel()
+
+		classifier := getRefundClassifier(&refundDetectorStub{})
+		_, isBuiltIn := classifier.(*refundDetector)
+		require.True(t, isBuiltIn)
+	})
+	t.Run("detector with classification should return the provided detector", func(t *testing.T) {
+		t.Parallel()
+
+		detector := &refundClassifierStub{}
+		classifier := getRefundClassifier(detector)
+		require.True(t, classifier == detector)
+	})
+}
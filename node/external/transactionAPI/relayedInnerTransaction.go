@@ -0,0 +1,90 @@
+package transactionAPI
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data/smartContractResult"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-core-go/marshal"
+	"github.com/multiversx/mx-chain-go/process"
+)
+
+const numRelayedV2Arguments = 4
+
+// decodeRelayedInnerTransaction decodes the inner transaction embedded in the data of a relayed v1 or v2 smart contract
+// result. The relayed v1 inner transaction is unmarshalled with the provided transaction marshaller, while the relayed v2
+// inner transaction is rebuilt from its arguments, its sender being the receiver of the smart contract result. The gas
+// limit of a relayed v2 inner transaction is not part of the data, so the one of the smart contract result is used.
+func decodeRelayedInnerTransaction(
+	scr *smartContractResult.SmartContractResult,
+	argsParser process.ArgumentsParser,
+	txMarshaller marshal.Marshalizer,
+) (*transaction.Transaction, error) {
+	if scr == nil {
+		return nil, ErrNilSmartContractResult
+	}
+	if check.IfNil(argsParser) {
+		return nil, process.ErrNilArgumentParser
+	}
+	if check.IfNil(txMarshaller) {
+		return nil, process.ErrNilMarshalizer
+	}
+
+	funcName, args, err := argsParser.ParseCallData(string(scr.Data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidRelayedData, err.Error())
+	}
+
+	switch funcName {
+	case core.RelayedTransaction:
+		return decodeRelayedV1InnerTransaction(args, txMarshaller)
+	case core.RelayedTransactionV2:
+		return decodeRelayedV2InnerTransaction(args, scr)
+	default:
+		return nil, fmt.Errorf("%w: unknown relayed function %s", ErrInvalidRelayedData, funcName)
+	}
+}
+
+// isRelayedTxData returns true if the provided data is a relayed v1 or v2 call
+func isRelayedTxData(data []byte) bool {
+	isRelayedV1 := bytes.HasPrefix(data, []byte(core.RelayedTransaction+argumentsSeparator))
+	isRelayedV2 := bytes.HasPrefix(data, []byte(core.RelayedTransactionV2+argumentsSeparator))
+
+	return isRelayedV1 || isRelayedV2
+}
+
+func decodeRelayedV1InnerTransaction(args [][]byte, txMarshaller marshal.Marshalizer) (*transaction.Transaction, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("%w: %s expects 1 argument, got %d", ErrInvalidRelayedData, core.RelayedTransaction, len(args))
+	}
+
+	innerTx := &transaction.Transaction{}
+	err := txMarshaller.Unmarshal(innerTx, args[0])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s inner transaction: %s", ErrInvalidRelayedData, core.RelayedTransaction, err.Error())
+	}
+
+	return innerTx, nil
+}
+
+func decodeRelayedV2InnerTransaction(args [][]byte, scr *smartContractResult.SmartContractResult) (*transaction.Transaction, error) {
+	if len(args) != numRelayedV2Arguments {
+		return nil, fmt.Errorf("%w: %s expects %d arguments, got %d",
+			ErrInvalidRelayedData, core.RelayedTransactionV2, numRelayedV2Arguments, len(args))
+	}
+
+	return &transaction.Transaction{
+		Nonce:     big.NewInt(0).SetBytes(args[1]).Uint64(),
+		Value:     big.NewInt(0),
+		RcvAddr:   args[0],
+		SndAddr:   scr.RcvAddr,
+		GasPrice:  scr.GasPrice,
+		GasLimit:  scr.GasLimit,
+		Data:      args[2],
+		Signature: args[3],
+	}, nil
+}
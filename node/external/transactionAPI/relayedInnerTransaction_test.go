@@ -0,0 +1,212 @@
+package transactionAPI
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/data/smartContractResult"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-core-go/marshal"
+	"github.com/multiversx/mx-chain-go/node/mock"
+	"github.com/multiversx/mx-chain-go/process"
+	"github.com/multiversx/mx-chain-go/process/smartContract"
+	"github.com/multiversx/mx-chain-go/testscommon"
+	dbLookupExtMock "github.com/multiversx/mx-chain-go/testscommon/dblookupext"
+	"github.com/multiversx/mx-chain-go/testscommon/enableEpochsHandlerMock"
+	storageStubs "github.com/multiversx/mx-chain-go/testscommon/storage"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeRelayedInnerTransaction(t *testing.T) {
+	t.Parallel()
+
+	txMarshaller := &marshal.JsonMarshalizer{}
+	argsParser := smartContract.NewArgumentParser()
+
+	t.Run("nil arguments should error", func(t *testing.T) {
+		t.Parallel()
+
+		innerTx, err := decodeRelayedInnerTransaction(nil, argsParser, txMarshaller)
+		require.Nil(t, innerTx)
+		require.Equal(t, ErrNilSmartContractResult, err)
+
+		innerTx, err = decodeRelayedInnerTransaction(&smartContractResult.SmartContractResult{}, nil, txMarshaller)
+		require.Nil(t, innerTx)
+		require.Equal(t, process.ErrNilArgumentParser, err)
+
+		innerTx, err = decodeRelayedInnerTransaction(&smartContractResult.SmartContractResult{}, argsParser, nil)
+		require.Nil(t, innerTx)
+		require.Equal(t, process.ErrNilMarshalizer, err)
+	})
+	t.Run("relayed v1 should decode the inner transaction", func(t *testing.T) {
+		t.Parallel()
+
+		expectedInnerTx := &transaction.Transaction{
+			Nonce:     7,
+			Value:     big.NewInt(1000),
+			RcvAddr:   []byte("receiver"),
+			SndAddr:   []byte("sender"),
+			GasPrice:  1000000000,
+			GasLimit:  50000,
+			Data:      []byte("doSomething@01"),
+			ChainID:   []byte("chainID"),
+			Version:   1,
+			Signature: []byte("signature"),
+		}
+		innerTxBytes, err := txMarshaller.Marshal(expectedInnerTx)
+		require.Nil(t, err)
+
+		scr := &smartContractResult.SmartContractResult{
+			Data: []byte("relayedTx@" + hex.EncodeToString(innerTxBytes)),
+		}
+		innerTx, err := decodeRelayedInnerTransaction(scr, argsParser, txMarshaller)
+		require.Nil(t, err)
+		require.Equal(t, expectedInnerTx, innerTx)
+	})
+	t.Run("relayed v2 should decode the inner transaction", func(t *testing.T) {
+		t.Parallel()
+
+		scr := &smartContractResult.SmartContractResult{
+			RcvAddr:  []byte("sender"),
+			GasPrice: 1000000000,
+			GasLimit: 40000,
+			Data: []byte("relayedTxV2@" + hex.EncodeToString([]byte("receiver")) + "@07@" +
+				hex.EncodeToString([]byte("doSomething")) + "@" + hex.EncodeToString([]byte("signature"))),
+		}
+		innerTx, err := decodeRelayedInnerTransaction(scr, argsParser, txMarshaller)
+		require.Nil(t, err)
+		require.Equal(t, &transaction.Transaction{
+			Nonce:     7,
+			Value:     big.NewInt(0),
+			RcvAddr:   []byte("receiver"),
+			SndAddr:   []byte("sender"),
+			GasPrice:  1000000000,
+			GasLimit:  40000,
+			Data:      []byte("doSomething"),
+			Signature: []byte("signature"),
+		}, innerTx)
+	})
+	t.Run("malformed data should error", func(t *testing.T) {
+		t.Parallel()
+
+		malformedData := []string{
+			"",
+			"transfer@01",
+			"relayedTx",
+			"relayedTx@01@02",
+			"relayedTx@" + hex.EncodeToString([]byte("not a transaction")),
+			"relayedTx@zz",
+			"relayedTxV2@01@02@03",
+			"relayedTxV2@01@02@03@04@05",
+		}
+		for _, data := range malformedData {
+			scr := &smartContractResult.SmartContractResult{
+				Data: []byte(data),
+			}
+			innerTx, err := decodeRelayedInnerTransaction(scr, argsParser, txMarshaller)
+			require.Nil(t, innerTx, data)
+			require.True(t, errors.Is(err, ErrInvalidRelayedData), data)
+		}
+	})
+}
+
+func TestIsRelayedTxData(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, isRelayedTxData([]byte("relayedTx@01")))
+	require.True(t, isRelayedTxData([]byte("relayedTxV2@01@02@03@04")))
+	require.False(t, isRelayedTxData([]byte("relayedTx")))
+	require.False(t, isRelayedTxData([]byte("transfer@01")))
+	require.False(t, isRelayedTxData(nil))
+}
+
+func TestApiTransactionResultsProcessor_GetRelayedInnerTransaction(t *testing.T) {
+	t.Parallel()
+
+	txMarshaller := &marshal.JsonMarshalizer{}
+	marshaller := &mock.MarshalizerFake{}
+	pubKeyConverter := &testscommon.PubkeyConverterMock{}
+	dataFieldParser := &testscommon.DataFieldParserStub{}
+	shardCoordinator := mock.NewOneShardCoordinatorMock()
+	txUnmarshaller := newTransactionUnmarshaller(marshaller, pubKeyConverter, dataFieldParser, shardCoordinator)
+	n := newAPITransactionResultProcessor(
+		pubKeyConverter,
+		&dbLookupExtMock.HistoryRepositoryStub{},
+		&storageStubs.ChainStorerStub{},
+		marshaller,
+		txUnmarshaller,
+		&testscommon.LogsFacadeStub{},
+		shardCoordinator,
+		dataFieldParser,
+		enableEpochsHandlerMock.NewEnableEpochsHandlerStub(),
+		NewRefundDetector(),
+		txMarshaller,
+		0,
+	)
+
+	t.Run("not relayed should return nil", func(t *testing.T) {
+		t.Parallel()
+
+		scr := &smartContractResult.SmartContractResult{
+			Data: []byte("transfer@01"),
+		}
+		require.Nil(t, n.getRelayedInnerTransaction([]byte("hash"), scr))
+	})
+	t.Run("malformed relayed data should return nil", func(t *testing.T) {
+		t.Parallel()
+
+		scr := &smartContractResult.SmartContractResult{
+			Data: []byte("relayedTx@" + hex.EncodeToString([]byte("not a transaction"))),
+		}
+		require.Nil(t, n.getRelayedInnerTransaction([]byte("hash"), scr))
+	})
+	t.Run("relayed v1 should return the inner transaction", func(t *testing.T) {
+		t.Parallel()
+
+		innerTx := &transaction.Transaction{
+			Nonce:     7,
+			Value:     big.NewInt(1000),
+			RcvAddr:   []byte("receiver"),
+			SndAddr:   []byte("sender"),
+			GasPrice:  1000000000,
+			GasLimit:  50000,
+			Data:      []byte("doSomething@01"),
+			ChainID:   []byte("chainID"),
+			Version:   1,
+			Signature: []byte("signature"),
+		}
+		innerTxBytes, err := txMarshaller.Marshal(innerTx)
+		require.Nil(t, err)
+
+		scr := &smartContractResult.SmartContractResult{
+			Data: []byte("relayedTx@" + hex.EncodeToString(innerTxBytes)),
+		}
+		apiInnerTx := n.getRelayedInnerTransaction([]byte("hash"), scr)
+		require.NotNil(t, apiInnerTx)
+		require.Equal(t, uint64(7), apiInnerTx.Nonce)
+		require.Equal(t, "1000", apiInnerTx.Value)
+		require.Equal(t, hex.EncodeToString([]byte("sender")), apiInnerTx.Sender)
+		require.Equal(t, hex.EncodeToString([]byte("receiver")), apiInnerTx.Receiver)
+		require.Equal(t, []byte("doSomething@01"), apiInnerTx.Data)
+		require.Equal(t, string(transaction.TxTypeNormal), apiInnerTx.Type)
+	})
+	t.Run("relayed v2 should return the inner transaction", func(t *testing.T) {
+		t.Parallel()
+
+		scr := &smartContractResult.SmartContractResult{
+			RcvAddr:  []byte("sender"),
+			GasPrice: 1000000000,
+			GasLimit: 40000,
+			Data: []byte("relayedTxV2@" + hex.EncodeToString([]byte("receiver")) + "@07@" +
+				hex.EncodeToString([]byte("doSomething")) + "@" + hex.EncodeToString([]byte("signature"))),
+		}
+		apiInnerTx := n.getRelayedInnerTransaction([]byte("hash"), scr)
+		require.NotNil(t, apiInnerTx)
+		require.Equal(t, uint64(7), apiInnerTx.Nonce)
+		require.Equal(t, hex.EncodeToString([]byte("sender")), apiInnerTx.Sender)
+		require.Equal(t, hex.EncodeToString([]byte("receiver")), apiInnerTx.Receiver)
+		require.Equal(t, uint64(40000), apiInnerTx.GasLimit)
+	})
+}
@@ -0,0 +1,65 @@
+package transactionAPI
+
+import (
+	"bytes"
+
+	"github.com/multiversx/mx-chain-core-go/data/smartContractResult"
+	"github.com/multiversx/mx-chain-core-go/data/vm"
+)
+
+// SCRCategory describes the role of a smart contract result in the execution of the originating transaction
+type SCRCategory string
+
+const (
+	// SCRCategoryCallback is the category of the smart contract results carrying an async callback
+	SCRCategoryCallback SCRCategory = "callback"
+	// SCRCategoryGasRefund is the category of the smart contract results returning the unused gas (including the
+	// refunds sent to the relayers), as identified by the refund detector
+	SCRCategoryGasRefund SCRCategory = "gasRefund"
+	// SCRCategoryValueReturn is the category of the smart contract results returning value with the ok return code
+	// along with gas for the further execution, as identified by the refund detector
+	SCRCategoryValueReturn SCRCategory = "valueReturn"
+	// SCRCategoryFailedCallReturn is the category of the smart contract results returning value with an error return
+	// code, for example the value sent along with a failed call. Not to be confused with the value returns of the
+	// refund detector, which carry the ok return code
+	SCRCategoryFailedCallReturn SCRCategory = "failedCallReturn"
+	// SCRCategoryCrossShardForward is the category of the smart contract results forwarding a call or a transfer to
+	// another shard
+	SCRCategoryCrossShardForward SCRCategory = "crossShardForward"
+	// SCRCategoryOther is the category of the smart contract results not matching any of the above categories
+	SCRCategoryOther SCRCategory = "other"
+)
+
+const returnDataPrefix = "@"
+
+// computeSCRCategory returns the category of the provided smart contract result. The categories are checked in the
+// order in which they are declared, the first match being returned. The gas refund and value return flags should be
+// the ones computed by the refund classifier for the same smart contract result
+func (arp *apiTransactionResultsProcessor) computeSCRCategory(
+	scr *smartContractResult.SmartContractResult,
+	isGasRefund bool,
+	isValueReturn bool,
+) SCRCategory {
+	if scr.CallType == vm.AsynchronousCallBack {
+		return SCRCategoryCallback
+	}
+	if isGasRefund {
+		return SCRCategoryGasRefund
+	}
+	if isValueReturn {
+		return SCRCategoryValueReturn
+	}
+
+	hasValue := scr.Value != nil && scr.Value.Sign() > 0
+	isReturnData := bytes.HasPrefix(scr.Data, []byte(returnDataPrefix))
+	if hasValue && isReturnData {
+		return SCRCategoryFailedCallReturn
+	}
+
+	isCrossShard := arp.shardCoordinator.ComputeId(scr.SndAddr) != arp.shardCoordinator.ComputeId(scr.RcvAddr)
+	if isCrossShard && !isReturnData {
+		return SCRCategoryCrossShardForward
+	}
+
+	return SCRCategoryOther
+}
@@ -0,0 +1,141 @@
+package transactionAPI
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/data/smartContractResult"
+	"github.com/multiversx/mx-chain-core-go/data/vm"
+	"github.com/multiversx/mx-chain-go/node/mock"
+	"github.com/multiversx/mx-chain-go/testscommon"
+	dbLookupExtMock "github.com/multiversx/mx-chain-go/testscommon/dblookupext"
+	"github.com/multiversx/mx-chain-go/testscommon/enableEpochsHandlerMock"
+	storageStubs "github.com/multiversx/mx-chain-go/testscommon/storage"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApiTransactionResultsProcessor_ComputeSCRCategory(t *testing.T) {
+	t.Parallel()
+
+	shardCoordinator := mock.NewMultiShardsCoordinatorMock(2)
+	shardCoordinator.ComputeIdCalled = func(address []byte) uint32 {
+		if string(address) == "addressShard1" {
+			return 1
+		}
+
+		return 0
+	}
+
+	n := newAPITransactionResultProcessor(
+		&testscommon.PubkeyConverterMock{},
+		&dbLookupExtMock.HistoryRepositoryStub{},
+		&storageStubs.ChainStorerStub{},
+		&mock.MarshalizerFake{},
+		nil,
+		&testscommon.LogsFacadeStub{},
+		shardCoordinator,
+		&testscommon.DataFieldParserStub{},
+		enableEpochsHandlerMock.NewEnableEpochsHandlerStub(),
+		NewRefundDetector(),
+		&mock.MarshalizerFake{},
+		0,
+	)
+
+	computeCategory := func(scr *smartContractResult.SmartContractResult) SCRCategory {
+		refundDetectorInput := createRefundDetectorInput(scr)
+		isGasRefund := n.refundClassifier.IsGasRefund(refundDetectorInput)
+		isValueReturn := n.refundClassifier.IsValueReturn(refundDetectorInput)
+
+		return n.computeSCRCategory(scr, isGasRefund, isValueReturn)
+	}
+
+	t.Run("callback", func(t *testing.T) {
+		t.Parallel()
+
+		scr := &smartContractResult.SmartContractResult{
+			Value:    big.NewInt(10),
+			Data:     []byte("@6f6b"),
+			CallType: vm.AsynchronousCallBack,
+			SndAddr:  []byte("addressShard0"),
+			RcvAddr:  []byte("addressShard1"),
+		}
+		require.Equal(t, SCRCategoryCallback, computeCategory(scr))
+	})
+	t.Run("gas refund", func(t *testing.T) {
+		t.Parallel()
+
+		scr := &smartContractResult.SmartContractResult{
+			Value:   big.NewInt(10),
+			Data:    []byte("@6f6b"),
+			SndAddr: []byte("addressShard0"),
+			RcvAddr: []byte("addressShard1"),
+		}
+		require.Equal(t, SCRCategoryGasRefund, computeCategory(scr))
+	})
+	t.Run("gas refund for relayer", func(t *testing.T) {
+		t.Parallel()
+
+		scr := &smartContractResult.SmartContractResult{
+			Value:         big.NewInt(10),
+			ReturnMessage: []byte("gas refund for relayer"),
+			SndAddr:       []byte("addressShard0"),
+			RcvAddr:       []byte("addressShard0"),
+		}
+		require.Equal(t, SCRCategoryGasRefund, computeCategory(scr))
+	})
+	t.Run("value return", func(t *testing.T) {
+		t.Parallel()
+
+		scr := &smartContractResult.SmartContractResult{
+			Value:    big.NewInt(10),
+			Data:     []byte("@6f6b"),
+			GasLimit: 1000,
+			SndAddr:  []byte("addressShard0"),
+			RcvAddr:  []byte("addressShard1"),
+		}
+		require.Equal(t, SCRCategoryValueReturn, computeCategory(scr))
+	})
+	t.Run("failed call return", func(t *testing.T) {
+		t.Parallel()
+
+		scr := &smartContractResult.SmartContractResult{
+			Value:         big.NewInt(10),
+			Data:          []byte("@75736572206572726f72"),
+			ReturnMessage: []byte("user error"),
+			SndAddr:       []byte("addressShard0"),
+			RcvAddr:       []byte("addressShard1"),
+		}
+		require.Equal(t, SCRCategoryFailedCallReturn, computeCategory(scr))
+	})
+	t.Run("cross shard forward", func(t *testing.T) {
+		t.Parallel()
+
+		scr := &smartContractResult.SmartContractResult{
+			Value:    big.NewInt(10),
+			Data:     []byte("deposit@01"),
+			CallType: vm.AsynchronousCall,
+			SndAddr:  []byte("addressShard0"),
+			RcvAddr:  []byte("addressShard1"),
+		}
+		require.Equal(t, SCRCategoryCrossShardForward, computeCategory(scr))
+	})
+	t.Run("other", func(t *testing.T) {
+		t.Parallel()
+
+		scr := &smartContractResult.SmartContractResult{
+			Value:   big.NewInt(0),
+			Data:    []byte("deposit@01"),
+			SndAddr: []byte("addressShard0"),
+			RcvAddr: []byte("addressShard0"),
+		}
+		require.Equal(t, SCRCategoryOther, computeCategory(scr))
+
+		scr = &smartContractResult.SmartContractResult{
+			Value:   big.NewInt(0),
+			Data:    []byte("@6f6b"),
+			SndAddr: []byte("addressShard0"),
+			RcvAddr: []byte("addressShard1"),
+		}
+		require.Equal(t, SCRCategoryOther, computeCategory(scr))
+	})
+}
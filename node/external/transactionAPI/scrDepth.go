@@ -0,0 +1,80 @@
+package transactionAPI
+
+import (
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+)
+
+// unknownSCRDepth is the depth of the smart contract results whose previous transactions chain does not lead to the
+// original transaction, either because a link is missing or because the chain has a cycle
+const unknownSCRDepth = -1
+
+// computeSCRsDepths returns the depth of each provided smart contract result in the call tree of the original
+// transaction, keyed by the smart contract result hash. The direct children of the original transaction (having it
+// as previous transaction) have the depth 0, their children the depth 1 and so on.
+func computeSCRsDepths(originalTxHash string, scrs []*transaction.ApiSmartContractResult) map[string]int {
+	scrsByHash := make(map[string]*transaction.ApiSmartContractResult, len(scrs))
+	for _, scr := range scrs {
+		if scr == nil {
+			continue
+		}
+
+		scrsByHash[scr.Hash] = scr
+	}
+
+	depths := make(map[string]int, len(scrsByHash))
+	for hash := range scrsByHash {
+		computeSCRDepth(hash, originalTxHash, scrsByHash, depths, make(map[string]struct{}))
+	}
+
+	return depths
+}
+
+// getSCRDepth returns the computed depth of the smart contract result with the provided hash, or unknownSCRDepth if the
+// depth was not computed
+func getSCRDepth(depths map[string]int, hash string) int {
+	depth, found := depths[hash]
+	if !found {
+		return unknownSCRDepth
+	}
+
+	return depth
+}
+
+// computeSCRDepth computes, memoizes and returns the depth of the smart contract result with the provided hash. The
+// visited map holds the hashes on the current chain, so a cycle is detected when a hash is visited twice
+func computeSCRDepth(
+	hash string,
+	originalTxHash string,
+	scrsByHash map[string]*transaction.ApiSmartContractResult,
+	depths map[string]int,
+	visited map[string]struct{},
+) int {
+	depth, computed := depths[hash]
+	if computed {
+		return depth
+	}
+
+	scr, found := scrsByHash[hash]
+	if !found {
+		return unknownSCRDepth
+	}
+	if scr.PrevTxHash == originalTxHash {
+		depths[hash] = 0
+		return 0
+	}
+
+	_, isCycle := visited[hash]
+	if isCycle {
+		depths[hash] = unknownSCRDepth
+		return unknownSCRDepth
+	}
+	visited[hash] = struct{}{}
+
+	depth = computeSCRDepth(scr.PrevTxHash, originalTxHash, scrsByHash, depths, visited)
+	if depth != unknownSCRDepth {
+		depth++
+	}
+	depths[hash] = depth
+
+	return depth
+}
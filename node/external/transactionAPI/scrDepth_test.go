@@ -0,0 +1,79 @@
+package transactionAPI
+
+import (
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeSCRsDepths(t *testing.T) {
+	t.Parallel()
+
+	t.Run("two levels tree", func(t *testing.T) {
+		t.Parallel()
+
+		scrs := []*transaction.ApiSmartContractResult{
+			{Hash: "grandchild", PrevTxHash: "child1"},
+			{Hash: "child1", PrevTxHash: "tx"},
+			{Hash: "child2", PrevTxHash: "tx"},
+		}
+
+		depths := computeSCRsDepths("tx", scrs)
+		expectedDepths := map[string]int{
+			"child1":     0,
+			"child2":     0,
+			"grandchild": 1,
+		}
+		require.Equal(t, expectedDepths, depths)
+	})
+	t.Run("missing parent should have unknown depth", func(t *testing.T) {
+		t.Parallel()
+
+		scrs := []*transaction.ApiSmartContractResult{
+			{Hash: "child", PrevTxHash: "tx"},
+			{Hash: "orphan", PrevTxHash: "missing"},
+			{Hash: "orphan child", PrevTxHash: "orphan"},
+		}
+
+		depths := computeSCRsDepths("tx", scrs)
+		expectedDepths := map[string]int{
+			"child":        0,
+			"orphan":       unknownSCRDepth,
+			"orphan child": unknownSCRDepth,
+		}
+		require.Equal(t, expectedDepths, depths)
+	})
+	t.Run("cycle should have unknown depth", func(t *testing.T) {
+		t.Parallel()
+
+		scrs := []*transaction.ApiSmartContractResult{
+			{Hash: "child", PrevTxHash: "tx"},
+			{Hash: "a", PrevTxHash: "b"},
+			{Hash: "b", PrevTxHash: "a"},
+			{Hash: "self", PrevTxHash: "self"},
+			nil,
+		}
+
+		depths := computeSCRsDepths("tx", scrs)
+		expectedDepths := map[string]int{
+			"child": 0,
+			"a":     unknownSCRDepth,
+			"b":     unknownSCRDepth,
+			"self":  unknownSCRDepth,
+		}
+		require.Equal(t, expectedDepths, depths)
+	})
+}
+
+func TestGetSCRDepth(t *testing.T) {
+	t.Parallel()
+
+	depths := map[string]int{
+		"child":      0,
+		"grandchild": 1,
+	}
+	require.Equal(t, 0, getSCRDepth(depths, "child"))
+	require.Equal(t, 1, getSCRDepth(depths, "grandchild"))
+	require.Equal(t, unknownSCRDepth, getSCRDepth(depths, "missing"))
+}
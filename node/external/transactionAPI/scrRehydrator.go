@@ -0,0 +1,104 @@
+package transactionAPI
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data/smartContractResult"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-go/process"
+)
+
+// RehydrateSmartContractResult reconstructs the smart contract result from its API representation, as built by
+// adaptSmartContractResult. The addresses are decoded using the provided pubkey converter and the hashes from hex.
+// The conversion is lossy in the following regards:
+//   - the empty byte fields (addresses, hashes, code, data, code metadata and return message) are restored as nil
+//   - the smart contract result's hash and the fields computed by the API (such as the operation, the function, the
+//     receivers or the refund flag) are not part of the smart contract result, so they are dropped
+func RehydrateSmartContractResult(
+	apiSCR *transaction.ApiSmartContractResult,
+	pubKeyConverter core.PubkeyConverter,
+) (*smartContractResult.SmartContractResult, error) {
+	if apiSCR == nil {
+		return nil, ErrNilSmartContractResult
+	}
+	if check.IfNil(pubKeyConverter) {
+		return nil, process.ErrNilPubkeyConverter
+	}
+
+	scr := &smartContractResult.SmartContractResult{
+		Nonce:         apiSCR.Nonce,
+		Value:         cloneBigInt(apiSCR.Value),
+		RelayedValue:  cloneBigInt(apiSCR.RelayedValue),
+		Code:          stringToBytes(apiSCR.Code),
+		Data:          stringToBytes(apiSCR.Data),
+		GasLimit:      apiSCR.GasLimit,
+		GasPrice:      apiSCR.GasPrice,
+		CallType:      apiSCR.CallType,
+		CodeMetadata:  stringToBytes(apiSCR.CodeMetadata),
+		ReturnMessage: stringToBytes(apiSCR.ReturnMessage),
+	}
+
+	var err error
+	scr.PrevTxHash, err = decodeHexField(apiSCR.PrevTxHash)
+	if err != nil {
+		return nil, fmt.Errorf("%w while decoding the previous transaction hash", err)
+	}
+	scr.OriginalTxHash, err = decodeHexField(apiSCR.OriginalTxHash)
+	if err != nil {
+		return nil, fmt.Errorf("%w while decoding the original transaction hash", err)
+	}
+	scr.SndAddr, err = decodeAddressField(pubKeyConverter, apiSCR.SndAddr)
+	if err != nil {
+		return nil, fmt.Errorf("%w while decoding the sender address", err)
+	}
+	scr.RcvAddr, err = decodeAddressField(pubKeyConverter, apiSCR.RcvAddr)
+	if err != nil {
+		return nil, fmt.Errorf("%w while decoding the receiver address", err)
+	}
+	scr.RelayerAddr, err = decodeAddressField(pubKeyConverter, apiSCR.RelayerAddr)
+	if err != nil {
+		return nil, fmt.Errorf("%w while decoding the relayer address", err)
+	}
+	scr.OriginalSender, err = decodeAddressField(pubKeyConverter, apiSCR.OriginalSender)
+	if err != nil {
+		return nil, fmt.Errorf("%w while decoding the original sender address", err)
+	}
+
+	return scr, nil
+}
+
+func cloneBigInt(value *big.Int) *big.Int {
+	if value == nil {
+		return nil
+	}
+
+	return big.NewInt(0).Set(value)
+}
+
+func stringToBytes(value string) []byte {
+	if len(value) == 0 {
+		return nil
+	}
+
+	return []byte(value)
+}
+
+func decodeHexField(value string) ([]byte, error) {
+	if len(value) == 0 {
+		return nil, nil
+	}
+
+	return hex.DecodeString(value)
+}
+
+func decodeAddressField(pubKeyConverter core.PubkeyConverter, address string) ([]byte, error) {
+	if len(address) == 0 {
+		return nil, nil
+	}
+
+	return pubKeyConverter.Decode(address)
+}
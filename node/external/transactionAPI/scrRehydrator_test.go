@@ -0,0 +1,121 @@
+package transactionAPI
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/data/smartContractResult"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-core-go/data/vm"
+	"github.com/multiversx/mx-chain-go/node/mock"
+	"github.com/multiversx/mx-chain-go/process"
+	"github.com/multiversx/mx-chain-go/testscommon"
+	dbLookupExtMock "github.com/multiversx/mx-chain-go/testscommon/dblookupext"
+	"github.com/multiversx/mx-chain-go/testscommon/enableEpochsHandlerMock"
+	storageStubs "github.com/multiversx/mx-chain-go/testscommon/storage"
+	datafield "github.com/multiversx/mx-chain-vm-common-go/parsers/dataField"
+	"github.com/stretchr/testify/require"
+)
+
+func createSmartContractResultForRehydration() *smartContractResult.SmartContractResult {
+	return &smartContractResult.SmartContractResult{
+		Nonce:          10,
+		Value:          big.NewInt(1000),
+		RcvAddr:        []byte("receiver"),
+		SndAddr:        []byte("sender"),
+		RelayerAddr:    []byte("relayer"),
+		RelayedValue:   big.NewInt(50),
+		Code:           []byte("code"),
+		Data:           []byte("function@01@02"),
+		PrevTxHash:     []byte("prevTxHash"),
+		OriginalTxHash: []byte("originalTxHash"),
+		GasLimit:       50000,
+		GasPrice:       1000000000,
+		CallType:       vm.AsynchronousCall,
+		CodeMetadata:   []byte("metadata"),
+		ReturnMessage:  []byte("return message"),
+		OriginalSender: []byte("originalSender"),
+	}
+}
+
+func TestRehydrateSmartContractResult(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil smart contract result should error", func(t *testing.T) {
+		t.Parallel()
+
+		scr, err := RehydrateSmartContractResult(nil, &testscommon.PubkeyConverterMock{})
+		require.Nil(t, scr)
+		require.Equal(t, ErrNilSmartContractResult, err)
+	})
+	t.Run("nil pubkey converter should error", func(t *testing.T) {
+		t.Parallel()
+
+		scr, err := RehydrateSmartContractResult(&transaction.ApiSmartContractResult{}, nil)
+		require.Nil(t, scr)
+		require.Equal(t, process.ErrNilPubkeyConverter, err)
+	})
+	t.Run("invalid hash should error", func(t *testing.T) {
+		t.Parallel()
+
+		scr, err := RehydrateSmartContractResult(&transaction.ApiSmartContractResult{PrevTxHash: "not hex"}, &testscommon.PubkeyConverterMock{})
+		require.Nil(t, scr)
+		require.NotNil(t, err)
+		require.Contains(t, err.Error(), "previous transaction hash")
+	})
+	t.Run("invalid address should error", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		pubKeyConverter := &testscommon.PubkeyConverterStub{
+			DecodeCalled: func(humanReadable string) ([]byte, error) {
+				return nil, expectedErr
+			},
+		}
+
+		scr, err := RehydrateSmartContractResult(&transaction.ApiSmartContractResult{SndAddr: "sender"}, pubKeyConverter)
+		require.Nil(t, scr)
+		require.ErrorIs(t, err, expectedErr)
+		require.Contains(t, err.Error(), "sender address")
+	})
+	t.Run("round trip should reconstruct the smart contract result", func(t *testing.T) {
+		t.Parallel()
+
+		pubKeyConverter := &testscommon.PubkeyConverterMock{}
+		n := newAPITransactionResultProcessor(
+			pubKeyConverter,
+			&dbLookupExtMock.HistoryRepositoryStub{},
+			&storageStubs.ChainStorerStub{},
+			&mock.MarshalizerFake{},
+			nil,
+			&testscommon.LogsFacadeStub{},
+			mock.NewOneShardCoordinatorMock(),
+			&testscommon.DataFieldParserStub{
+				ParseCalled: func(dataField []byte, sender, receiver []byte, numOfShards uint32) *datafield.ResponseParseData {
+					return &datafield.ResponseParseData{}
+				},
+			},
+			enableEpochsHandlerMock.NewEnableEpochsHandlerStub(),
+			NewRefundDetector(),
+			&mock.MarshalizerFake{},
+			0,
+		)
+
+		originalSCR := createSmartContractResultForRehydration()
+		apiSCR := n.adaptSmartContractResult([]byte("scrHash"), originalSCR)
+		require.Equal(t, hex.EncodeToString([]byte("scrHash")), apiSCR.Hash)
+
+		scr, err := RehydrateSmartContractResult(apiSCR, pubKeyConverter)
+		require.Nil(t, err)
+		require.Equal(t, originalSCR, scr)
+	})
+	t.Run("empty fields are restored as nil", func(t *testing.T) {
+		t.Parallel()
+
+		scr, err := RehydrateSmartContractResult(&transaction.ApiSmartContractResult{Nonce: 1}, &testscommon.PubkeyConverterMock{})
+		require.Nil(t, err)
+		require.Equal(t, &smartContractResult.SmartContractResult{Nonce: 1}, scr)
+	})
+}
@@ -0,0 +1,78 @@
+package transactionAPI
+
+import (
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-go/common"
+	"github.com/multiversx/mx-chain-go/node/filters"
+)
+
+// computeStatusSummary returns the status summary of the provided transaction. The transaction should already contain
+// the smart contract results and the logs (as assembled by putResultsInTransaction). The provided transaction is not altered
+func (arp *apiTransactionResultsProcessor) computeStatusSummary(tx *transaction.ApiTransactionResult) *common.TransactionStatusSummary {
+	summary := &common.TransactionStatusSummary{
+		NumSCRs: len(tx.SmartContractResults),
+	}
+
+	for _, scr := range tx.SmartContractResults {
+		value := ""
+		if scr.Value != nil {
+			value = scr.Value.String()
+		}
+
+		isRefund := arp.refundDetector.IsRefund(RefundDetectorInput{
+			Value:         value,
+			Data:          []byte(scr.Data),
+			ReturnMessage: scr.ReturnMessage,
+			GasLimit:      scr.GasLimit,
+		})
+		summary.HadRefund = summary.HadRefund || isRefund
+
+		if len(summary.FailedReason) == 0 && !isRefund {
+			summary.FailedReason = scr.ReturnMessage
+		}
+		if len(summary.FailedReason) == 0 {
+			summary.FailedReason = getErrorMessageFromLogs(scr.Logs)
+		}
+	}
+
+	errorMessageFromTxLogs := getErrorMessageFromLogs(tx.Logs)
+	if len(errorMessageFromTxLogs) > 0 {
+		summary.FailedReason = errorMessageFromTxLogs
+	}
+
+	// work on a copy, as the status filters alter the status of the provided transaction
+	txCopy := *tx
+	statusFilters := filters.NewStatusFilters(arp.shardCoordinator.SelfId())
+	statusFilters.SetStatusIfIsFailedESDTTransfer(&txCopy)
+
+	isFailedStatus := txCopy.Status == transaction.TxStatusFail || txCopy.Status == transaction.TxStatusInvalid
+	if isFailedStatus && len(summary.FailedReason) == 0 {
+		summary.FailedReason = string(txCopy.Status)
+	}
+
+	summary.Success = len(summary.FailedReason) == 0
+
+	return summary
+}
+
+func getErrorMessageFromLogs(logs *transaction.ApiLogs) string {
+	if logs == nil {
+		return ""
+	}
+
+	for _, event := range logs.Events {
+		if event == nil || event.Identifier != core.SignalErrorOperation {
+			continue
+		}
+
+		// the last topic of a signal error event holds the error message
+		if len(event.Topics) > 0 {
+			return string(event.Topics[len(event.Topics)-1])
+		}
+
+		return string(event.Data)
+	}
+
+	return ""
+}
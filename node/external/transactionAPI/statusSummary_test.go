@@ -0,0 +1,133 @@
+package transactionAPI
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-go/common"
+	"github.com/multiversx/mx-chain-go/node/mock"
+	"github.com/multiversx/mx-chain-go/testscommon"
+	dbLookupExtMock "github.com/multiversx/mx-chain-go/testscommon/dblookupext"
+	"github.com/multiversx/mx-chain-go/testscommon/enableEpochsHandlerMock"
+	storageStubs "github.com/multiversx/mx-chain-go/testscommon/storage"
+	"github.com/stretchr/testify/require"
+)
+
+func createResultsProcessorForStatusSummary() *apiTransactionResultsProcessor {
+	return newAPITransactionResultProcessor(
+		&testscommon.PubkeyConverterMock{},
+		&dbLookupExtMock.HistoryRepositoryStub{},
+		&storageStubs.ChainStorerStub{},
+		&mock.MarshalizerFake{},
+		nil,
+		&testscommon.LogsFacadeStub{},
+		mock.NewOneShardCoordinatorMock(),
+		&testscommon.DataFieldParserStub{},
+		enableEpochsHandlerMock.NewEnableEpochsHandlerStub(),
+		NewRefundDetector(),
+		&mock.MarshalizerFake{},
+		0,
+	)
+}
+
+func TestApiTransactionResultsProcessor_ComputeStatusSummary(t *testing.T) {
+	t.Parallel()
+
+	t.Run("successful transaction", func(t *testing.T) {
+		t.Parallel()
+
+		n := createResultsProcessorForStatusSummary()
+		tx := &transaction.ApiTransactionResult{
+			Status: transaction.TxStatusSuccess,
+			SmartContractResults: []*transaction.ApiSmartContractResult{
+				{Value: big.NewInt(0), Data: "@6f6b"},
+			},
+		}
+
+		summary := n.computeStatusSummary(tx)
+		require.Equal(t, &common.TransactionStatusSummary{
+			Success: true,
+			NumSCRs: 1,
+		}, summary)
+	})
+	t.Run("successful transaction with refund", func(t *testing.T) {
+		t.Parallel()
+
+		n := createResultsProcessorForStatusSummary()
+		tx := &transaction.ApiTransactionResult{
+			Status: transaction.TxStatusSuccess,
+			SmartContractResults: []*transaction.ApiSmartContractResult{
+				{Value: big.NewInt(0), Data: "@6f6b"},
+				{Value: big.NewInt(1000), Data: "@6f6b", ReturnMessage: "gas refund for relayer"},
+			},
+		}
+
+		summary := n.computeStatusSummary(tx)
+		require.Equal(t, &common.TransactionStatusSummary{
+			Success:   true,
+			HadRefund: true,
+			NumSCRs:   2,
+		}, summary)
+	})
+	t.Run("transaction failed in a smart contract result", func(t *testing.T) {
+		t.Parallel()
+
+		n := createResultsProcessorForStatusSummary()
+		tx := &transaction.ApiTransactionResult{
+			Status: transaction.TxStatusSuccess,
+			SmartContractResults: []*transaction.ApiSmartContractResult{
+				{Value: big.NewInt(0), Data: "@6f6b"},
+				{Value: big.NewInt(10), Data: "@75736572206572726f72", ReturnMessage: "insufficient funds"},
+			},
+		}
+
+		summary := n.computeStatusSummary(tx)
+		require.Equal(t, &common.TransactionStatusSummary{
+			FailedReason: "insufficient funds",
+			NumSCRs:      2,
+		}, summary)
+	})
+	t.Run("transaction failed with signal error in logs", func(t *testing.T) {
+		t.Parallel()
+
+		n := createResultsProcessorForStatusSummary()
+		tx := &transaction.ApiTransactionResult{
+			Status: transaction.TxStatusSuccess,
+			Logs: &transaction.ApiLogs{
+				Events: []*transaction.Events{
+					{Identifier: "transferValueOnly"},
+					{Identifier: core.SignalErrorOperation, Topics: [][]byte{[]byte("addr"), []byte("execution failed")}},
+				},
+			},
+		}
+
+		summary := n.computeStatusSummary(tx)
+		require.Equal(t, &common.TransactionStatusSummary{
+			FailedReason: "execution failed",
+		}, summary)
+	})
+	t.Run("failed ESDT transfer should not alter the transaction", func(t *testing.T) {
+		t.Parallel()
+
+		n := createResultsProcessorForStatusSummary()
+		tx := &transaction.ApiTransactionResult{
+			Status:           transaction.TxStatusSuccess,
+			Nonce:            5,
+			Data:             []byte("ESDTTransfer@54474e@01"),
+			SourceShard:      1,
+			DestinationShard: 0,
+			SmartContractResults: []*transaction.ApiSmartContractResult{
+				{Value: big.NewInt(0), Nonce: 5, Data: "ESDTTransfer@54474e@01@75736572206572726f72"},
+			},
+		}
+
+		summary := n.computeStatusSummary(tx)
+		require.Equal(t, &common.TransactionStatusSummary{
+			FailedReason: string(transaction.TxStatusFail),
+			NumSCRs:      1,
+		}, summary)
+		require.Equal(t, transaction.TxStatusSuccess, tx.Status)
+	})
+}
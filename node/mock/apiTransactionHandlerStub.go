@@ -16,6 +16,16 @@ type TransactionAPIHandlerStub struct {
 	UnmarshalReceiptCalled                      func(receiptBytes []byte) (*transaction.ApiReceipt, error)
 	PopulateComputedFieldsCalled                func(tx *transaction.ApiTransactionResult)
 	GetSCRsByTxHashCalled                       func(txHash string, scrHash string) ([]*transaction.ApiSmartContractResult, error)
+	GetTransactionDetailsCalled                 func(txHash string, originalSender string) (*common.ApiTransactionDetails, error)
+}
+
+// GetTransactionDetails -
+func (tas *TransactionAPIHandlerStub) GetTransactionDetails(txHash string, originalSender string) (*common.ApiTransactionDetails, error) {
+	if tas.GetTransactionDetailsCalled != nil {
+		return tas.GetTransactionDetailsCalled(txHash, originalSender)
+	}
+
+	return nil, nil
 }
 
 // GetSCRsByTxHash --
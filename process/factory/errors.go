@@ -0,0 +1,15 @@
+package factory
+
+import "errors"
+
+// ErrVMTypeLengthIsNotCorrect signals that the vm type length is not correct
+var ErrVMTypeLengthIsNotCorrect = errors.New("vm type length is not correct")
+
+// ErrUnknownVMType signals that an unknown vm type has been provided
+var ErrUnknownVMType = errors.New("unknown vm type")
+
+// ErrEmptyVMTypeName signals that an empty vm type name has been provided
+var ErrEmptyVMTypeName = errors.New("empty vm type name")
+
+// ErrDuplicatedVMType signals that the vm type is already registered
+var ErrDuplicatedVMType = errors.New("duplicated vm type")
@@ -0,0 +1,17 @@
+package factory
+
+import "bytes"
+
+// unregisterVMType removes the provided vm type from the known ones, so the tests registering vm types can be
+// repeated in the same process
+func unregisterVMType(id []byte) {
+	mutKnownVMTypes.Lock()
+	defer mutKnownVMTypes.Unlock()
+
+	for i, knownVMType := range knownVMTypes {
+		if bytes.Equal(knownVMType.id, id) {
+			knownVMTypes = append(knownVMTypes[:i:i], knownVMTypes[i+1:]...)
+			return
+		}
+	}
+}
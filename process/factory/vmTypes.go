@@ -0,0 +1,109 @@
+package factory
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+)
+
+type vmTypeInfo struct {
+	name string
+	id   []byte
+}
+
+var mutKnownVMTypes sync.RWMutex
+var knownVMTypes = []vmTypeInfo{
+	{name: "system", id: SystemVirtualMachine},
+	{name: "iele", id: IELEVirtualMachine},
+	{name: "wasm", id: WasmVirtualMachine},
+	{name: "internalTesting", id: InternalTestingVM},
+}
+
+// RegisterVMType registers an additional vm type, which will be reported as known by IsKnownVMType and CheckVMType.
+// It should be called at initialization, before any component uses the vm types. Both the name and the identifier
+// should be unique
+func RegisterVMType(name string, id []byte) error {
+	if len(name) == 0 {
+		return ErrEmptyVMTypeName
+	}
+	if len(id) != core.VMTypeLen {
+		return fmt.Errorf("%w, expected %d, got %d", ErrVMTypeLengthIsNotCorrect, core.VMTypeLen, len(id))
+	}
+
+	mutKnownVMTypes.Lock()
+	defer mutKnownVMTypes.Unlock()
+
+	for _, knownVMType := range knownVMTypes {
+		if bytes.Equal(knownVMType.id, id) {
+			return fmt.Errorf("%w: id %s already registered as %s", ErrDuplicatedVMType, hex.EncodeToString(id), knownVMType.name)
+		}
+		if knownVMType.name == name {
+			return fmt.Errorf("%w: name %s already registered with id %s", ErrDuplicatedVMType, name, hex.EncodeToString(knownVMType.id))
+		}
+	}
+
+	knownVMTypes = append(knownVMTypes, vmTypeInfo{
+		name: name,
+		id:   append([]byte{}, id...),
+	})
+
+	return nil
+}
+
+// AllVMTypes returns the identifiers of all the known vm types, including the registered ones
+func AllVMTypes() [][]byte {
+	mutKnownVMTypes.RLock()
+	defer mutKnownVMTypes.RUnlock()
+
+	vmTypes := make([][]byte, 0, len(knownVMTypes))
+	for _, knownVMType := range knownVMTypes {
+		vmTypes = append(vmTypes, append([]byte{}, knownVMType.id...))
+	}
+
+	return vmTypes
+}
+
+// VMTypeName returns the name of the provided vm type identifier or an empty string if the vm type is not known
+func VMTypeName(vmType []byte) string {
+	knownVMType, found := getKnownVMType(vmType)
+	if !found {
+		return ""
+	}
+
+	return knownVMType.name
+}
+
+// IsKnownVMType returns true if the provided bytes represent one of the known vm type identifiers
+func IsKnownVMType(vmType []byte) bool {
+	return CheckVMType(vmType) == nil
+}
+
+// CheckVMType returns an error if the provided bytes do not represent a valid & known vm type identifier
+func CheckVMType(vmType []byte) error {
+	if len(vmType) != core.VMTypeLen {
+		return fmt.Errorf("%w, expected %d, got %d", ErrVMTypeLengthIsNotCorrect, core.VMTypeLen, len(vmType))
+	}
+
+	_, found := getKnownVMType(vmType)
+	if !found {
+		return fmt.Errorf("%w: %s", ErrUnknownVMType, hex.EncodeToString(vmType))
+	}
+
+	return nil
+}
+
+func getKnownVMType(vmType []byte) (vmTypeInfo, bool) {
+	mutKnownVMTypes.RLock()
+	defer mutKnownVMTypes.RUnlock()
+
+	for _, knownVMType := range knownVMTypes {
+		if bytes.Equal(knownVMType.id, vmType) {
+			return knownVMType, true
+		}
+	}
+
+	return vmTypeInfo{}, false
+}
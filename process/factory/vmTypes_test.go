@@ -0,0 +1,144 @@
+package factory
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-go/testscommon/scAddressMocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsKnownVMType(t *testing.T) {
+	t.Parallel()
+
+	t.Run("known vm types should return true", func(t *testing.T) {
+		t.Parallel()
+
+		assert.True(t, IsKnownVMType(SystemVirtualMachine))
+		assert.True(t, IsKnownVMType(IELEVirtualMachine))
+		assert.True(t, IsKnownVMType(WasmVirtualMachine))
+		assert.True(t, IsKnownVMType(InternalTestingVM))
+	})
+	t.Run("unknown vm type should return false", func(t *testing.T) {
+		t.Parallel()
+
+		assert.False(t, IsKnownVMType([]byte{7, 7}))
+	})
+	t.Run("wrong length should return false", func(t *testing.T) {
+		t.Parallel()
+
+		assert.False(t, IsKnownVMType(nil))
+		assert.False(t, IsKnownVMType([]byte{5}))
+		assert.False(t, IsKnownVMType([]byte{5, 0, 0}))
+	})
+}
+
+func TestIsKnownVMType_MockSCAddresses(t *testing.T) {
+	t.Parallel()
+
+	builtInVMTypes := [][]byte{SystemVirtualMachine, IELEVirtualMachine, WasmVirtualMachine, InternalTestingVM}
+	for _, vmType := range builtInVMTypes {
+		address, err := scAddressMocks.GenerateMockSCAddress(vmType, 1)
+		require.Nil(t, err)
+
+		extractedVMType := address[core.NumInitCharactersForScAddress-core.VMTypeLen : core.NumInitCharactersForScAddress]
+		assert.Equal(t, vmType, extractedVMType)
+		assert.True(t, IsKnownVMType(extractedVMType))
+	}
+
+	address, err := scAddressMocks.GenerateMockSCAddress([]byte{7, 7}, 0)
+	require.Nil(t, err)
+
+	extractedVMType := address[core.NumInitCharactersForScAddress-core.VMTypeLen : core.NumInitCharactersForScAddress]
+	assert.False(t, IsKnownVMType(extractedVMType))
+}
+
+func TestCheckVMType(t *testing.T) {
+	t.Parallel()
+
+	t.Run("known vm type should work", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Nil(t, CheckVMType([]byte{5, 0}))
+	})
+	t.Run("wrong length should error", func(t *testing.T) {
+		t.Parallel()
+
+		err := CheckVMType([]byte{5, 0, 1})
+		assert.True(t, errors.Is(err, ErrVMTypeLengthIsNotCorrect))
+	})
+	t.Run("unknown vm type should error", func(t *testing.T) {
+		t.Parallel()
+
+		err := CheckVMType([]byte{1, 1})
+		assert.True(t, errors.Is(err, ErrUnknownVMType))
+		assert.Contains(t, err.Error(), "0101")
+	})
+}
+
+func TestRegisterVMType(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty name should error", func(t *testing.T) {
+		t.Parallel()
+
+		err := RegisterVMType("", []byte{9, 1})
+		assert.Equal(t, ErrEmptyVMTypeName, err)
+		assert.False(t, IsKnownVMType([]byte{9, 1}))
+	})
+	t.Run("wrong length should error", func(t *testing.T) {
+		t.Parallel()
+
+		err := RegisterVMType("wrongLengthVM", []byte{9, 2, 0})
+		assert.True(t, errors.Is(err, ErrVMTypeLengthIsNotCorrect))
+
+		err = RegisterVMType("wrongLengthVM", []byte{9})
+		assert.True(t, errors.Is(err, ErrVMTypeLengthIsNotCorrect))
+		assert.Empty(t, VMTypeName([]byte{9}))
+	})
+	t.Run("duplicated id should error", func(t *testing.T) {
+		t.Parallel()
+
+		err := RegisterVMType("anotherWasm", WasmVirtualMachine)
+		assert.True(t, errors.Is(err, ErrDuplicatedVMType))
+		assert.Equal(t, "wasm", VMTypeName(WasmVirtualMachine))
+	})
+	t.Run("duplicated name should error", func(t *testing.T) {
+		t.Parallel()
+
+		err := RegisterVMType("wasm", []byte{9, 3})
+		assert.True(t, errors.Is(err, ErrDuplicatedVMType))
+		assert.False(t, IsKnownVMType([]byte{9, 3}))
+	})
+	t.Run("should register", func(t *testing.T) {
+		t.Parallel()
+
+		customVMType := []byte{9, 4}
+		assert.False(t, IsKnownVMType(customVMType))
+
+		err := RegisterVMType("customVM", customVMType)
+		assert.Nil(t, err)
+		t.Cleanup(func() {
+			unregisterVMType(customVMType)
+		})
+		assert.True(t, IsKnownVMType(customVMType))
+		assert.Nil(t, CheckVMType(customVMType))
+		assert.Equal(t, "customVM", VMTypeName(customVMType))
+		assert.Contains(t, AllVMTypes(), customVMType)
+
+		err = RegisterVMType("customVM2", customVMType)
+		assert.True(t, errors.Is(err, ErrDuplicatedVMType))
+	})
+}
+
+func TestVMTypeName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "system", VMTypeName(SystemVirtualMachine))
+	assert.Equal(t, "iele", VMTypeName(IELEVirtualMachine))
+	assert.Equal(t, "wasm", VMTypeName(WasmVirtualMachine))
+	assert.Equal(t, "internalTesting", VMTypeName(InternalTestingVM))
+	assert.Empty(t, VMTypeName([]byte{7, 7}))
+}
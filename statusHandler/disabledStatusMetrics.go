@@ -0,0 +1,89 @@
+package statusHandler
+
+// disabledStatusMetrics is a no-op implementation of the status metrics component. It can be used when the metrics
+// collection is not required, without the need of nil checks on the caller side
+type disabledStatusMetrics struct {
+}
+
+// NewDisabledStatusMetrics will return an instance of the disabled status metrics
+func NewDisabledStatusMetrics() *disabledStatusMetrics {
+	return &disabledStatusMetrics{}
+}
+
+// Increment method - won't do anything
+func (dsm *disabledStatusMetrics) Increment(_ string) {
+}
+
+// AddUint64 method - won't do anything
+func (dsm *disabledStatusMetrics) AddUint64(_ string, _ uint64) {
+}
+
+// Decrement method - won't do anything
+func (dsm *disabledStatusMetrics) Decrement(_ string) {
+}
+
+// SetInt64Value method - won't do anything
+func (dsm *disabledStatusMetrics) SetInt64Value(_ string, _ int64) {
+}
+
+// SetUInt64Value method - won't do anything
+func (dsm *disabledStatusMetrics) SetUInt64Value(_ string, _ uint64) {
+}
+
+// SetStringValue method - won't do anything
+func (dsm *disabledStatusMetrics) SetStringValue(_ string, _ string) {
+}
+
+// Close method - won't do anything
+func (dsm *disabledStatusMetrics) Close() {
+}
+
+// StatusMetricsMapWithoutP2P returns an empty map
+func (dsm *disabledStatusMetrics) StatusMetricsMapWithoutP2P() (map[string]interface{}, error) {
+	return make(map[string]interface{}), nil
+}
+
+// StatusP2pMetricsMap returns an empty map
+func (dsm *disabledStatusMetrics) StatusP2pMetricsMap() (map[string]interface{}, error) {
+	return make(map[string]interface{}), nil
+}
+
+// StatusMetricsWithoutP2PPrometheusString returns an empty string
+func (dsm *disabledStatusMetrics) StatusMetricsWithoutP2PPrometheusString() (string, error) {
+	return "", nil
+}
+
+// EconomicsMetrics returns an empty map
+func (dsm *disabledStatusMetrics) EconomicsMetrics() (map[string]interface{}, error) {
+	return make(map[string]interface{}), nil
+}
+
+// ConfigMetrics returns an empty map
+func (dsm *disabledStatusMetrics) ConfigMetrics() (map[string]interface{}, error) {
+	return make(map[string]interface{}), nil
+}
+
+// EnableEpochsMetrics returns an empty map
+func (dsm *disabledStatusMetrics) EnableEpochsMetrics() (map[string]interface{}, error) {
+	return make(map[string]interface{}), nil
+}
+
+// NetworkMetrics returns an empty map
+func (dsm *disabledStatusMetrics) NetworkMetrics() (map[string]interface{}, error) {
+	return make(map[string]interface{}), nil
+}
+
+// RatingsMetrics returns an empty map
+func (dsm *disabledStatusMetrics) RatingsMetrics() (map[string]interface{}, error) {
+	return make(map[string]interface{}), nil
+}
+
+// BootstrapMetrics returns an empty map
+func (dsm *disabledStatusMetrics) BootstrapMetrics() (map[string]interface{}, error) {
+	return make(map[string]interface{}), nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (dsm *disabledStatusMetrics) IsInterfaceNil() bool {
+	return dsm == nil
+}
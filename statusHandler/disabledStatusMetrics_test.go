@@ -0,0 +1,56 @@
+package statusHandler_test
+
+import (
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-go/statusHandler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDisabledStatusMetrics(t *testing.T) {
+	t.Parallel()
+
+	dsm := statusHandler.NewDisabledStatusMetrics()
+	assert.False(t, check.IfNil(dsm))
+
+	var appStatusHandler core.AppStatusHandler = dsm
+	assert.NotNil(t, appStatusHandler)
+}
+
+func TestDisabledStatusMetrics_ShouldNotStoreAnything(t *testing.T) {
+	t.Parallel()
+
+	dsm := statusHandler.NewDisabledStatusMetrics()
+	dsm.SetUInt64Value("uint64-key", 10)
+	dsm.SetInt64Value("int64-key", -10)
+	dsm.SetStringValue("string-key", "value")
+	dsm.SetUInt64Value("key_p2p_", 10)
+	dsm.Increment("uint64-key")
+	dsm.AddUint64("uint64-key", 5)
+	dsm.Decrement("uint64-key")
+	dsm.Close()
+
+	getters := []func() (map[string]interface{}, error){
+		dsm.StatusMetricsMapWithoutP2P,
+		dsm.StatusP2pMetricsMap,
+		dsm.EconomicsMetrics,
+		dsm.ConfigMetrics,
+		dsm.EnableEpochsMetrics,
+		dsm.NetworkMetrics,
+		dsm.RatingsMetrics,
+		dsm.BootstrapMetrics,
+	}
+	for _, getter := range getters {
+		metrics, err := getter()
+		require.Nil(t, err)
+		require.NotNil(t, metrics)
+		assert.Empty(t, metrics)
+	}
+
+	prometheusString, err := dsm.StatusMetricsWithoutP2PPrometheusString()
+	assert.Nil(t, err)
+	assert.Empty(t, prometheusString)
+}
@@ -19,3 +19,8 @@ var ErrNilUint64Converter = errors.New("uint64converter is nil")
 
 // ErrNilStorage signals that a nil storage has been provided
 var ErrNilStorage = errors.New("nil storage")
+
+// ErrInvalidMetricsSnapshot signals that the provided binary metrics snapshot is not valid
+var ErrInvalidMetricsSnapshot = errors.New("invalid metrics snapshot")
+
+var errUnexpectedSnapshotEnd = errors.New("unexpected end of data")
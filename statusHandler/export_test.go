@@ -1,8 +1,25 @@
 package statusHandler
 
+import "time"
+
 // StatusMetricsMap will return all metrics in a map
 func (sm *statusMetrics) StatusMetricsMap() map[string]interface{} {
 	return sm.getMetricsWithKeyFilterMutexProtected(func(_ string) bool {
 		return true
 	})
 }
+
+// SetTimeHandler sets the time handler used for the metrics write moments
+func (sm *statusMetrics) SetTimeHandler(handler func() time.Time) {
+	sm.getTimeHandler = handler
+}
+
+// NumThresholdWatchers returns the number of registered threshold watchers
+func (sm *statusMetrics) NumThresholdWatchers() int64 {
+	return sm.numThresholdWatchers.Get()
+}
+
+// MetricValueType returns the type name reported by MetricsTyped for the provided value
+func MetricValueType(value interface{}) string {
+	return metricValueType(value)
+}
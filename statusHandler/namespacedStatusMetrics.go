@@ -0,0 +1,55 @@
+package statusHandler
+
+// namespacedStatusMetrics is a lightweight view over a statusMetrics instance which prepends a prefix to all the
+// keys it writes. The values are stored in, and read from, the shared statusMetrics instance
+type namespacedStatusMetrics struct {
+	prefix        string
+	statusMetrics *statusMetrics
+}
+
+// WithNamespace returns a view of the status metrics which will prepend the provided prefix to all the written keys
+func (sm *statusMetrics) WithNamespace(prefix string) *namespacedStatusMetrics {
+	return &namespacedStatusMetrics{
+		prefix:        prefix,
+		statusMetrics: sm,
+	}
+}
+
+// Increment method increment a metric
+func (nsm *namespacedStatusMetrics) Increment(key string) {
+	nsm.statusMetrics.Increment(nsm.prefix + key)
+}
+
+// AddUint64 method increase a metric with a specific value
+func (nsm *namespacedStatusMetrics) AddUint64(key string, val uint64) {
+	nsm.statusMetrics.AddUint64(nsm.prefix+key, val)
+}
+
+// Decrement method - decrement a metric
+func (nsm *namespacedStatusMetrics) Decrement(key string) {
+	nsm.statusMetrics.Decrement(nsm.prefix + key)
+}
+
+// SetInt64Value method - sets an int64 value for a key
+func (nsm *namespacedStatusMetrics) SetInt64Value(key string, value int64) {
+	nsm.statusMetrics.SetInt64Value(nsm.prefix+key, value)
+}
+
+// SetUInt64Value method - sets an uint64 value for a key
+func (nsm *namespacedStatusMetrics) SetUInt64Value(key string, value uint64) {
+	nsm.statusMetrics.SetUInt64Value(nsm.prefix+key, value)
+}
+
+// SetStringValue method - sets a string value for a key
+func (nsm *namespacedStatusMetrics) SetStringValue(key string, value string) {
+	nsm.statusMetrics.SetStringValue(nsm.prefix+key, value)
+}
+
+// Close method - won't do anything, the shared status metrics instance is not owned by the view
+func (nsm *namespacedStatusMetrics) Close() {
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (nsm *namespacedStatusMetrics) IsInterfaceNil() bool {
+	return nsm == nil
+}
@@ -0,0 +1,36 @@
+package statusHandler_test
+
+import (
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-go/statusHandler"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusMetrics_WithNamespace(t *testing.T) {
+	t.Parallel()
+
+	sm := statusHandler.NewStatusMetrics()
+	consensusMetrics := sm.WithNamespace("consensus.")
+	syncMetrics := sm.WithNamespace("sync.")
+	assert.False(t, check.IfNil(consensusMetrics))
+
+	consensusMetrics.SetUInt64Value("rounds", 10)
+	consensusMetrics.Increment("rounds")
+	syncMetrics.SetUInt64Value("rounds", 20)
+	syncMetrics.AddUint64("rounds", 5)
+	syncMetrics.Decrement("rounds")
+	consensusMetrics.SetInt64Value("delta", -3)
+	consensusMetrics.SetStringValue("state", "ok")
+	consensusMetrics.Close()
+
+	retMap := sm.StatusMetricsMap()
+	assert.Equal(t, uint64(11), retMap["consensus.rounds"])
+	assert.Equal(t, uint64(24), retMap["sync.rounds"])
+	assert.Equal(t, int64(-3), retMap["consensus.delta"])
+	assert.Equal(t, "ok", retMap["consensus.state"])
+	assert.Nil(t, retMap["rounds"])
+	assert.Nil(t, retMap["delta"])
+	assert.Nil(t, retMap["state"])
+}
@@ -0,0 +1,65 @@
+package statusHandler
+
+// SnapshotKeyStatus is used in the result of DiffSnapshots for the keys that can not be compared numerically
+type SnapshotKeyStatus string
+
+const (
+	// SnapshotKeyAdded marks a key present only in the new snapshot
+	SnapshotKeyAdded SnapshotKeyStatus = "added"
+	// SnapshotKeyRemoved marks a key present only in the old snapshot
+	SnapshotKeyRemoved SnapshotKeyStatus = "removed"
+	// SnapshotKeyTypeMismatch marks a key holding values of different types in the two snapshots
+	SnapshotKeyTypeMismatch SnapshotKeyStatus = "type mismatch"
+)
+
+// DiffSnapshots compares two metrics snapshots, as returned by StatusMetricsMap. For each numeric key present in both
+// snapshots, the result holds the difference between the new and the old value, as an int64. The keys present in only
+// one of the snapshots and the keys whose values changed their type are marked with a SnapshotKeyStatus. The non-numeric
+// keys present in both snapshots with the same type are not part of the result
+func DiffSnapshots(oldSnapshot map[string]interface{}, newSnapshot map[string]interface{}) map[string]interface{} {
+	diff := make(map[string]interface{})
+
+	for key, newValue := range newSnapshot {
+		oldValue, found := oldSnapshot[key]
+		if !found {
+			diff[key] = SnapshotKeyAdded
+			continue
+		}
+
+		oldNumeric, isOldNumeric := metricValueToInt64(oldValue)
+		newNumeric, isNewNumeric := metricValueToInt64(newValue)
+		if isOldNumeric && isNewNumeric {
+			diff[key] = newNumeric - oldNumeric
+			continue
+		}
+		if isOldNumeric != isNewNumeric {
+			diff[key] = SnapshotKeyTypeMismatch
+		}
+	}
+
+	for key := range oldSnapshot {
+		_, found := newSnapshot[key]
+		if !found {
+			diff[key] = SnapshotKeyRemoved
+		}
+	}
+
+	return diff
+}
+
+func metricValueToInt64(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case uint64:
+		return int64(v), true
+	case int64:
+		return v, true
+	case uint32:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int:
+		return int64(v), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,82 @@
+package statusHandler_test
+
+import (
+	"testing"
+
+	"github.com/multiversx/mx-chain-go/common"
+	"github.com/multiversx/mx-chain-go/statusHandler"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffSnapshots(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty snapshots should return empty diff", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Empty(t, statusHandler.DiffSnapshots(nil, nil))
+		assert.Empty(t, statusHandler.DiffSnapshots(map[string]interface{}{}, map[string]interface{}{}))
+	})
+	t.Run("added, removed and changed keys", func(t *testing.T) {
+		t.Parallel()
+
+		oldSnapshot := map[string]interface{}{
+			common.MetricNonce:        uint64(100),
+			common.MetricCurrentRound: uint64(110),
+			common.MetricEpochNumber:  uint64(5),
+			common.MetricNodeType:     "validator",
+			common.MetricAppVersion:   "v1.0.0",
+			"erd_removed_metric":      uint64(7),
+			"erd_int64_metric":        int64(-10),
+		}
+		newSnapshot := map[string]interface{}{
+			common.MetricNonce:        uint64(150),
+			common.MetricCurrentRound: uint64(160),
+			common.MetricEpochNumber:  uint64(5),
+			common.MetricNodeType:     "observer",
+			common.MetricAppVersion:   "v1.0.0",
+			"erd_added_metric":        uint64(3),
+			"erd_int64_metric":        int64(-30),
+		}
+
+		diff := statusHandler.DiffSnapshots(oldSnapshot, newSnapshot)
+		assert.Equal(t, map[string]interface{}{
+			common.MetricNonce:        int64(50),
+			common.MetricCurrentRound: int64(50),
+			common.MetricEpochNumber:  int64(0),
+			"erd_removed_metric":      statusHandler.SnapshotKeyRemoved,
+			"erd_added_metric":        statusHandler.SnapshotKeyAdded,
+			"erd_int64_metric":        int64(-20),
+		}, diff)
+	})
+	t.Run("decreasing counter should return negative difference", func(t *testing.T) {
+		t.Parallel()
+
+		oldSnapshot := map[string]interface{}{common.MetricNumConnectedPeers: uint64(20)}
+		newSnapshot := map[string]interface{}{common.MetricNumConnectedPeers: uint64(15)}
+
+		diff := statusHandler.DiffSnapshots(oldSnapshot, newSnapshot)
+		assert.Equal(t, map[string]interface{}{common.MetricNumConnectedPeers: int64(-5)}, diff)
+	})
+	t.Run("type mismatch should be marked", func(t *testing.T) {
+		t.Parallel()
+
+		oldSnapshot := map[string]interface{}{
+			common.MetricNonce:    uint64(100),
+			common.MetricNodeType: "validator",
+			"erd_mixed_metric":    int64(10),
+		}
+		newSnapshot := map[string]interface{}{
+			common.MetricNonce:    "100",
+			common.MetricNodeType: uint64(1),
+			"erd_mixed_metric":    uint64(15),
+		}
+
+		diff := statusHandler.DiffSnapshots(oldSnapshot, newSnapshot)
+		assert.Equal(t, map[string]interface{}{
+			common.MetricNonce:    statusHandler.SnapshotKeyTypeMismatch,
+			common.MetricNodeType: statusHandler.SnapshotKeyTypeMismatch,
+			"erd_mixed_metric":    int64(5),
+		}, diff)
+	})
+}
@@ -0,0 +1,158 @@
+package statusHandler
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const auditTimestampFormat = time.RFC3339Nano
+
+type auditEntry struct {
+	timestamp time.Time
+	key       string
+	oldValue  interface{}
+	newValue  interface{}
+}
+
+// metricsAuditor writes the audit entries on the sink from a background go routine, so the metrics setters are
+// never blocked by a slow sink
+type metricsAuditor struct {
+	sink      io.Writer
+	entries   chan auditEntry
+	closeOnce sync.Once
+	closeChan chan struct{}
+	doneChan  chan struct{}
+}
+
+// SetAuditSink starts recording each metric change as a timestamped line on the provided sink, holding the key, the old
+// and the new value. The values are quoted, so string values holding spaces or new lines can not corrupt the line, and
+// a missing old value is written unquoted as -. The lines are buffered and written from a background go routine: when the buffer is full, the
+// change is dropped and counted (see NumDroppedAuditEntries). A previously set sink is stopped and a nil sink disables
+// the auditing. The returned function stops the auditing after all the buffered lines are written on the sink
+func (sm *statusMetrics) SetAuditSink(sink io.Writer, buffer int) func() {
+	if buffer < 0 {
+		buffer = 0
+	}
+
+	var auditor *metricsAuditor
+	if sink != nil {
+		auditor = &metricsAuditor{
+			sink:      sink,
+			entries:   make(chan auditEntry, buffer),
+			closeChan: make(chan struct{}),
+			doneChan:  make(chan struct{}),
+		}
+		go auditor.processEntries()
+	}
+
+	sm.mutAudit.Lock()
+	previousAuditor := sm.auditor
+	sm.auditor = auditor
+	sm.mutAudit.Unlock()
+
+	sm.stopAuditor(previousAuditor)
+
+	return func() {
+		sm.mutAudit.Lock()
+		if sm.auditor == auditor {
+			sm.auditor = nil
+		}
+		sm.mutAudit.Unlock()
+
+		sm.stopAuditor(auditor)
+	}
+}
+
+// NumDroppedAuditEntries returns the number of metric changes that were not recorded because of a slow audit sink
+func (sm *statusMetrics) NumDroppedAuditEntries() uint64 {
+	return sm.numDroppedAuditEntries.GetUint64()
+}
+
+// auditChange records the change of the provided metric. A nil old value means the metric did not exist before
+func (sm *statusMetrics) auditChange(key string, oldValue interface{}, newValue interface{}) {
+	sm.mutAudit.RLock()
+	defer sm.mutAudit.RUnlock()
+
+	if sm.auditor == nil {
+		return
+	}
+
+	entry := auditEntry{
+		timestamp: sm.getTimeHandler(),
+		key:       key,
+		oldValue:  oldValue,
+		newValue:  newValue,
+	}
+	select {
+	case sm.auditor.entries <- entry:
+	default:
+		sm.numDroppedAuditEntries.Increment()
+	}
+}
+
+func (sm *statusMetrics) stopAuditor(auditor *metricsAuditor) {
+	if auditor == nil {
+		return
+	}
+
+	auditor.closeOnce.Do(func() {
+		close(auditor.closeChan)
+	})
+	<-auditor.doneChan
+}
+
+func (auditor *metricsAuditor) processEntries() {
+	defer close(auditor.doneChan)
+
+	for {
+		select {
+		case entry := <-auditor.entries:
+			auditor.write(entry)
+		case <-auditor.closeChan:
+			auditor.flush()
+			return
+		}
+	}
+}
+
+// flush writes the entries still buffered. No new entries can be added, as the auditor is no longer set on the
+// status metrics when the flush is performed
+func (auditor *metricsAuditor) flush() {
+	for {
+		select {
+		case entry := <-auditor.entries:
+			auditor.write(entry)
+		default:
+			return
+		}
+	}
+}
+
+func (auditor *metricsAuditor) write(entry auditEntry) {
+	oldValue := "-"
+	if entry.oldValue != nil {
+		oldValue = strconv.Quote(fmt.Sprint(entry.oldValue))
+	}
+
+	_, err := fmt.Fprintf(auditor.sink, "%s key=%s old=%s new=%q\n",
+		entry.timestamp.Format(auditTimestampFormat),
+		entry.key,
+		oldValue,
+		fmt.Sprint(entry.newValue),
+	)
+	if err != nil {
+		log.Debug("statusMetrics: can not write the audit entry", "key", entry.key, "error", err)
+	}
+}
+
+// existingValueOrNil returns the provided value if the metric existed, nil otherwise
+func existingValueOrNil(value interface{}, exists bool) interface{} {
+	if !exists {
+		return nil
+	}
+
+	return value
+}
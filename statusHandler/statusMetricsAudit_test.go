@@ -0,0 +1,105 @@
+package statusHandler_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/multiversx/mx-chain-go/common"
+	"github.com/multiversx/mx-chain-go/statusHandler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusMetrics_SetAuditSink(t *testing.T) {
+	t.Parallel()
+
+	t.Run("changes should be recorded as audit lines", func(t *testing.T) {
+		t.Parallel()
+
+		sm := statusHandler.NewStatusMetrics()
+		sink := &bytes.Buffer{}
+		stop := sm.SetAuditSink(sink, 10)
+
+		sm.SetUInt64Value(common.MetricNonce, 37)
+		sm.Increment(common.MetricNonce)
+		sm.SetStringValue(common.MetricNodeType, "validator")
+		sm.SetInt64Value(common.MetricEpochNumber, -1)
+		sm.SetStringValue(common.MetricNodeType, "new=\"x\"\nold")
+		sm.Increment("missing key")
+		stop()
+
+		lines := strings.Split(strings.TrimSuffix(sink.String(), "\n"), "\n")
+		expectedSuffixes := []string{
+			" key=" + common.MetricNonce + ` old=- new="37"`,
+			" key=" + common.MetricNonce + ` old="37" new="38"`,
+			" key=" + common.MetricNodeType + ` old=- new="validator"`,
+			" key=" + common.MetricEpochNumber + ` old=- new="-1"`,
+			" key=" + common.MetricNodeType + ` old="validator" new="new=\"x\"\nold"`,
+		}
+		require.Len(t, lines, len(expectedSuffixes))
+		for i, expectedSuffix := range expectedSuffixes {
+			assert.True(t, strings.HasSuffix(lines[i], expectedSuffix), lines[i])
+		}
+		assert.Zero(t, sm.NumDroppedAuditEntries())
+
+		// no more lines after stop
+		sm.SetUInt64Value(common.MetricNonce, 40)
+		assert.Len(t, strings.Split(strings.TrimSuffix(sink.String(), "\n"), "\n"), len(expectedSuffixes))
+	})
+	t.Run("set multi should record each change", func(t *testing.T) {
+		t.Parallel()
+
+		sm := statusHandler.NewStatusMetrics()
+		sm.SetUInt64Value(common.MetricNonce, 37)
+
+		sink := &bytes.Buffer{}
+		stop := sm.SetAuditSink(sink, 10)
+		sm.SetMulti(map[string]interface{}{
+			common.MetricNonce:    uint64(38),
+			common.MetricNodeType: "observer",
+		})
+		stop()
+
+		assert.Contains(t, sink.String(), " key="+common.MetricNonce+` old="37" new="38"`+"\n")
+		assert.Contains(t, sink.String(), " key="+common.MetricNodeType+` old=- new="observer"`+"\n")
+	})
+	t.Run("nil sink should be a no-op", func(t *testing.T) {
+		t.Parallel()
+
+		sm := statusHandler.NewStatusMetrics()
+		stop := sm.SetAuditSink(nil, 10)
+
+		sm.SetUInt64Value(common.MetricNonce, 37)
+		sm.Increment(common.MetricNonce)
+		stop()
+
+		assert.Zero(t, sm.NumDroppedAuditEntries())
+		assert.Equal(t, uint64(38), sm.GetUint64OrDefault(common.MetricNonce, 0))
+	})
+	t.Run("nil sink should disable a previously set sink", func(t *testing.T) {
+		t.Parallel()
+
+		sm := statusHandler.NewStatusMetrics()
+		sink := &bytes.Buffer{}
+		_ = sm.SetAuditSink(sink, 10)
+		sm.SetUInt64Value(common.MetricNonce, 37)
+
+		stop := sm.SetAuditSink(nil, 10)
+		sm.SetUInt64Value(common.MetricNonce, 38)
+		stop()
+
+		assert.Equal(t, 1, strings.Count(sink.String(), "\n"))
+	})
+	t.Run("close should flush the buffered lines", func(t *testing.T) {
+		t.Parallel()
+
+		sm := statusHandler.NewStatusMetrics()
+		sink := &bytes.Buffer{}
+		_ = sm.SetAuditSink(sink, 10)
+		sm.SetUInt64Value(common.MetricNonce, 37)
+		sm.Close()
+
+		assert.Equal(t, 1, strings.Count(sink.String(), "\n"))
+	})
+}
@@ -0,0 +1,230 @@
+package statusHandler
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sort"
+)
+
+// metricsSnapshotVersion is the first byte of the binary metrics snapshot, allowing future changes of the encoding
+const metricsSnapshotVersion = byte(1)
+
+// type tags of the values encoded in the binary metrics snapshot
+const (
+	metricTagUint64 = byte(1)
+	metricTagInt64  = byte(2)
+	metricTagString = byte(3)
+)
+
+// MarshalBinary returns a compact binary snapshot of all the stored metrics. After a version byte and the number of
+// metrics, each metric is encoded as the length prefixed key, a type tag and the value: a varint for the numeric
+// values or a length prefixed string. The metrics are sorted by key, so the same metrics produce the same snapshot
+func (sm *statusMetrics) MarshalBinary() ([]byte, error) {
+	uint64Metrics, int64Metrics, stringMetrics := sm.copyMetrics()
+
+	buff := []byte{metricsSnapshotVersion}
+	buff = binary.AppendUvarint(buff, uint64(len(uint64Metrics)+len(int64Metrics)+len(stringMetrics)))
+
+	keys := make([]string, 0, len(uint64Metrics))
+	for key := range uint64Metrics {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		buff = appendSnapshotKey(buff, key, metricTagUint64)
+		buff = binary.AppendUvarint(buff, uint64Metrics[key])
+	}
+
+	keys = make([]string, 0, len(int64Metrics))
+	for key := range int64Metrics {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		buff = appendSnapshotKey(buff, key, metricTagInt64)
+		buff = binary.AppendVarint(buff, int64Metrics[key])
+	}
+
+	keys = make([]string, 0, len(stringMetrics))
+	for key := range stringMetrics {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		buff = appendSnapshotKey(buff, key, metricTagString)
+		buff = appendSnapshotString(buff, stringMetrics[key])
+	}
+
+	return buff, nil
+}
+
+// UnmarshalBinary replaces all the stored metrics with the ones from the provided snapshot, created by MarshalBinary.
+// The stored metrics are left unchanged if the snapshot is not valid. The limit set by SetMaxNumMetrics is not applied
+// on the snapshot metrics. As for Reset, the metrics streams, thresholds
+// and audit are not notified about the replaced values and the metrics versions and write moments are cleared
+func (sm *statusMetrics) UnmarshalBinary(data []byte) error {
+	decoder := &snapshotDecoder{data: data}
+	uint64Metrics, int64Metrics, stringMetrics, err := decoder.decodeMetrics()
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidMetricsSnapshot, err.Error())
+	}
+
+	sm.mutUint64Operations.Lock()
+	sm.mutStringOperations.Lock()
+	sm.mutInt64Operations.Lock()
+	sm.uint64Metrics = uint64Metrics
+	sm.stringMetrics = stringMetrics
+	sm.int64Metrics = int64Metrics
+	sm.resetMetricWriteInfos()
+	sm.setNumMetrics(uint32(len(uint64Metrics) + len(int64Metrics) + len(stringMetrics)))
+	sm.mutInt64Operations.Unlock()
+	sm.mutStringOperations.Unlock()
+	sm.mutUint64Operations.Unlock()
+
+	sm.markUpdated()
+
+	return nil
+}
+
+func (sm *statusMetrics) copyMetrics() (map[string]uint64, map[string]int64, map[string]string) {
+	sm.mutUint64Operations.RLock()
+	uint64Metrics := make(map[string]uint64, len(sm.uint64Metrics))
+	for key, value := range sm.uint64Metrics {
+		uint64Metrics[key] = value
+	}
+	sm.mutUint64Operations.RUnlock()
+
+	sm.mutInt64Operations.RLock()
+	int64Metrics := make(map[string]int64, len(sm.int64Metrics))
+	for key, value := range sm.int64Metrics {
+		int64Metrics[key] = value
+	}
+	sm.mutInt64Operations.RUnlock()
+
+	sm.mutStringOperations.RLock()
+	stringMetrics := make(map[string]string, len(sm.stringMetrics))
+	for key, value := range sm.stringMetrics {
+		stringMetrics[key] = value
+	}
+	sm.mutStringOperations.RUnlock()
+
+	return uint64Metrics, int64Metrics, stringMetrics
+}
+
+func appendSnapshotKey(buff []byte, key string, tag byte) []byte {
+	buff = appendSnapshotString(buff, key)
+	return append(buff, tag)
+}
+
+func appendSnapshotString(buff []byte, value string) []byte {
+	buff = binary.AppendUvarint(buff, uint64(len(value)))
+	return append(buff, value...)
+}
+
+type snapshotDecoder struct {
+	data []byte
+}
+
+func (decoder *snapshotDecoder) decodeMetrics() (map[string]uint64, map[string]int64, map[string]string, error) {
+	version, err := decoder.readByte()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if version != metricsSnapshotVersion {
+		return nil, nil, nil, fmt.Errorf("unknown version %d", version)
+	}
+
+	numMetrics, err := decoder.readUvarint()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	uint64Metrics := make(map[string]uint64)
+	int64Metrics := make(map[string]int64)
+	stringMetrics := make(map[string]string)
+	for i := uint64(0); i < numMetrics; i++ {
+		err = decoder.decodeMetric(uint64Metrics, int64Metrics, stringMetrics)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("%w for metric %d", err, i)
+		}
+	}
+	if len(decoder.data) > 0 {
+		return nil, nil, nil, fmt.Errorf("%d trailing bytes", len(decoder.data))
+	}
+
+	return uint64Metrics, int64Metrics, stringMetrics, nil
+}
+
+func (decoder *snapshotDecoder) decodeMetric(
+	uint64Metrics map[string]uint64,
+	int64Metrics map[string]int64,
+	stringMetrics map[string]string,
+) error {
+	key, err := decoder.readString()
+	if err != nil {
+		return err
+	}
+	tag, err := decoder.readByte()
+	if err != nil {
+		return err
+	}
+
+	switch tag {
+	case metricTagUint64:
+		uint64Metrics[key], err = decoder.readUvarint()
+	case metricTagInt64:
+		int64Metrics[key], err = decoder.readVarint()
+	case metricTagString:
+		stringMetrics[key], err = decoder.readString()
+	default:
+		err = fmt.Errorf("unknown type tag %d", tag)
+	}
+
+	return err
+}
+
+func (decoder *snapshotDecoder) readByte() (byte, error) {
+	if len(decoder.data) == 0 {
+		return 0, errUnexpectedSnapshotEnd
+	}
+
+	value := decoder.data[0]
+	decoder.data = decoder.data[1:]
+
+	return value, nil
+}
+
+func (decoder *snapshotDecoder) readUvarint() (uint64, error) {
+	value, n := binary.Uvarint(decoder.data)
+	if n <= 0 {
+		return 0, errUnexpectedSnapshotEnd
+	}
+	decoder.data = decoder.data[n:]
+
+	return value, nil
+}
+
+func (decoder *snapshotDecoder) readVarint() (int64, error) {
+	value, n := binary.Varint(decoder.data)
+	if n <= 0 {
+		return 0, errUnexpectedSnapshotEnd
+	}
+	decoder.data = decoder.data[n:]
+
+	return value, nil
+}
+
+func (decoder *snapshotDecoder) readString() (string, error) {
+	length, err := decoder.readUvarint()
+	if err != nil {
+		return "", err
+	}
+	if length > uint64(len(decoder.data)) {
+		return "", errUnexpectedSnapshotEnd
+	}
+
+	value := string(decoder.data[:length])
+	decoder.data = decoder.data[length:]
+
+	return value, nil
+}
@@ -0,0 +1,79 @@
+package statusHandler_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/multiversx/mx-chain-go/common"
+	"github.com/multiversx/mx-chain-go/statusHandler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusMetrics_MarshalUnmarshalBinary(t *testing.T) {
+	t.Parallel()
+
+	t.Run("mixed metrics should round trip", func(t *testing.T) {
+		t.Parallel()
+
+		sm := statusHandler.NewStatusMetrics()
+		sm.SetUInt64Value(common.MetricNonce, 37)
+		sm.SetUInt64Value(common.MetricRoundDuration, math.MaxUint64)
+		sm.SetInt64Value(common.MetricEpochNumber, -1)
+		sm.SetInt64Value("int64 metric", math.MinInt64)
+		sm.SetStringValue(common.MetricNodeType, "validator")
+		sm.SetStringValue(common.MetricAppVersion, "")
+
+		buff, err := sm.MarshalBinary()
+		require.Nil(t, err)
+
+		recovered := statusHandler.NewStatusMetrics()
+		recovered.SetUInt64Value("stale metric", 1)
+		err = recovered.UnmarshalBinary(buff)
+		require.Nil(t, err)
+
+		assert.Equal(t, sm.MetricsTyped(), recovered.MetricsTyped())
+		assert.NotContains(t, recovered.MetricsTyped(), "stale metric")
+
+		otherBuff, err := recovered.MarshalBinary()
+		require.Nil(t, err)
+		assert.Equal(t, buff, otherBuff)
+	})
+	t.Run("empty metrics should round trip", func(t *testing.T) {
+		t.Parallel()
+
+		buff, err := statusHandler.NewStatusMetrics().MarshalBinary()
+		require.Nil(t, err)
+		assert.Equal(t, []byte{1, 0}, buff)
+
+		recovered := statusHandler.NewStatusMetrics()
+		recovered.SetUInt64Value(common.MetricNonce, 37)
+		err = recovered.UnmarshalBinary(buff)
+		require.Nil(t, err)
+		assert.Empty(t, recovered.MetricsTyped())
+	})
+	t.Run("invalid snapshot should error and leave the metrics unchanged", func(t *testing.T) {
+		t.Parallel()
+
+		sm := statusHandler.NewStatusMetrics()
+		sm.SetStringValue(common.MetricNodeType, "validator")
+		buff, _ := sm.MarshalBinary()
+
+		invalidSnapshots := map[string][]byte{
+			"empty":           nil,
+			"unknown version": append([]byte{2}, buff[1:]...),
+			"truncated":       buff[:len(buff)-1],
+			"trailing bytes":  append(append([]byte{}, buff...), 0),
+			"unknown tag":     {1, 1, 1, 'k', 9, 0},
+		}
+		for name, invalidSnapshot := range invalidSnapshots {
+			recovered := statusHandler.NewStatusMetrics()
+			recovered.SetUInt64Value(common.MetricNonce, 37)
+
+			err := recovered.UnmarshalBinary(invalidSnapshot)
+			assert.True(t, errors.Is(err, statusHandler.ErrInvalidMetricsSnapshot), name)
+			assert.Equal(t, uint64(37), recovered.GetUint64OrDefault(common.MetricNonce, 0), name)
+		}
+	})
+}
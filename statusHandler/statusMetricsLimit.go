@@ -0,0 +1,44 @@
+package statusHandler
+
+// SetMaxNumMetrics sets the maximum number of metrics which can be stored, bounding the memory used if a component
+// creates an unbounded number of metric keys. Once the limit is reached, the metrics with new keys are rejected while
+// the existing ones can still be updated. Zero means no limit. A limit under the current number of metrics does not
+// remove any of them
+func (sm *statusMetrics) SetMaxNumMetrics(maxNumMetrics uint32) {
+	sm.mutNumMetrics.Lock()
+	sm.maxNumMetrics = maxNumMetrics
+	sm.mutNumMetrics.Unlock()
+}
+
+// NumRejectedMetrics returns the number of times a metric with a new key was rejected because the limit set by
+// SetMaxNumMetrics was reached
+func (sm *statusMetrics) NumRejectedMetrics() uint64 {
+	return sm.numRejectedMetrics.GetUint64()
+}
+
+// reserveMetricSlot accounts a new metric key, returning false if the metric should be rejected because the limit was
+// reached. It should be called while holding the write lock of the map in which the metric will be stored
+func (sm *statusMetrics) reserveMetricSlot(key string) bool {
+	sm.mutNumMetrics.Lock()
+	defer sm.mutNumMetrics.Unlock()
+
+	if sm.maxNumMetrics > 0 && sm.numMetrics >= sm.maxNumMetrics {
+		sm.numRejectedMetrics.Increment()
+		log.Debug("statusMetrics: max number of metrics reached, new metric rejected",
+			"key", key,
+			"max num metrics", sm.maxNumMetrics,
+		)
+		return false
+	}
+
+	sm.numMetrics++
+
+	return true
+}
+
+// setNumMetrics should be called while holding the write locks of all the metrics maps
+func (sm *statusMetrics) setNumMetrics(numMetrics uint32) {
+	sm.mutNumMetrics.Lock()
+	sm.numMetrics = numMetrics
+	sm.mutNumMetrics.Unlock()
+}
@@ -0,0 +1,77 @@
+package statusHandler_test
+
+import (
+	"testing"
+
+	"github.com/multiversx/mx-chain-go/common"
+	"github.com/multiversx/mx-chain-go/statusHandler"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusMetrics_SetMaxNumMetrics(t *testing.T) {
+	t.Parallel()
+
+	t.Run("zero limit should not reject metrics", func(t *testing.T) {
+		t.Parallel()
+
+		sm := statusHandler.NewStatusMetrics()
+		sm.SetMaxNumMetrics(0)
+		sm.SetUInt64Value(common.MetricNonce, 1)
+		sm.SetInt64Value(common.MetricEpochNumber, 2)
+		sm.SetStringValue(common.MetricNodeType, "validator")
+
+		assert.Len(t, sm.MetricsTyped(), 3)
+		assert.Zero(t, sm.NumRejectedMetrics())
+	})
+	t.Run("new keys should be rejected past the limit", func(t *testing.T) {
+		t.Parallel()
+
+		sm := statusHandler.NewStatusMetrics()
+		sm.SetMaxNumMetrics(2)
+		sm.SetUInt64Value(common.MetricNonce, 1)
+		sm.SetStringValue(common.MetricNodeType, "validator")
+
+		sm.SetUInt64Value("new uint64", 1)
+		sm.SetInt64Value("new int64", 2)
+		sm.SetStringValue("new string", "value")
+		sm.SetMulti(map[string]interface{}{
+			"new multi":           uint64(3),
+			common.MetricNodeType: "observer",
+		})
+
+		expectedMetrics := map[string]statusHandler.TypedMetric{
+			common.MetricNonce:    {Value: uint64(1), Type: "uint64"},
+			common.MetricNodeType: {Value: "observer", Type: "string"},
+		}
+		assert.Equal(t, expectedMetrics, sm.MetricsTyped())
+		assert.Equal(t, uint64(4), sm.NumRejectedMetrics())
+	})
+	t.Run("existing keys should still be updated", func(t *testing.T) {
+		t.Parallel()
+
+		sm := statusHandler.NewStatusMetrics()
+		sm.SetUInt64Value(common.MetricNonce, 1)
+		sm.SetInt64Value(common.MetricEpochNumber, 2)
+		sm.SetMaxNumMetrics(1)
+
+		sm.SetUInt64Value(common.MetricNonce, 10)
+		sm.Increment(common.MetricNonce)
+		sm.SetInt64Value(common.MetricEpochNumber, 20)
+
+		assert.Equal(t, uint64(11), sm.GetUint64OrDefault(common.MetricNonce, 0))
+		assert.Equal(t, int64(20), sm.MetricsTyped()[common.MetricEpochNumber].Value)
+		assert.Zero(t, sm.NumRejectedMetrics())
+	})
+	t.Run("reset should free the slots", func(t *testing.T) {
+		t.Parallel()
+
+		sm := statusHandler.NewStatusMetrics()
+		sm.SetMaxNumMetrics(1)
+		sm.SetUInt64Value(common.MetricNonce, 1)
+		sm.Reset()
+
+		sm.SetStringValue(common.MetricNodeType, "validator")
+		assert.Equal(t, "validator", sm.GetStringOrDefault(common.MetricNodeType, ""))
+		assert.Zero(t, sm.NumRejectedMetrics())
+	})
+}
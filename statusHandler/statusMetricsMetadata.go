@@ -0,0 +1,49 @@
+package statusHandler
+
+// MetricMeta holds the unit and the description of a metric
+type MetricMeta struct {
+	Unit        string `json:"unit"`
+	Description string `json:"description"`
+}
+
+// DescribedMetric holds the value of a metric along with its metadata
+type DescribedMetric struct {
+	Value interface{} `json:"value"`
+	MetricMeta
+}
+
+// RegisterMetricMeta registers the unit (for example "ms") and the description of the provided metric, so the metric can
+// be rendered in a self-documenting way by DescribedMetrics. A new registration for the same key replaces the old one
+func (sm *statusMetrics) RegisterMetricMeta(key string, unit string, description string) {
+	sm.mutMetricsMeta.Lock()
+	defer sm.mutMetricsMeta.Unlock()
+
+	if sm.metricsMeta == nil {
+		sm.metricsMeta = make(map[string]MetricMeta)
+	}
+	sm.metricsMeta[key] = MetricMeta{
+		Unit:        unit,
+		Description: description,
+	}
+}
+
+// DescribedMetrics returns all the stored metrics, each one with its value and its registered metadata. The metrics
+// without registered metadata will have an empty unit and description
+func (sm *statusMetrics) DescribedMetrics() map[string]DescribedMetric {
+	metrics := sm.getMetricsWithKeyFilterMutexProtected(func(_ string) bool {
+		return true
+	})
+
+	sm.mutMetricsMeta.RLock()
+	defer sm.mutMetricsMeta.RUnlock()
+
+	describedMetrics := make(map[string]DescribedMetric, len(metrics))
+	for key, value := range metrics {
+		describedMetrics[key] = DescribedMetric{
+			Value:      value,
+			MetricMeta: sm.metricsMeta[key],
+		}
+	}
+
+	return describedMetrics
+}
@@ -0,0 +1,77 @@
+package statusHandler_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/multiversx/mx-chain-go/common"
+	"github.com/multiversx/mx-chain-go/statusHandler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusMetrics_DescribedMetrics(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no metrics should return empty", func(t *testing.T) {
+		t.Parallel()
+
+		sm := statusHandler.NewStatusMetrics()
+		sm.RegisterMetricMeta(common.MetricRoundDuration, "ms", "the duration of a round")
+
+		assert.Empty(t, sm.DescribedMetrics())
+	})
+	t.Run("metadata should be attached to the registered metrics", func(t *testing.T) {
+		t.Parallel()
+
+		sm := statusHandler.NewStatusMetrics()
+		sm.RegisterMetricMeta(common.MetricRoundDuration, "ms", "the duration of a round")
+		sm.RegisterMetricMeta(common.MetricNodeType, "", "the type of the node")
+		sm.SetUInt64Value(common.MetricRoundDuration, 6000)
+		sm.SetStringValue(common.MetricNodeType, "validator")
+		sm.SetInt64Value(common.MetricEpochNumber, 3)
+
+		expectedMetrics := map[string]statusHandler.DescribedMetric{
+			common.MetricRoundDuration: {
+				Value: uint64(6000),
+				MetricMeta: statusHandler.MetricMeta{
+					Unit:        "ms",
+					Description: "the duration of a round",
+				},
+			},
+			common.MetricNodeType: {
+				Value: "validator",
+				MetricMeta: statusHandler.MetricMeta{
+					Description: "the type of the node",
+				},
+			},
+			common.MetricEpochNumber: {
+				Value: int64(3),
+			},
+		}
+		assert.Equal(t, expectedMetrics, sm.DescribedMetrics())
+	})
+	t.Run("new registration should replace the old one", func(t *testing.T) {
+		t.Parallel()
+
+		sm := statusHandler.NewStatusMetrics()
+		sm.RegisterMetricMeta(common.MetricRoundDuration, "s", "round duration")
+		sm.RegisterMetricMeta(common.MetricRoundDuration, "ms", "the duration of a round")
+		sm.SetUInt64Value(common.MetricRoundDuration, 6000)
+
+		describedMetric := sm.DescribedMetrics()[common.MetricRoundDuration]
+		assert.Equal(t, "ms", describedMetric.Unit)
+		assert.Equal(t, "the duration of a round", describedMetric.Description)
+	})
+	t.Run("described metric should be rendered with its metadata", func(t *testing.T) {
+		t.Parallel()
+
+		sm := statusHandler.NewStatusMetrics()
+		sm.RegisterMetricMeta(common.MetricRoundDuration, "ms", "the duration of a round")
+		sm.SetUInt64Value(common.MetricRoundDuration, 6000)
+
+		buff, err := json.Marshal(sm.DescribedMetrics())
+		require.Nil(t, err)
+		assert.Equal(t, `{"`+common.MetricRoundDuration+`":{"value":6000,"unit":"ms","description":"the duration of a round"}}`, string(buff))
+	})
+}
@@ -0,0 +1,51 @@
+package statusHandler
+
+import "strings"
+
+// P2PMetricsFilter defines which of the p2p metrics are exposed by StatusP2pMetricsMap. The empty filter exposes all
+// of them
+type P2PMetricsFilter struct {
+	// AllowedSubstrings, when not empty, restricts the exposed p2p metrics to the ones containing at least one of the
+	// provided substrings
+	AllowedSubstrings []string
+	// DeniedKeys holds the keys of the p2p metrics which are never exposed, even if allowed by AllowedSubstrings
+	DeniedKeys []string
+}
+
+type p2pMetricsFilter struct {
+	allowedSubstrings []string
+	deniedKeys        map[string]struct{}
+}
+
+func newP2PMetricsFilter(filter P2PMetricsFilter) *p2pMetricsFilter {
+	deniedKeys := make(map[string]struct{}, len(filter.DeniedKeys))
+	for _, key := range filter.DeniedKeys {
+		deniedKeys[key] = struct{}{}
+	}
+
+	allowedSubstrings := make([]string, len(filter.AllowedSubstrings))
+	copy(allowedSubstrings, filter.AllowedSubstrings)
+
+	return &p2pMetricsFilter{
+		allowedSubstrings: allowedSubstrings,
+		deniedKeys:        deniedKeys,
+	}
+}
+
+func (filter *p2pMetricsFilter) isExposed(key string) bool {
+	_, isDenied := filter.deniedKeys[key]
+	if isDenied {
+		return false
+	}
+	if len(filter.allowedSubstrings) == 0 {
+		return true
+	}
+
+	for _, substring := range filter.allowedSubstrings {
+		if strings.Contains(key, substring) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,98 @@
+package statusHandler_test
+
+import (
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-go/common"
+	"github.com/multiversx/mx-chain-go/statusHandler"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusMetrics_StatusP2pMetricsMapWithFilter(t *testing.T) {
+	t.Parallel()
+
+	setMetrics := func(sm core.AppStatusHandler) {
+		sm.SetUInt64Value("erd_p2p_in_num_connections", 10)
+		sm.SetUInt64Value("erd_p2p_out_num_connections", 5)
+		sm.SetStringValue(common.MetricP2PPeerInfo, "peer info")
+		sm.SetUInt64Value(common.MetricP2PUnknownPeers, 3)
+		sm.SetUInt64Value(common.MetricNonce, 37)
+	}
+
+	t.Run("empty filter should expose all the p2p metrics", func(t *testing.T) {
+		t.Parallel()
+
+		sm := statusHandler.NewStatusMetricsWithP2PMetricsFilter(statusHandler.P2PMetricsFilter{})
+		setMetrics(sm)
+
+		p2pMetrics, err := sm.StatusP2pMetricsMap()
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"erd_p2p_in_num_connections":  uint64(10),
+			"erd_p2p_out_num_connections": uint64(5),
+			common.MetricP2PPeerInfo:      "peer info",
+			common.MetricP2PUnknownPeers:  uint64(3),
+		}, p2pMetrics)
+	})
+	t.Run("allowed substrings should restrict the p2p metrics", func(t *testing.T) {
+		t.Parallel()
+
+		sm := statusHandler.NewStatusMetricsWithP2PMetricsFilter(statusHandler.P2PMetricsFilter{
+			AllowedSubstrings: []string{"num_connections", "unknown"},
+		})
+		setMetrics(sm)
+
+		p2pMetrics, err := sm.StatusP2pMetricsMap()
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"erd_p2p_in_num_connections":  uint64(10),
+			"erd_p2p_out_num_connections": uint64(5),
+			common.MetricP2PUnknownPeers:  uint64(3),
+		}, p2pMetrics)
+
+		// the non p2p metrics should not be exposed even if they contain an allowed substring
+		sm.SetUInt64Value("erd_num_connections", 1)
+		p2pMetrics, _ = sm.StatusP2pMetricsMap()
+		assert.NotContains(t, p2pMetrics, "erd_num_connections")
+	})
+	t.Run("denied keys should be removed from the p2p metrics", func(t *testing.T) {
+		t.Parallel()
+
+		sm := statusHandler.NewStatusMetricsWithP2PMetricsFilter(statusHandler.P2PMetricsFilter{
+			DeniedKeys: []string{common.MetricP2PPeerInfo},
+		})
+		setMetrics(sm)
+
+		p2pMetrics, err := sm.StatusP2pMetricsMap()
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"erd_p2p_in_num_connections":  uint64(10),
+			"erd_p2p_out_num_connections": uint64(5),
+			common.MetricP2PUnknownPeers:  uint64(3),
+		}, p2pMetrics)
+
+		_, _, neutral := sm.P2pMetricsByDirection()
+		assert.Equal(t, map[string]interface{}{
+			common.MetricP2PUnknownPeers: uint64(3),
+		}, neutral)
+
+		// the filter applies only to the p2p metrics map
+		assert.Equal(t, "peer info", sm.GetStringOrDefault(common.MetricP2PPeerInfo, ""))
+	})
+	t.Run("denied keys should take precedence over allowed substrings", func(t *testing.T) {
+		t.Parallel()
+
+		sm := statusHandler.NewStatusMetricsWithP2PMetricsFilter(statusHandler.P2PMetricsFilter{
+			AllowedSubstrings: []string{"num_connections"},
+			DeniedKeys:        []string{"erd_p2p_out_num_connections"},
+		})
+		setMetrics(sm)
+
+		p2pMetrics, err := sm.StatusP2pMetricsMap()
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"erd_p2p_in_num_connections": uint64(10),
+		}, p2pMetrics)
+	})
+}
@@ -2,30 +2,94 @@ package statusHandler
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/atomic"
 	"github.com/multiversx/mx-chain-go/common"
 )
 
+// shardMetricSeparator is the separator used by the metrics that are tracked per shard. The convention for these metrics
+// is <metric name>_shard_<shard ID>, where the shard ID is either a number or "metachain"
+const shardMetricSeparator = "_shard_"
+
+// numericMetricSizeBytes is the size accounted by ApproxSizeBytes for each numeric metric value
+const numericMetricSizeBytes = 8
+
+// p2pInboundMarker and p2pOutboundMarker are the markers used by the p2p metrics that are tracked per connection direction.
+// The convention for these metrics is <prefix>_p2p_in_<metric name> and <prefix>_p2p_out_<metric name>
+const (
+	p2pInboundMarker  = "_in_"
+	p2pOutboundMarker = "_out_"
+)
+
 // statusMetrics will handle displaying at /node/details all metrics already collected for other status handlers
 type statusMetrics struct {
 	uint64Metrics       map[string]uint64
+	uint64WriteInfos    map[string]metricWriteInfo
 	mutUint64Operations sync.RWMutex
 
 	stringMetrics       map[string]string
+	stringWriteInfos    map[string]metricWriteInfo
 	mutStringOperations sync.RWMutex
 
 	int64Metrics       map[string]int64
+	int64WriteInfos    map[string]metricWriteInfo
 	mutInt64Operations sync.RWMutex
+
+	lastUpdateUnixNano atomic.Int64
+	version            atomic.Counter
+
+	mutCache                sync.RWMutex
+	cachedMetricsWithoutP2P map[string]interface{}
+	cachedVersion           int64
+
+	mutStreams        sync.RWMutex
+	streams           map[chan MetricUpdate]struct{}
+	numDroppedUpdates atomic.Counter
+
+	mutThresholds        sync.Mutex
+	thresholds           map[string][]*thresholdWatcher
+	numThresholdWatchers atomic.Counter
+
+	mutAudit               sync.RWMutex
+	auditor                *metricsAuditor
+	numDroppedAuditEntries atomic.Counter
+
+	mutMetricsMeta sync.RWMutex
+	metricsMeta    map[string]MetricMeta
+
+	mutNumMetrics      sync.Mutex
+	numMetrics         uint32
+	maxNumMetrics      uint32
+	numRejectedMetrics atomic.Counter
+
+	p2pFilter *p2pMetricsFilter
+
+	getTimeHandler func() time.Time
 }
 
 // NewStatusMetrics will return an instance of the struct
 func NewStatusMetrics() *statusMetrics {
+	return NewStatusMetricsWithP2PMetricsFilter(P2PMetricsFilter{})
+}
+
+// NewStatusMetricsWithP2PMetricsFilter will return an instance of the struct which exposes through StatusP2pMetricsMap
+// only the p2p metrics allowed by the provided filter
+func NewStatusMetricsWithP2PMetricsFilter(filter P2PMetricsFilter) *statusMetrics {
 	return &statusMetrics{
-		uint64Metrics: make(map[string]uint64),
-		stringMetrics: make(map[string]string),
-		int64Metrics:  make(map[string]int64),
+		uint64Metrics:    make(map[string]uint64),
+		uint64WriteInfos: make(map[string]metricWriteInfo),
+		stringMetrics:    make(map[string]string),
+		stringWriteInfos: make(map[string]metricWriteInfo),
+		int64Metrics:     make(map[string]int64),
+		int64WriteInfos:  make(map[string]metricWriteInfo),
+		p2pFilter:        newP2PMetricsFilter(filter),
+		getTimeHandler:   time.Now,
 	}
 }
 
@@ -36,48 +100,50 @@ func (sm *statusMetrics) IsInterfaceNil() bool {
 
 // Increment method increment a metric
 func (sm *statusMetrics) Increment(key string) {
-	sm.mutUint64Operations.Lock()
-	defer sm.mutUint64Operations.Unlock()
-
-	value, ok := sm.uint64Metrics[key]
-	if !ok {
-		return
-	}
-
-	value++
-	sm.uint64Metrics[key] = value
+	sm.updateExistingUint64Value(key, func(value uint64) (uint64, bool) {
+		return value + 1, true
+	})
 }
 
 // AddUint64 method increase a metric with a specific value
 func (sm *statusMetrics) AddUint64(key string, val uint64) {
-	sm.mutUint64Operations.Lock()
-	defer sm.mutUint64Operations.Unlock()
-
-	value, ok := sm.uint64Metrics[key]
-	if !ok {
-		return
-	}
-
-	value += val
-	sm.uint64Metrics[key] = value
+	sm.updateExistingUint64Value(key, func(value uint64) (uint64, bool) {
+		return value + val, true
+	})
 }
 
 // Decrement method - decrement a metric
 func (sm *statusMetrics) Decrement(key string) {
-	sm.mutUint64Operations.Lock()
-	defer sm.mutUint64Operations.Unlock()
+	sm.updateExistingUint64Value(key, func(value uint64) (uint64, bool) {
+		if value == 0 {
+			return 0, false
+		}
 
-	value, ok := sm.uint64Metrics[key]
-	if !ok {
-		return
-	}
+		return value - 1, true
+	})
+}
 
-	if value == 0 {
+// updateExistingUint64Value applies the provided update function on the stored value, if the metric exists. The update
+// function returns the new value and false if the metric should be left unchanged
+func (sm *statusMetrics) updateExistingUint64Value(key string, update func(value uint64) (uint64, bool)) {
+	sm.mutUint64Operations.Lock()
+	oldValue, ok := sm.uint64Metrics[key]
+	value := oldValue
+	if ok {
+		value, ok = update(oldValue)
+	}
+	if !ok {
+		sm.mutUint64Operations.Unlock()
 		return
 	}
 
-	value--
 	sm.uint64Metrics[key] = value
+	markMetricWritten(sm.uint64WriteInfos, key, sm.markUpdated())
+	sm.publishUpdate(key, value)
+	sm.auditChange(key, oldValue, value)
+	sm.mutUint64Operations.Unlock()
+
+	sm.checkThresholds(key, value)
 }
 
 // SetInt64Value method - sets an int64 value for a key
@@ -85,15 +151,33 @@ func (sm *statusMetrics) SetInt64Value(key string, value int64) {
 	sm.mutInt64Operations.Lock()
 	defer sm.mutInt64Operations.Unlock()
 
+	oldValue, exists := sm.int64Metrics[key]
+	if !exists && !sm.reserveMetricSlot(key) {
+		return
+	}
+
 	sm.int64Metrics[key] = value
+	markMetricWritten(sm.int64WriteInfos, key, sm.markUpdated())
+	sm.publishUpdate(key, value)
+	sm.auditChange(key, existingValueOrNil(oldValue, exists), value)
 }
 
 // SetUInt64Value method - sets an uint64 value for a key
 func (sm *statusMetrics) SetUInt64Value(key string, value uint64) {
 	sm.mutUint64Operations.Lock()
-	defer sm.mutUint64Operations.Unlock()
+	oldValue, exists := sm.uint64Metrics[key]
+	if !exists && !sm.reserveMetricSlot(key) {
+		sm.mutUint64Operations.Unlock()
+		return
+	}
 
 	sm.uint64Metrics[key] = value
+	markMetricWritten(sm.uint64WriteInfos, key, sm.markUpdated())
+	sm.publishUpdate(key, value)
+	sm.auditChange(key, existingValueOrNil(oldValue, exists), value)
+	sm.mutUint64Operations.Unlock()
+
+	sm.checkThresholds(key, value)
 }
 
 // SetStringValue method - sets a string value for a key
@@ -101,15 +185,247 @@ func (sm *statusMetrics) SetStringValue(key string, value string) {
 	sm.mutStringOperations.Lock()
 	defer sm.mutStringOperations.Unlock()
 
+	oldValue, exists := sm.stringMetrics[key]
+	if !exists && !sm.reserveMetricSlot(key) {
+		return
+	}
+
 	sm.stringMetrics[key] = value
+	markMetricWritten(sm.stringWriteInfos, key, sm.markUpdated())
+	sm.publishUpdate(key, value)
+	sm.auditChange(key, existingValueOrNil(oldValue, exists), value)
+}
+
+// SetMulti method - sets all the provided values in one call, each value being stored in the metrics map corresponding
+// to its type. Only uint64, int64 and string values are accepted, the values of other types being skipped
+func (sm *statusMetrics) SetMulti(values map[string]interface{}) {
+	uint64Values := make(map[string]uint64)
+	int64Values := make(map[string]int64)
+	stringValues := make(map[string]string)
+	for key, value := range values {
+		switch typedValue := value.(type) {
+		case uint64:
+			uint64Values[key] = typedValue
+		case int64:
+			int64Values[key] = typedValue
+		case string:
+			stringValues[key] = typedValue
+		default:
+			log.Trace("statusMetrics.SetMulti: unknown value type, skipping", "key", key, "type", fmt.Sprintf("%T", value))
+		}
+	}
+
+	writeUnixNano := sm.getTimeHandler().UnixNano()
+	oldValues := make(map[string]interface{}, len(values))
+	if len(uint64Values) > 0 {
+		sm.mutUint64Operations.Lock()
+		for key, value := range uint64Values {
+			oldValue, exists := sm.uint64Metrics[key]
+			if !exists && !sm.reserveMetricSlot(key) {
+				delete(uint64Values, key)
+				continue
+			}
+
+			oldValues[key] = existingValueOrNil(oldValue, exists)
+			sm.uint64Metrics[key] = value
+			markMetricWritten(sm.uint64WriteInfos, key, writeUnixNano)
+		}
+		sm.mutUint64Operations.Unlock()
+	}
+
+	if len(int64Values) > 0 {
+		sm.mutInt64Operations.Lock()
+		for key, value := range int64Values {
+			oldValue, exists := sm.int64Metrics[key]
+			if !exists && !sm.reserveMetricSlot(key) {
+				delete(int64Values, key)
+				continue
+			}
+
+			oldValues[key] = existingValueOrNil(oldValue, exists)
+			sm.int64Metrics[key] = value
+			markMetricWritten(sm.int64WriteInfos, key, writeUnixNano)
+		}
+		sm.mutInt64Operations.Unlock()
+	}
+
+	if len(stringValues) > 0 {
+		sm.mutStringOperations.Lock()
+		for key, value := range stringValues {
+			oldValue, exists := sm.stringMetrics[key]
+			if !exists && !sm.reserveMetricSlot(key) {
+				delete(stringValues, key)
+				continue
+			}
+
+			oldValues[key] = existingValueOrNil(oldValue, exists)
+			sm.stringMetrics[key] = value
+			markMetricWritten(sm.stringWriteInfos, key, writeUnixNano)
+		}
+		sm.mutStringOperations.Unlock()
+	}
+
+	if len(uint64Values)+len(int64Values)+len(stringValues) > 0 {
+		sm.markUpdatedAt(writeUnixNano)
+	}
+
+	for key, value := range uint64Values {
+		sm.publishUpdate(key, value)
+		sm.auditChange(key, oldValues[key], value)
+		sm.checkThresholds(key, value)
+	}
+	for key, value := range int64Values {
+		sm.publishUpdate(key, value)
+		sm.auditChange(key, oldValues[key], value)
+	}
+	for key, value := range stringValues {
+		sm.publishUpdate(key, value)
+		sm.auditChange(key, oldValues[key], value)
+	}
+}
+
+// GetUint64OrDefault returns the uint64 metric stored for the provided key or the provided default value if the
+// metric does not exist
+func (sm *statusMetrics) GetUint64OrDefault(key string, def uint64) uint64 {
+	sm.mutUint64Operations.RLock()
+	defer sm.mutUint64Operations.RUnlock()
+
+	value, ok := sm.uint64Metrics[key]
+	if !ok {
+		return def
+	}
+
+	return value
+}
+
+// GetStringOrDefault returns the string metric stored for the provided key or the provided default value if the
+// metric does not exist
+func (sm *statusMetrics) GetStringOrDefault(key string, def string) string {
+	sm.mutStringOperations.RLock()
+	defer sm.mutStringOperations.RUnlock()
+
+	value, ok := sm.stringMetrics[key]
+	if !ok {
+		return def
+	}
+
+	return value
+}
+
+// ApproxSizeBytes returns an estimate of the memory held by the stored metrics, computed as the sum of the lengths of
+// the keys and of the string values, plus 8 bytes for each numeric value. The overhead of the maps is not accounted
+func (sm *statusMetrics) ApproxSizeBytes() int {
+	size := 0
+
+	sm.mutUint64Operations.RLock()
+	for key := range sm.uint64Metrics {
+		size += len(key) + numericMetricSizeBytes
+	}
+	sm.mutUint64Operations.RUnlock()
+
+	sm.mutInt64Operations.RLock()
+	for key := range sm.int64Metrics {
+		size += len(key) + numericMetricSizeBytes
+	}
+	sm.mutInt64Operations.RUnlock()
+
+	sm.mutStringOperations.RLock()
+	for key, value := range sm.stringMetrics {
+		size += len(key) + len(value)
+	}
+	sm.mutStringOperations.RUnlock()
+
+	return size
 }
 
-// Close method - won't do anything
+// markUpdated records the current moment as the last update and returns it, so it can be reused for the written metric
+func (sm *statusMetrics) markUpdated() int64 {
+	updateUnixNano := sm.getTimeHandler().UnixNano()
+	sm.markUpdatedAt(updateUnixNano)
+
+	return updateUnixNano
+}
+
+func (sm *statusMetrics) markUpdatedAt(updateUnixNano int64) {
+	sm.lastUpdateUnixNano.Set(updateUnixNano)
+	sm.version.Increment()
+}
+
+// LastUpdate returns the moment when any of the metrics was last written. The zero time is returned if no metric
+// was written yet
+func (sm *statusMetrics) LastUpdate() time.Time {
+	lastUpdateUnixNano := sm.lastUpdateUnixNano.Get()
+	if lastUpdateUnixNano == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(0, lastUpdateUnixNano)
+}
+
+// Reset removes all the stored metrics, together with their versions and write moments. It counts as a metrics
+// update, so the last update time is refreshed and any cached snapshot is invalidated
+func (sm *statusMetrics) Reset() {
+	sm.mutUint64Operations.Lock()
+	sm.mutStringOperations.Lock()
+	sm.mutInt64Operations.Lock()
+	sm.uint64Metrics = make(map[string]uint64)
+	sm.stringMetrics = make(map[string]string)
+	sm.int64Metrics = make(map[string]int64)
+	sm.resetMetricWriteInfos()
+	sm.setNumMetrics(0)
+	sm.mutInt64Operations.Unlock()
+	sm.mutStringOperations.Unlock()
+	sm.mutUint64Operations.Unlock()
+
+	sm.markUpdated()
+}
+
+// Close method - stops the metrics auditing, if an audit sink was set
 func (sm *statusMetrics) Close() {
+	sm.mutAudit.Lock()
+	auditor := sm.auditor
+	sm.auditor = nil
+	sm.mutAudit.Unlock()
+
+	sm.stopAuditor(auditor)
 }
 
-// StatusMetricsMapWithoutP2P will return the non-p2p metrics in a map
+// StatusMetricsMapWithoutP2P will return the non-p2p metrics in a map. The metrics are cached and rebuilt only when a
+// metric changed since the last build, each call returning its own copy of the cached map
 func (sm *statusMetrics) StatusMetricsMapWithoutP2P() (map[string]interface{}, error) {
+	currentVersion := sm.version.Get()
+
+	sm.mutCache.RLock()
+	cachedMetrics := sm.cachedMetricsWithoutP2P
+	isCacheValid := cachedMetrics != nil && sm.cachedVersion == currentVersion
+	sm.mutCache.RUnlock()
+	if isCacheValid {
+		return copyMetricsMap(cachedMetrics), nil
+	}
+
+	metrics, err := sm.buildMetricsMapWithoutP2P()
+	if err != nil {
+		return nil, err
+	}
+
+	sm.mutCache.Lock()
+	sm.cachedMetricsWithoutP2P = metrics
+	sm.cachedVersion = currentVersion
+	sm.mutCache.Unlock()
+
+	return copyMetricsMap(metrics), nil
+}
+
+func copyMetricsMap(metrics map[string]interface{}) map[string]interface{} {
+	metricsCopy := make(map[string]interface{}, len(metrics))
+	for key, value := range metrics {
+		metricsCopy[key] = value
+	}
+
+	return metricsCopy
+}
+
+func (sm *statusMetrics) buildMetricsMapWithoutP2P() (map[string]interface{}, error) {
 	metrics, err := sm.getMetricsWithoutP2P()
 	if err != nil {
 		return nil, err
@@ -132,13 +448,35 @@ func (sm *statusMetrics) getMetricsWithoutP2P() (map[string]interface{}, error)
 	}), nil
 }
 
-// StatusP2pMetricsMap will return the p2p metrics in a map
+// StatusP2pMetricsMap will return the p2p metrics allowed by the filter provided on construction in a map
 func (sm *statusMetrics) StatusP2pMetricsMap() (map[string]interface{}, error) {
 	return sm.getMetricsWithKeyFilterMutexProtected(func(input string) bool {
-		return strings.Contains(input, "_p2p_")
+		return strings.Contains(input, "_p2p_") && sm.p2pFilter.isExposed(input)
 	}), nil
 }
 
+// P2pMetricsByDirection will return the p2p metrics partitioned by the connection direction. The metrics containing
+// the "_in_" marker are inbound, the ones containing the "_out_" marker are outbound and the rest are returned as neutral
+func (sm *statusMetrics) P2pMetricsByDirection() (inbound map[string]interface{}, outbound map[string]interface{}, neutral map[string]interface{}) {
+	p2pMetrics, _ := sm.StatusP2pMetricsMap()
+
+	inbound = make(map[string]interface{})
+	outbound = make(map[string]interface{})
+	neutral = make(map[string]interface{})
+	for key, value := range p2pMetrics {
+		switch {
+		case strings.Contains(key, p2pInboundMarker):
+			inbound[key] = value
+		case strings.Contains(key, p2pOutboundMarker):
+			outbound[key] = value
+		default:
+			neutral[key] = value
+		}
+	}
+
+	return inbound, outbound, neutral
+}
+
 func (sm *statusMetrics) getMetricsWithKeyFilterMutexProtected(filterFunc func(input string) bool) map[string]interface{} {
 	statusMetricsMap := make(map[string]interface{})
 
@@ -175,6 +513,50 @@ func (sm *statusMetrics) getMetricsWithKeyFilterMutexProtected(filterFunc func(i
 	return statusMetricsMap
 }
 
+// MetricsByShard will return all metrics grouped by the shard encoded in their keys. Keys respecting the
+// <metric name>_shard_<shard ID> convention are grouped under the provided shard ID (the "metachain" value is mapped to
+// core.MetachainShardId), while all the other keys are grouped under the reserved core.AllShardId bucket
+func (sm *statusMetrics) MetricsByShard() map[uint32]map[string]interface{} {
+	allMetrics := sm.getMetricsWithKeyFilterMutexProtected(func(_ string) bool {
+		return true
+	})
+
+	metricsByShard := make(map[uint32]map[string]interface{})
+	for key, value := range allMetrics {
+		shardID, ok := parseShardFromMetricKey(key)
+		if !ok {
+			shardID = core.AllShardId
+		}
+
+		_, exists := metricsByShard[shardID]
+		if !exists {
+			metricsByShard[shardID] = make(map[string]interface{})
+		}
+		metricsByShard[shardID][key] = value
+	}
+
+	return metricsByShard
+}
+
+func parseShardFromMetricKey(key string) (uint32, bool) {
+	idx := strings.LastIndex(key, shardMetricSeparator)
+	if idx < 0 {
+		return 0, false
+	}
+
+	shardIDString := key[idx+len(shardMetricSeparator):]
+	if shardIDString == core.GetShardIDString(core.MetachainShardId) {
+		return core.MetachainShardId, true
+	}
+
+	shardID, err := strconv.ParseUint(shardIDString, 10, 32)
+	if err != nil {
+		return 0, false
+	}
+
+	return uint32(shardID), true
+}
+
 // StatusMetricsWithoutP2PPrometheusString returns the metrics in a string format which respects prometheus style
 func (sm *statusMetrics) StatusMetricsWithoutP2PPrometheusString() (string, error) {
 	metrics, err := sm.getMetricsWithoutP2P()
@@ -510,6 +892,39 @@ func (sm *statusMetrics) BootstrapMetrics() (map[string]interface{}, error) {
 	return bootstrapMetrics, nil
 }
 
+// String returns all the metrics as a text block containing one "key = value" line for each metric, sorted by key
+func (sm *statusMetrics) String() string {
+	allMetrics := sm.getMetricsWithKeyFilterMutexProtected(func(_ string) bool {
+		return true
+	})
+
+	keys := make([]string, 0, len(allMetrics))
+	for key := range allMetrics {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	builder := strings.Builder{}
+	for _, key := range keys {
+		builder.WriteString(fmt.Sprintf("%s = %s\n", key, formatMetricValue(allMetrics[key])))
+	}
+
+	return builder.String()
+}
+
+func formatMetricValue(value interface{}) string {
+	switch v := value.(type) {
+	case uint64:
+		return strconv.FormatUint(v, 10)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case string:
+		return strconv.Quote(v)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
 func computeDelta(biggerNum uint64, lowerNum uint64) uint64 {
 	if biggerNum >= lowerNum {
 		return biggerNum - lowerNum
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-go/common"
 	"github.com/multiversx/mx-chain-go/statusHandler"
 	"github.com/stretchr/testify/assert"
@@ -784,3 +785,256 @@ func TestStatusMetrics_ConcurrentOperations(t *testing.T) {
 	elapsedTime := time.Since(startTime)
 	require.True(t, elapsedTime < 10*time.Second, "if the test isn't finished within 10 seconds, there might be a deadlock somewhere")
 }
+
+func TestStatusMetrics_MetricsByShard(t *testing.T) {
+	t.Parallel()
+
+	sm := statusHandler.NewStatusMetrics()
+	sm.SetUInt64Value("erd_num_headers_shard_0", 10)
+	sm.SetStringValue("erd_last_hash_shard_0", "hash0")
+	sm.SetUInt64Value("erd_num_headers_shard_1", 11)
+	sm.SetInt64Value("erd_delta_shard_metachain", -5)
+	sm.SetUInt64Value(common.MetricShardId, 1)
+	sm.SetStringValue("erd_wrong_shard_abc", "value")
+
+	expectedMetrics := map[uint32]map[string]interface{}{
+		0: {
+			"erd_num_headers_shard_0": uint64(10),
+			"erd_last_hash_shard_0":   "hash0",
+		},
+		1: {
+			"erd_num_headers_shard_1": uint64(11),
+		},
+		core.MetachainShardId: {
+			"erd_delta_shard_metachain": int64(-5),
+		},
+		core.AllShardId: {
+			common.MetricShardId:  uint64(1),
+			"erd_wrong_shard_abc": "value",
+		},
+	}
+	require.Equal(t, expectedMetrics, sm.MetricsByShard())
+}
+
+func TestStatusMetrics_SetMulti(t *testing.T) {
+	t.Parallel()
+
+	sm := statusHandler.NewStatusMetrics()
+	sm.SetUInt64Value("uint64-key", 1)
+	sm.SetMulti(map[string]interface{}{
+		"uint64-key": uint64(10),
+		"int64-key":  int64(-10),
+		"string-key": "value",
+		"float-key":  1.5,
+		"int-key":    7,
+		"nil-key":    nil,
+		"uint32-key": uint32(3),
+	})
+
+	expectedMetrics := map[string]interface{}{
+		"uint64-key": uint64(10),
+		"int64-key":  int64(-10),
+		"string-key": "value",
+	}
+	require.Equal(t, expectedMetrics, sm.StatusMetricsMap())
+
+	sm.Increment("uint64-key")
+	require.Equal(t, uint64(11), sm.StatusMetricsMap()["uint64-key"])
+}
+
+func TestStatusMetrics_String(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no metrics should return empty string", func(t *testing.T) {
+		t.Parallel()
+
+		sm := statusHandler.NewStatusMetrics()
+		assert.Equal(t, "", sm.String())
+	})
+	t.Run("should return the metrics sorted by key", func(t *testing.T) {
+		t.Parallel()
+
+		sm := statusHandler.NewStatusMetrics()
+		sm.SetStringValue("c_string", "value")
+		sm.SetUInt64Value("a_uint64", 37)
+		sm.SetInt64Value("b_int64", -5)
+		sm.SetStringValue("d_empty_string", "")
+
+		expectedText := "a_uint64 = 37\n" +
+			"b_int64 = -5\n" +
+			"c_string = \"value\"\n" +
+			"d_empty_string = \"\"\n"
+		assert.Equal(t, expectedText, sm.String())
+		assert.Equal(t, expectedText, sm.String())
+	})
+}
+
+func TestStatusMetrics_LastUpdate(t *testing.T) {
+	t.Parallel()
+
+	sm := statusHandler.NewStatusMetrics()
+	assert.True(t, sm.LastUpdate().IsZero())
+
+	sm.Increment("missing key")
+	assert.True(t, sm.LastUpdate().IsZero())
+
+	sm.SetUInt64Value("key", 1)
+	firstUpdate := sm.LastUpdate()
+	assert.False(t, firstUpdate.IsZero())
+
+	time.Sleep(time.Millisecond * 10)
+	sm.Increment("key")
+	secondUpdate := sm.LastUpdate()
+	assert.True(t, secondUpdate.After(firstUpdate))
+
+	time.Sleep(time.Millisecond * 10)
+	sm.SetStringValue("string key", "value")
+	assert.True(t, sm.LastUpdate().After(secondUpdate))
+}
+
+func TestStatusMetrics_StatusMetricsMapWithoutP2PCaching(t *testing.T) {
+	t.Parallel()
+
+	sm := statusHandler.NewStatusMetrics()
+	sm.SetUInt64Value(common.MetricNonce, 37)
+	sm.SetStringValue(common.MetricP2PPeerInfo, "peer info")
+
+	firstResult, err := sm.StatusMetricsMapWithoutP2P()
+	require.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{common.MetricNonce: uint64(37)}, firstResult)
+
+	secondResult, err := sm.StatusMetricsMapWithoutP2P()
+	require.Nil(t, err)
+	assert.Equal(t, firstResult, secondResult)
+	assert.NotEqual(t, fmt.Sprintf("%p", firstResult), fmt.Sprintf("%p", secondResult), "each call should return its own copy")
+
+	sm.Increment(common.MetricNonce)
+
+	thirdResult, err := sm.StatusMetricsMapWithoutP2P()
+	require.Nil(t, err)
+	assert.Equal(t, uint64(38), thirdResult[common.MetricNonce])
+	assert.Equal(t, uint64(37), firstResult[common.MetricNonce])
+
+	sm.Increment("missing key")
+	fourthResult, err := sm.StatusMetricsMapWithoutP2P()
+	require.Nil(t, err)
+	assert.Equal(t, thirdResult, fourthResult)
+}
+
+func TestStatusMetrics_StatusMetricsMapWithoutP2PShouldReturnACopy(t *testing.T) {
+	t.Parallel()
+
+	sm := statusHandler.NewStatusMetrics()
+	sm.SetUInt64Value(common.MetricNonce, 37)
+	sm.SetStringValue(common.MetricNodeType, "validator")
+
+	result, err := sm.StatusMetricsMapWithoutP2P()
+	require.Nil(t, err)
+	result[common.MetricNonce] = uint64(1)
+	result["injected key"] = "injected value"
+	delete(result, common.MetricNodeType)
+
+	nextResult, err := sm.StatusMetricsMapWithoutP2P()
+	require.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{
+		common.MetricNonce:    uint64(37),
+		common.MetricNodeType: "validator",
+	}, nextResult)
+}
+
+func TestStatusMetrics_Reset(t *testing.T) {
+	t.Parallel()
+
+	sm := statusHandler.NewStatusMetrics()
+	sm.SetUInt64Value(common.MetricNonce, 37)
+	sm.SetInt64Value("int64 key", -1)
+	sm.SetStringValue(common.MetricNodeType, "validator")
+	metricsBeforeReset, _ := sm.StatusMetricsMapWithoutP2P()
+	require.Equal(t, 3, len(metricsBeforeReset))
+
+	sm.Reset()
+
+	assert.Empty(t, sm.StatusMetricsMap())
+	metricsAfterReset, _ := sm.StatusMetricsMapWithoutP2P()
+	assert.Empty(t, metricsAfterReset)
+
+	sm.SetUInt64Value(common.MetricNonce, 1)
+	assert.Equal(t, map[string]interface{}{common.MetricNonce: uint64(1)}, sm.StatusMetricsMap())
+}
+
+func TestStatusMetrics_GetOrDefault(t *testing.T) {
+	t.Parallel()
+
+	t.Run("uint64 metric", func(t *testing.T) {
+		t.Parallel()
+
+		sm := statusHandler.NewStatusMetrics()
+		sm.SetUInt64Value(common.MetricNonce, 37)
+		sm.SetUInt64Value(common.MetricCurrentRound, 0)
+
+		assert.Equal(t, uint64(37), sm.GetUint64OrDefault(common.MetricNonce, 100))
+		assert.Equal(t, uint64(0), sm.GetUint64OrDefault(common.MetricCurrentRound, 100), "stored zero should be returned")
+		assert.Equal(t, uint64(100), sm.GetUint64OrDefault(common.MetricEpochNumber, 100))
+	})
+	t.Run("string metric", func(t *testing.T) {
+		t.Parallel()
+
+		sm := statusHandler.NewStatusMetrics()
+		sm.SetStringValue(common.MetricNodeType, "validator")
+		sm.SetStringValue(common.MetricAppVersion, "")
+
+		assert.Equal(t, "validator", sm.GetStringOrDefault(common.MetricNodeType, "N/A"))
+		assert.Equal(t, "", sm.GetStringOrDefault(common.MetricAppVersion, "N/A"), "stored empty string should be returned")
+		assert.Equal(t, "N/A", sm.GetStringOrDefault(common.MetricChainId, "N/A"))
+	})
+}
+
+func TestStatusMetrics_P2pMetricsByDirection(t *testing.T) {
+	t.Parallel()
+
+	sm := statusHandler.NewStatusMetrics()
+	sm.SetUInt64Value("erd_p2p_in_num_connections", 10)
+	sm.SetUInt64Value("erd_p2p_in_num_bytes", 2048)
+	sm.SetUInt64Value("erd_p2p_out_num_connections", 5)
+	sm.SetStringValue("erd_p2p_out_peer_info", "peer info")
+	sm.SetStringValue(common.MetricP2PPeerInfo, "peer info")
+	sm.SetUInt64Value(common.MetricP2PUnknownPeers, 3)
+	sm.SetUInt64Value(common.MetricNonce, 37)
+
+	inbound, outbound, neutral := sm.P2pMetricsByDirection()
+	assert.Equal(t, map[string]interface{}{
+		"erd_p2p_in_num_connections": uint64(10),
+		"erd_p2p_in_num_bytes":       uint64(2048),
+	}, inbound)
+	assert.Equal(t, map[string]interface{}{
+		"erd_p2p_out_num_connections": uint64(5),
+		"erd_p2p_out_peer_info":       "peer info",
+	}, outbound)
+	assert.Equal(t, map[string]interface{}{
+		common.MetricP2PPeerInfo:     "peer info",
+		common.MetricP2PUnknownPeers: uint64(3),
+	}, neutral)
+}
+
+func TestStatusMetrics_ApproxSizeBytes(t *testing.T) {
+	t.Parallel()
+
+	sm := statusHandler.NewStatusMetrics()
+	assert.Zero(t, sm.ApproxSizeBytes())
+
+	sm.SetUInt64Value("uint64", 1)
+	assert.Equal(t, len("uint64")+8, sm.ApproxSizeBytes())
+
+	sm.SetInt64Value("int64", -1)
+	assert.Equal(t, len("uint64")+len("int64")+16, sm.ApproxSizeBytes())
+
+	sm.SetStringValue("string", "value")
+	expectedSize := len("uint64") + len("int64") + 16 + len("string") + len("value")
+	assert.Equal(t, expectedSize, sm.ApproxSizeBytes())
+
+	sm.SetUInt64Value("uint64", 2)
+	assert.Equal(t, expectedSize, sm.ApproxSizeBytes(), "overwriting a metric should not grow the estimate")
+
+	sm.SetStringValue("another string", "another value")
+	assert.Greater(t, sm.ApproxSizeBytes(), expectedSize)
+}
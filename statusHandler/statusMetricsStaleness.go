@@ -0,0 +1,34 @@
+package statusHandler
+
+import (
+	"sort"
+	"time"
+)
+
+// MetricLastUpdate returns the moment when the provided metric was last written. The zero time is returned if the
+// metric was never written or was removed by Reset
+func (sm *statusMetrics) MetricLastUpdate(key string) time.Time {
+	writeInfo := sm.getMetricWriteInfo(key)
+	if writeInfo.version == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(0, writeInfo.lastWriteUnixNano)
+}
+
+// StaleMetrics returns the sorted keys of the stored metrics whose last write is older than the provided duration, so
+// the components that stopped reporting can be found. The metrics removed by Reset are not reported
+func (sm *statusMetrics) StaleMetrics(olderThan time.Duration) []string {
+	threshold := sm.getTimeHandler().Add(-olderThan).UnixNano()
+
+	staleMetrics := make([]string, 0)
+	for key, writeInfo := range sm.copyMetricWriteInfos() {
+		if writeInfo.lastWriteUnixNano < threshold {
+			staleMetrics = append(staleMetrics, key)
+		}
+	}
+
+	sort.Strings(staleMetrics)
+
+	return staleMetrics
+}
@@ -0,0 +1,67 @@
+package statusHandler_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-go/common"
+	"github.com/multiversx/mx-chain-go/statusHandler"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusMetrics_StaleMetrics(t *testing.T) {
+	t.Parallel()
+
+	startTime := time.Unix(1700000000, 0)
+	currentTime := startTime
+	sm := statusHandler.NewStatusMetrics()
+	sm.SetTimeHandler(func() time.Time {
+		return currentTime
+	})
+
+	assert.Empty(t, sm.StaleMetrics(time.Second))
+	assert.Equal(t, time.Time{}, sm.MetricLastUpdate(common.MetricNonce))
+
+	sm.SetUInt64Value(common.MetricNonce, 1)
+	sm.SetStringValue(common.MetricNodeType, "validator")
+	sm.SetInt64Value(common.MetricEpochNumber, 1)
+	assert.Equal(t, startTime, sm.MetricLastUpdate(common.MetricNonce))
+
+	currentTime = startTime.Add(time.Minute)
+	sm.Increment(common.MetricNonce)
+	assert.Equal(t, currentTime, sm.MetricLastUpdate(common.MetricNonce))
+
+	currentTime = startTime.Add(2 * time.Minute)
+	assert.Equal(t, []string{common.MetricEpochNumber, common.MetricNodeType}, sm.StaleMetrics(90*time.Second))
+	assert.Equal(t, []string{common.MetricEpochNumber, common.MetricNodeType, common.MetricNonce}, sm.StaleMetrics(30*time.Second))
+	assert.Empty(t, sm.StaleMetrics(3*time.Minute))
+
+	sm.SetMulti(map[string]interface{}{
+		common.MetricNodeType: "observer",
+	})
+	assert.Equal(t, []string{common.MetricEpochNumber, common.MetricNonce}, sm.StaleMetrics(30*time.Second))
+	assert.Equal(t, currentTime, sm.LastUpdate())
+}
+
+func TestStatusMetrics_StaleMetricsAfterReset(t *testing.T) {
+	t.Parallel()
+
+	startTime := time.Unix(1700000000, 0)
+	currentTime := startTime
+	sm := statusHandler.NewStatusMetrics()
+	sm.SetTimeHandler(func() time.Time {
+		return currentTime
+	})
+
+	sm.SetUInt64Value(common.MetricNonce, 1)
+	sm.SetStringValue(common.MetricNodeType, "validator")
+	sm.Reset()
+
+	currentTime = startTime.Add(time.Minute)
+	sm.SetStringValue(common.MetricNodeType, "observer")
+
+	currentTime = startTime.Add(2 * time.Minute)
+	assert.Equal(t, []string{common.MetricNodeType}, sm.StaleMetrics(30*time.Second))
+	assert.Equal(t, time.Time{}, sm.MetricLastUpdate(common.MetricNonce))
+	assert.Equal(t, startTime.Add(time.Minute), sm.MetricLastUpdate(common.MetricNodeType))
+}
@@ -0,0 +1,70 @@
+package statusHandler
+
+import (
+	"sync"
+	"time"
+)
+
+// MetricUpdate holds a metric change, as delivered on the channels opened with Stream
+type MetricUpdate struct {
+	Key       string
+	Value     interface{}
+	Timestamp time.Time
+}
+
+// Stream opens a stream of metric updates, returning the channel on which the updates are delivered and the function
+// that closes the stream. The updates are sent without blocking: when the consumer is slow and the buffer is full, the
+// update is dropped and counted (see NumDroppedUpdates). Reset does not produce updates
+func (sm *statusMetrics) Stream(buffer int) (<-chan MetricUpdate, func()) {
+	if buffer < 0 {
+		buffer = 0
+	}
+
+	updatesChan := make(chan MetricUpdate, buffer)
+
+	sm.mutStreams.Lock()
+	if sm.streams == nil {
+		sm.streams = make(map[chan MetricUpdate]struct{})
+	}
+	sm.streams[updatesChan] = struct{}{}
+	sm.mutStreams.Unlock()
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			sm.mutStreams.Lock()
+			delete(sm.streams, updatesChan)
+			close(updatesChan)
+			sm.mutStreams.Unlock()
+		})
+	}
+
+	return updatesChan, cancel
+}
+
+// NumDroppedUpdates returns the number of metric updates that were not delivered because of slow stream consumers
+func (sm *statusMetrics) NumDroppedUpdates() uint64 {
+	return sm.numDroppedUpdates.GetUint64()
+}
+
+func (sm *statusMetrics) publishUpdate(key string, value interface{}) {
+	sm.mutStreams.RLock()
+	defer sm.mutStreams.RUnlock()
+
+	if len(sm.streams) == 0 {
+		return
+	}
+
+	update := MetricUpdate{
+		Key:       key,
+		Value:     value,
+		Timestamp: sm.getTimeHandler(),
+	}
+	for updatesChan := range sm.streams {
+		select {
+		case updatesChan <- update:
+		default:
+			sm.numDroppedUpdates.Increment()
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package statusHandler_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-go/common"
+	"github.com/multiversx/mx-chain-go/statusHandler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusMetrics_Stream(t *testing.T) {
+	t.Parallel()
+
+	t.Run("updates should arrive and cancel should stop the stream", func(t *testing.T) {
+		t.Parallel()
+
+		sm := statusHandler.NewStatusMetrics()
+		updates, cancel := sm.Stream(10)
+
+		sm.SetUInt64Value(common.MetricNonce, 37)
+		sm.Increment(common.MetricNonce)
+		sm.SetStringValue(common.MetricNodeType, "validator")
+		sm.SetInt64Value(common.MetricEpochNumber, -1)
+		sm.Increment("missing key")
+
+		expectedUpdates := []statusHandler.MetricUpdate{
+			{Key: common.MetricNonce, Value: uint64(37)},
+			{Key: common.MetricNonce, Value: uint64(38)},
+			{Key: common.MetricNodeType, Value: "validator"},
+			{Key: common.MetricEpochNumber, Value: int64(-1)},
+		}
+		for _, expectedUpdate := range expectedUpdates {
+			select {
+			case update := <-updates:
+				assert.Equal(t, expectedUpdate.Key, update.Key)
+				assert.Equal(t, expectedUpdate.Value, update.Value)
+				assert.False(t, update.Timestamp.IsZero())
+			case <-time.After(time.Second):
+				require.Fail(t, "timeout waiting for the update of "+expectedUpdate.Key)
+			}
+		}
+
+		cancel()
+		cancel() // second call should not panic
+		sm.SetUInt64Value(common.MetricNonce, 39)
+
+		_, isOpen := <-updates
+		assert.False(t, isOpen)
+		assert.Zero(t, sm.NumDroppedUpdates())
+	})
+	t.Run("updates should be timestamped by the time handler", func(t *testing.T) {
+		t.Parallel()
+
+		providedTime := time.Unix(1700000000, 0)
+		sm := statusHandler.NewStatusMetrics()
+		sm.SetTimeHandler(func() time.Time {
+			return providedTime
+		})
+		updates, cancel := sm.Stream(1)
+		defer cancel()
+
+		sm.SetUInt64Value(common.MetricNonce, 1)
+
+		update := <-updates
+		assert.Equal(t, providedTime, update.Timestamp)
+	})
+	t.Run("slow consumer should not block and the dropped updates are counted", func(t *testing.T) {
+		t.Parallel()
+
+		sm := statusHandler.NewStatusMetrics()
+		updates, cancel := sm.Stream(1)
+		defer cancel()
+
+		sm.SetUInt64Value(common.MetricNonce, 1)
+		sm.SetUInt64Value(common.MetricNonce, 2)
+		sm.SetMulti(map[string]interface{}{
+			common.MetricCurrentRound: uint64(3),
+			common.MetricNodeType:     "observer",
+		})
+
+		update := <-updates
+		assert.Equal(t, uint64(1), update.Value)
+		assert.Equal(t, uint64(3), sm.NumDroppedUpdates())
+	})
+	t.Run("negative buffer should open an unbuffered stream", func(t *testing.T) {
+		t.Parallel()
+
+		sm := statusHandler.NewStatusMetrics()
+		updates, cancel := sm.Stream(-1)
+		defer cancel()
+
+		assert.Zero(t, cap(updates))
+
+		sm.SetUInt64Value(common.MetricNonce, 1)
+		assert.Equal(t, uint64(1), sm.NumDroppedUpdates())
+	})
+}
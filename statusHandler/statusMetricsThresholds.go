@@ -0,0 +1,93 @@
+package statusHandler
+
+// Direction defines the way a metric crosses a threshold
+type Direction int
+
+const (
+	// CrossingUp signals that the metric went from below the threshold to a value greater than or equal to it
+	CrossingUp Direction = iota
+	// CrossingDown signals that the metric went from a value greater than or equal to the threshold to below it
+	CrossingDown
+)
+
+type thresholdWatcher struct {
+	threshold     uint64
+	direction     Direction
+	callback      func(value uint64)
+	hasValue      bool
+	isAboveOrOnIt bool
+}
+
+// RegisterThreshold registers a callback called whenever the uint64 metric crosses the threshold in the provided direction.
+// Repeated changes on the same side of the threshold do not trigger the callback again, the metric having to cross the
+// threshold back before a new notification. The first value observed for the metric does not count as a crossing.
+// The callback is called synchronously, after the metric was updated, so it should not block
+func (sm *statusMetrics) RegisterThreshold(key string, threshold uint64, direction Direction, cb func(value uint64)) {
+	if cb == nil {
+		return
+	}
+
+	watcher := &thresholdWatcher{
+		threshold: threshold,
+		direction: direction,
+		callback:  cb,
+	}
+
+	// the current value is read and the watcher is registered under the same lock, so a concurrent write is either
+	// observed as the initial value or checked against the registered watcher
+	sm.mutUint64Operations.RLock()
+	defer sm.mutUint64Operations.RUnlock()
+
+	value, found := sm.uint64Metrics[key]
+	if found {
+		watcher.hasValue = true
+		watcher.isAboveOrOnIt = value >= threshold
+	}
+
+	sm.mutThresholds.Lock()
+	if sm.thresholds == nil {
+		sm.thresholds = make(map[string][]*thresholdWatcher)
+	}
+	sm.thresholds[key] = append(sm.thresholds[key], watcher)
+	sm.numThresholdWatchers.Increment()
+	sm.mutThresholds.Unlock()
+}
+
+// checkThresholds notifies the watchers of the provided metric whose threshold was crossed. It is called on each uint64
+// metric update, so it returns without locking when no watcher was registered at all
+func (sm *statusMetrics) checkThresholds(key string, value uint64) {
+	if sm.numThresholdWatchers.Get() == 0 {
+		return
+	}
+
+	sm.mutThresholds.Lock()
+	watchers := sm.thresholds[key]
+	if len(watchers) == 0 {
+		sm.mutThresholds.Unlock()
+		return
+	}
+
+	var callbacks []func(value uint64)
+	for _, watcher := range watchers {
+		isAboveOrOnIt := value >= watcher.threshold
+		wasAboveOrOnIt := watcher.isAboveOrOnIt
+		hadValue := watcher.hasValue
+		watcher.hasValue = true
+		watcher.isAboveOrOnIt = isAboveOrOnIt
+
+		if !hadValue || wasAboveOrOnIt == isAboveOrOnIt {
+			continue
+		}
+
+		crossedUp := isAboveOrOnIt && watcher.direction == CrossingUp
+		crossedDown := !isAboveOrOnIt && watcher.direction == CrossingDown
+		if crossedUp || crossedDown {
+			callbacks = append(callbacks, watcher.callback)
+		}
+	}
+	sm.mutThresholds.Unlock()
+
+	for _, callback := range callbacks {
+		callback(value)
+	}
+}
@@ -0,0 +1,120 @@
+package statusHandler_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/multiversx/mx-chain-go/common"
+	"github.com/multiversx/mx-chain-go/statusHandler"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusMetrics_RegisterThreshold(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil callback should not register", func(t *testing.T) {
+		t.Parallel()
+
+		sm := statusHandler.NewStatusMetrics()
+		sm.RegisterThreshold(common.MetricNumConnectedPeers, 5, statusHandler.CrossingDown, nil)
+		assert.Equal(t, int64(0), sm.NumThresholdWatchers())
+
+		assert.NotPanics(t, func() {
+			sm.SetUInt64Value(common.MetricNumConnectedPeers, 10)
+			sm.SetUInt64Value(common.MetricNumConnectedPeers, 1)
+		})
+	})
+	t.Run("crossing down", func(t *testing.T) {
+		t.Parallel()
+
+		sm := statusHandler.NewStatusMetrics()
+		sm.SetUInt64Value(common.MetricNumConnectedPeers, 10)
+
+		notifiedValues := make([]uint64, 0)
+		sm.RegisterThreshold(common.MetricNumConnectedPeers, 5, statusHandler.CrossingDown, func(value uint64) {
+			notifiedValues = append(notifiedValues, value)
+		})
+		assert.Equal(t, int64(1), sm.NumThresholdWatchers())
+
+		sm.SetUInt64Value(common.MetricNumConnectedPeers, 6)
+		sm.Decrement(common.MetricNumConnectedPeers) // 5, still on the threshold
+		assert.Empty(t, notifiedValues)
+
+		sm.Decrement(common.MetricNumConnectedPeers) // 4, crossed down
+		assert.Equal(t, []uint64{4}, notifiedValues)
+
+		sm.Decrement(common.MetricNumConnectedPeers) // 3, same side, debounced
+		sm.SetUInt64Value(common.MetricNumConnectedPeers, 2)
+		assert.Equal(t, []uint64{4}, notifiedValues)
+
+		sm.AddUint64(common.MetricNumConnectedPeers, 10) // 12, crossed up, not watched
+		assert.Equal(t, []uint64{4}, notifiedValues)
+
+		sm.SetMulti(map[string]interface{}{common.MetricNumConnectedPeers: uint64(0)}) // crossed down again
+		assert.Equal(t, []uint64{4, 0}, notifiedValues)
+	})
+	t.Run("crossing up", func(t *testing.T) {
+		t.Parallel()
+
+		sm := statusHandler.NewStatusMetrics()
+
+		notifiedValues := make([]uint64, 0)
+		sm.RegisterThreshold(common.MetricNonce, 3, statusHandler.CrossingUp, func(value uint64) {
+			notifiedValues = append(notifiedValues, value)
+		})
+
+		sm.SetUInt64Value(common.MetricNonce, 1) // first value, not a crossing
+		sm.Increment(common.MetricNonce)
+		assert.Empty(t, notifiedValues)
+
+		sm.Increment(common.MetricNonce) // 3, crossed up
+		assert.Equal(t, []uint64{3}, notifiedValues)
+
+		sm.Increment(common.MetricNonce) // 4, same side, debounced
+		assert.Equal(t, []uint64{3}, notifiedValues)
+
+		sm.SetUInt64Value(common.MetricNonce, 0) // crossed down, not watched
+		sm.SetUInt64Value(common.MetricNonce, 100)
+		assert.Equal(t, []uint64{3, 100}, notifiedValues)
+	})
+	t.Run("callback can read the metrics", func(t *testing.T) {
+		t.Parallel()
+
+		sm := statusHandler.NewStatusMetrics()
+		sm.SetUInt64Value(common.MetricNonce, 0)
+
+		readValue := uint64(0)
+		sm.RegisterThreshold(common.MetricNonce, 1, statusHandler.CrossingUp, func(value uint64) {
+			readValue = sm.GetUint64OrDefault(common.MetricNonce, 0)
+		})
+
+		sm.Increment(common.MetricNonce)
+		assert.Equal(t, uint64(1), readValue)
+	})
+	t.Run("registration concurrent with a write should not miss the next crossing", func(t *testing.T) {
+		t.Parallel()
+
+		for i := 0; i < 100; i++ {
+			sm := statusHandler.NewStatusMetrics()
+
+			numCalls := uint32(0)
+			wg := sync.WaitGroup{}
+			wg.Add(2)
+			go func() {
+				sm.SetUInt64Value(common.MetricNonce, 10)
+				wg.Done()
+			}()
+			go func() {
+				sm.RegisterThreshold(common.MetricNonce, 5, statusHandler.CrossingDown, func(value uint64) {
+					atomic.AddUint32(&numCalls, 1)
+				})
+				wg.Done()
+			}()
+			wg.Wait()
+
+			sm.SetUInt64Value(common.MetricNonce, 2)
+			assert.Equal(t, uint32(1), atomic.LoadUint32(&numCalls))
+		}
+	})
+}
@@ -0,0 +1,50 @@
+package statusHandler
+
+import "fmt"
+
+// Metric value types reported by MetricsTyped
+const (
+	metricTypeUint64  = "uint64"
+	metricTypeInt64   = "int64"
+	metricTypeString  = "string"
+	metricTypeFloat64 = "float64"
+)
+
+// TypedMetric holds the value of a metric along with its Go type name, so API consumers do not have to guess it
+type TypedMetric struct {
+	Value interface{} `json:"value"`
+	Type  string      `json:"type"`
+}
+
+// MetricsTyped returns all the stored metrics, each one with its value and its type: "uint64", "int64", "string" or
+// "float64". The values of any other type are reported with their Go type name
+func (sm *statusMetrics) MetricsTyped() map[string]TypedMetric {
+	metrics := sm.getMetricsWithKeyFilterMutexProtected(func(_ string) bool {
+		return true
+	})
+
+	typedMetrics := make(map[string]TypedMetric, len(metrics))
+	for key, value := range metrics {
+		typedMetrics[key] = TypedMetric{
+			Value: value,
+			Type:  metricValueType(value),
+		}
+	}
+
+	return typedMetrics
+}
+
+func metricValueType(value interface{}) string {
+	switch value.(type) {
+	case uint64:
+		return metricTypeUint64
+	case int64:
+		return metricTypeInt64
+	case string:
+		return metricTypeString
+	case float64:
+		return metricTypeFloat64
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
@@ -0,0 +1,52 @@
+package statusHandler_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/multiversx/mx-chain-go/common"
+	"github.com/multiversx/mx-chain-go/statusHandler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusMetrics_MetricsTyped(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no metrics should return empty", func(t *testing.T) {
+		t.Parallel()
+
+		sm := statusHandler.NewStatusMetrics()
+		assert.Empty(t, sm.MetricsTyped())
+	})
+	t.Run("each metric should be tagged with its type", func(t *testing.T) {
+		t.Parallel()
+
+		sm := statusHandler.NewStatusMetrics()
+		sm.SetUInt64Value(common.MetricNonce, 37)
+		sm.SetInt64Value(common.MetricEpochNumber, -1)
+		sm.SetStringValue(common.MetricNodeType, "validator")
+
+		expectedMetrics := map[string]statusHandler.TypedMetric{
+			common.MetricNonce:       {Value: uint64(37), Type: "uint64"},
+			common.MetricEpochNumber: {Value: int64(-1), Type: "int64"},
+			common.MetricNodeType:    {Value: "validator", Type: "string"},
+		}
+		typedMetrics := sm.MetricsTyped()
+		assert.Equal(t, expectedMetrics, typedMetrics)
+
+		buff, err := json.Marshal(typedMetrics[common.MetricNonce])
+		require.Nil(t, err)
+		assert.Equal(t, `{"value":37,"type":"uint64"}`, string(buff))
+	})
+}
+
+func TestMetricValueType(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "uint64", statusHandler.MetricValueType(uint64(1)))
+	assert.Equal(t, "int64", statusHandler.MetricValueType(int64(-1)))
+	assert.Equal(t, "string", statusHandler.MetricValueType("value"))
+	assert.Equal(t, "float64", statusHandler.MetricValueType(1.5))
+	assert.Equal(t, "bool", statusHandler.MetricValueType(true))
+}
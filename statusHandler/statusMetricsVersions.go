@@ -0,0 +1,81 @@
+package statusHandler
+
+// metricWriteInfo holds the number of writes and the last write moment of a metric
+type metricWriteInfo struct {
+	version           uint64
+	lastWriteUnixNano int64
+}
+
+// merge combines the write info of a key stored in more than one metrics map
+func (info metricWriteInfo) merge(other metricWriteInfo) metricWriteInfo {
+	info.version += other.version
+	if other.lastWriteUnixNano > info.lastWriteUnixNano {
+		info.lastWriteUnixNano = other.lastWriteUnixNano
+	}
+
+	return info
+}
+
+// MetricVersion returns the number of writes of the provided metric, regardless of the written value, so unexpectedly
+// frequent overwrites of a metric can be detected. Zero is returned for a metric that was never written. The versions
+// are removed together with the metrics by Reset and UnmarshalBinary
+func (sm *statusMetrics) MetricVersion(key string) uint64 {
+	return sm.getMetricWriteInfo(key).version
+}
+
+// markMetricWritten increments the version and records the last write moment of the provided metric. It should be
+// called while holding the write lock of the metrics map the provided write infos belong to, so the write infos are
+// bounded by the stored metrics
+func markMetricWritten(writeInfos map[string]metricWriteInfo, key string, writeUnixNano int64) {
+	writeInfo := writeInfos[key]
+	writeInfo.version++
+	writeInfo.lastWriteUnixNano = writeUnixNano
+	writeInfos[key] = writeInfo
+}
+
+func (sm *statusMetrics) getMetricWriteInfo(key string) metricWriteInfo {
+	sm.mutUint64Operations.RLock()
+	writeInfo := sm.uint64WriteInfos[key]
+	sm.mutUint64Operations.RUnlock()
+
+	sm.mutInt64Operations.RLock()
+	writeInfo = writeInfo.merge(sm.int64WriteInfos[key])
+	sm.mutInt64Operations.RUnlock()
+
+	sm.mutStringOperations.RLock()
+	writeInfo = writeInfo.merge(sm.stringWriteInfos[key])
+	sm.mutStringOperations.RUnlock()
+
+	return writeInfo
+}
+
+func (sm *statusMetrics) copyMetricWriteInfos() map[string]metricWriteInfo {
+	writeInfos := make(map[string]metricWriteInfo)
+
+	sm.mutUint64Operations.RLock()
+	for key, writeInfo := range sm.uint64WriteInfos {
+		writeInfos[key] = writeInfos[key].merge(writeInfo)
+	}
+	sm.mutUint64Operations.RUnlock()
+
+	sm.mutInt64Operations.RLock()
+	for key, writeInfo := range sm.int64WriteInfos {
+		writeInfos[key] = writeInfos[key].merge(writeInfo)
+	}
+	sm.mutInt64Operations.RUnlock()
+
+	sm.mutStringOperations.RLock()
+	for key, writeInfo := range sm.stringWriteInfos {
+		writeInfos[key] = writeInfos[key].merge(writeInfo)
+	}
+	sm.mutStringOperations.RUnlock()
+
+	return writeInfos
+}
+
+// resetMetricWriteInfos should be called while holding the write locks of all the metrics maps
+func (sm *statusMetrics) resetMetricWriteInfos() {
+	sm.uint64WriteInfos = make(map[string]metricWriteInfo)
+	sm.stringWriteInfos = make(map[string]metricWriteInfo)
+	sm.int64WriteInfos = make(map[string]metricWriteInfo)
+}
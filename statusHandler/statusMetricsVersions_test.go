@@ -0,0 +1,106 @@
+package statusHandler_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/multiversx/mx-chain-go/common"
+	"github.com/multiversx/mx-chain-go/statusHandler"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusMetrics_MetricVersion(t *testing.T) {
+	t.Parallel()
+
+	t.Run("never written metric should return zero", func(t *testing.T) {
+		t.Parallel()
+
+		sm := statusHandler.NewStatusMetrics()
+		assert.Equal(t, uint64(0), sm.MetricVersion(common.MetricNonce))
+
+		sm.Increment(common.MetricNonce)
+		assert.Equal(t, uint64(0), sm.MetricVersion(common.MetricNonce))
+	})
+	t.Run("version should increment on each write", func(t *testing.T) {
+		t.Parallel()
+
+		sm := statusHandler.NewStatusMetrics()
+
+		sm.SetUInt64Value(common.MetricNonce, 10)
+		assert.Equal(t, uint64(1), sm.MetricVersion(common.MetricNonce))
+
+		sm.SetUInt64Value(common.MetricNonce, 10)
+		assert.Equal(t, uint64(2), sm.MetricVersion(common.MetricNonce))
+
+		sm.Increment(common.MetricNonce)
+		sm.AddUint64(common.MetricNonce, 5)
+		sm.Decrement(common.MetricNonce)
+		assert.Equal(t, uint64(5), sm.MetricVersion(common.MetricNonce))
+
+		sm.SetMulti(map[string]interface{}{
+			common.MetricNonce:       uint64(1),
+			common.MetricNodeType:    "observer",
+			common.MetricEpochNumber: int64(3),
+		})
+		assert.Equal(t, uint64(6), sm.MetricVersion(common.MetricNonce))
+		assert.Equal(t, uint64(1), sm.MetricVersion(common.MetricNodeType))
+		assert.Equal(t, uint64(1), sm.MetricVersion(common.MetricEpochNumber))
+
+		sm.SetStringValue(common.MetricNodeType, "validator")
+		sm.SetInt64Value(common.MetricEpochNumber, 4)
+		assert.Equal(t, uint64(2), sm.MetricVersion(common.MetricNodeType))
+		assert.Equal(t, uint64(2), sm.MetricVersion(common.MetricEpochNumber))
+	})
+	t.Run("reset should clear the versions", func(t *testing.T) {
+		t.Parallel()
+
+		sm := statusHandler.NewStatusMetrics()
+		sm.SetUInt64Value(common.MetricNonce, 10)
+		sm.SetStringValue(common.MetricNodeType, "validator")
+		sm.Reset()
+		assert.Equal(t, uint64(0), sm.MetricVersion(common.MetricNonce))
+		assert.Equal(t, uint64(0), sm.MetricVersion(common.MetricNodeType))
+
+		sm.SetUInt64Value(common.MetricNonce, 10)
+		assert.Equal(t, uint64(1), sm.MetricVersion(common.MetricNonce))
+	})
+	t.Run("rejected metrics should not be versioned", func(t *testing.T) {
+		t.Parallel()
+
+		sm := statusHandler.NewStatusMetrics()
+		sm.SetMaxNumMetrics(1)
+		sm.SetUInt64Value(common.MetricNonce, 10)
+		sm.SetStringValue(common.MetricNodeType, "validator")
+		sm.SetMulti(map[string]interface{}{
+			common.MetricEpochNumber: int64(3),
+		})
+		assert.Equal(t, uint64(1), sm.MetricVersion(common.MetricNonce))
+		assert.Equal(t, uint64(0), sm.MetricVersion(common.MetricNodeType))
+		assert.Equal(t, uint64(0), sm.MetricVersion(common.MetricEpochNumber))
+	})
+	t.Run("same key stored with different types should count all the writes", func(t *testing.T) {
+		t.Parallel()
+
+		sm := statusHandler.NewStatusMetrics()
+		sm.SetUInt64Value(common.MetricNonce, 10)
+		sm.SetStringValue(common.MetricNonce, "10")
+		assert.Equal(t, uint64(2), sm.MetricVersion(common.MetricNonce))
+	})
+	t.Run("concurrent writes should all be counted", func(t *testing.T) {
+		t.Parallel()
+
+		sm := statusHandler.NewStatusMetrics()
+		numWrites := 100
+		wg := sync.WaitGroup{}
+		wg.Add(numWrites)
+		for i := 0; i < numWrites; i++ {
+			go func(value int) {
+				sm.SetUInt64Value(common.MetricNonce, uint64(value))
+				wg.Done()
+			}(i)
+		}
+		wg.Wait()
+
+		assert.Equal(t, uint64(numWrites), sm.MetricVersion(common.MetricNonce))
+	})
+}
@@ -0,0 +1,35 @@
+package testscommon
+
+import (
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-go/common"
+)
+
+// LogsConverterStub -
+type LogsConverterStub struct {
+	TxLogToApiResourceCalled    func(logKey []byte, log *transaction.Log) *transaction.ApiLogs
+	TxLogToApiLogsDetailsCalled func(logKey []byte, log *transaction.Log) *common.ApiLogsDetails
+}
+
+// TxLogToApiResource -
+func (stub *LogsConverterStub) TxLogToApiResource(logKey []byte, log *transaction.Log) *transaction.ApiLogs {
+	if stub.TxLogToApiResourceCalled != nil {
+		return stub.TxLogToApiResourceCalled(logKey, log)
+	}
+
+	return nil
+}
+
+// TxLogToApiLogsDetails -
+func (stub *LogsConverterStub) TxLogToApiLogsDetails(logKey []byte, log *transaction.Log) *common.ApiLogsDetails {
+	if stub.TxLogToApiLogsDetailsCalled != nil {
+		return stub.TxLogToApiLogsDetailsCalled(logKey, log)
+	}
+
+	return nil
+}
+
+// IsInterfaceNil -
+func (stub *LogsConverterStub) IsInterfaceNil() bool {
+	return stub == nil
+}
@@ -2,11 +2,13 @@ package testscommon
 
 import (
 	"github.com/multiversx/mx-chain-core-go/data/transaction"
+	"github.com/multiversx/mx-chain-go/common"
 )
 
 // LogsFacadeStub -
 type LogsFacadeStub struct {
 	GetLogCalled                    func(txHash []byte, epoch uint32) (*transaction.ApiLogs, error)
+	GetLogDetailsCalled             func(logKey []byte, epoch uint32) (*common.ApiLogsDetails, error)
 	IncludeLogsInTransactionsCalled func(txs []*transaction.ApiTransactionResult, logsKeys [][]byte, epoch uint32) error
 }
 
@@ -19,6 +21,15 @@ func (stub *LogsFacadeStub) GetLog(logKey []byte, epoch uint32) (*transaction.Ap
 	return nil, nil
 }
 
+// GetLogDetails -
+func (stub *LogsFacadeStub) GetLogDetails(logKey []byte, epoch uint32) (*common.ApiLogsDetails, error) {
+	if stub.GetLogDetailsCalled != nil {
+		return stub.GetLogDetailsCalled(logKey, epoch)
+	}
+
+	return nil, nil
+}
+
 // IncludeLogsInTransactions -
 func (stub *LogsFacadeStub) IncludeLogsInTransactions(txs []*transaction.ApiTransactionResult, logsKeys [][]byte, epoch uint32) error {
 	if stub.IncludeLogsInTransactionsCalled != nil {
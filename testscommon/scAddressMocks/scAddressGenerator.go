@@ -0,0 +1,39 @@
+package scAddressMocks
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+)
+
+const mockSCAddressLen = 32
+
+// ErrInvalidVMTypeLength signals that the provided vm type does not have the expected length
+var ErrInvalidVMTypeLength = errors.New("invalid vm type length")
+
+// ErrShardIDDoesNotFitInAByte signals that the provided shard ID can not be carried by the last byte of the address
+var ErrShardIDDoesNotFitInAByte = errors.New("shard ID does not fit in a byte")
+
+// GenerateMockSCAddress generates a well-formed smart contract address carrying the provided vm type, ending in the
+// provided shard identifier. The shard identifier is carried by the last byte of the address, so the shard IDs above
+// 255 (including the metachain shard ID) are rejected
+func GenerateMockSCAddress(vmType []byte, shardID uint32) ([]byte, error) {
+	if len(vmType) != core.VMTypeLen {
+		return nil, fmt.Errorf("%w, expected %d, got %d", ErrInvalidVMTypeLength, core.VMTypeLen, len(vmType))
+	}
+	if shardID > math.MaxUint8 {
+		return nil, fmt.Errorf("%w: %d", ErrShardIDDoesNotFitInAByte, shardID)
+	}
+
+	address := make([]byte, mockSCAddressLen)
+	copy(address[core.NumInitCharactersForScAddress-core.VMTypeLen:core.NumInitCharactersForScAddress], vmType)
+	for i := core.NumInitCharactersForScAddress; i < mockSCAddressLen-core.ShardIdentiferLen; i++ {
+		// non-zero filler so the generated address will not be the empty address
+		address[i] = byte(i)
+	}
+	address[mockSCAddressLen-1] = byte(shardID)
+
+	return address, nil
+}
@@ -0,0 +1,44 @@
+package scAddressMocks
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateMockSCAddress(t *testing.T) {
+	t.Parallel()
+
+	t.Run("wrong vm type length should error", func(t *testing.T) {
+		t.Parallel()
+
+		address, err := GenerateMockSCAddress([]byte{5}, 0)
+		assert.Nil(t, address)
+		assert.True(t, errors.Is(err, ErrInvalidVMTypeLength))
+	})
+	t.Run("shard ID not fitting in a byte should error", func(t *testing.T) {
+		t.Parallel()
+
+		address, err := GenerateMockSCAddress([]byte{5, 0}, 256)
+		assert.Nil(t, address)
+		assert.True(t, errors.Is(err, ErrShardIDDoesNotFitInAByte))
+
+		address, err = GenerateMockSCAddress([]byte{5, 0}, core.MetachainShardId)
+		assert.Nil(t, address)
+		assert.True(t, errors.Is(err, ErrShardIDDoesNotFitInAByte))
+	})
+	t.Run("should generate a smart contract address", func(t *testing.T) {
+		t.Parallel()
+
+		address, err := GenerateMockSCAddress([]byte{5, 0}, 255)
+		require.Nil(t, err)
+
+		assert.True(t, core.IsSmartContractAddress(address))
+		assert.False(t, core.IsEmptyAddress(address))
+		assert.Equal(t, []byte{5, 0}, address[core.NumInitCharactersForScAddress-core.VMTypeLen:core.NumInitCharactersForScAddress])
+		assert.Equal(t, byte(255), address[len(address)-1])
+	})
+}
@@ -1,6 +1,9 @@
 package update
 
 import (
+	"bytes"
+	"fmt"
+
 	"github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-core-go/core/check"
 	"github.com/multiversx/mx-chain-core-go/data"
@@ -35,6 +38,10 @@ type ArgsHardForkProcessor struct {
 	MapBodies                 map[uint32]*block.Body
 	MapHardForkBlockProcessor map[uint32]HardForkBlockProcessor
 	PostMbs                   []*MbInfo
+	VerifyTxsHashes           bool
+	// SkipMissingBlockProcessors allows partial imports: the shards without a hardfork block processor are skipped
+	// instead of failing the whole processing
+	SkipMissingBlockProcessors bool
 }
 
 // GetPendingMiniBlocks get all the pending miniBlocks from epoch start metaBlock and unFinished metaBlocks
@@ -70,6 +77,76 @@ func GetPendingMiniBlocks(
 	return pendingMiniBlocks, nil
 }
 
+// GetNumPendingMiniBlocksPerSenderShard returns the number of pending miniBlocks, from epoch start metaBlock and
+// unFinished metaBlocks, grouped by their sender shard
+func GetNumPendingMiniBlocksPerSenderShard(
+	epochStartMetaBlock data.MetaHeaderHandler,
+	unFinishedMetaBlocksMap map[string]data.MetaHeaderHandler,
+) (map[uint32]int, error) {
+	pendingMiniBlocks, err := GetPendingMiniBlocks(epochStartMetaBlock, unFinishedMetaBlocksMap)
+	if err != nil {
+		return nil, err
+	}
+
+	numPendingMiniBlocksPerSenderShard := make(map[uint32]int)
+	for _, pendingMiniBlock := range pendingMiniBlocks {
+		numPendingMiniBlocksPerSenderShard[pendingMiniBlock.GetSenderShardID()]++
+	}
+
+	return numPendingMiniBlocksPerSenderShard, nil
+}
+
+// GetPendingMiniBlocksByType returns the pending miniBlocks, from epoch start metaBlock and unFinished metaBlocks,
+// grouped by their type
+func GetPendingMiniBlocksByType(
+	epochStartMetaBlock data.MetaHeaderHandler,
+	unFinishedMetaBlocksMap map[string]data.MetaHeaderHandler,
+) (map[block.Type][]data.MiniBlockHeaderHandler, error) {
+	pendingMiniBlocks, err := GetPendingMiniBlocks(epochStartMetaBlock, unFinishedMetaBlocksMap)
+	if err != nil {
+		return nil, err
+	}
+
+	pendingMiniBlocksByType := make(map[block.Type][]data.MiniBlockHeaderHandler)
+	for _, pendingMiniBlock := range pendingMiniBlocks {
+		mbType := block.Type(pendingMiniBlock.GetTypeInt32())
+		pendingMiniBlocksByType[mbType] = append(pendingMiniBlocksByType[mbType], pendingMiniBlock)
+	}
+
+	return pendingMiniBlocksByType, nil
+}
+
+// GetPendingMiniBlocksOfTypes returns the pending miniBlocks, from epoch start metaBlock and unFinished metaBlocks,
+// which have one of the provided types. If no type is provided, all the pending miniBlocks are returned
+func GetPendingMiniBlocksOfTypes(
+	epochStartMetaBlock data.MetaHeaderHandler,
+	unFinishedMetaBlocksMap map[string]data.MetaHeaderHandler,
+	types ...block.Type,
+) ([]data.MiniBlockHeaderHandler, error) {
+	pendingMiniBlocks, err := GetPendingMiniBlocks(epochStartMetaBlock, unFinishedMetaBlocksMap)
+	if err != nil {
+		return nil, err
+	}
+	if len(types) == 0 {
+		return pendingMiniBlocks, nil
+	}
+
+	allowedTypes := make(map[block.Type]struct{}, len(types))
+	for _, mbType := range types {
+		allowedTypes[mbType] = struct{}{}
+	}
+
+	filteredPendingMiniBlocks := make([]data.MiniBlockHeaderHandler, 0, len(pendingMiniBlocks))
+	for _, pendingMiniBlock := range pendingMiniBlocks {
+		_, isAllowed := allowedTypes[block.Type(pendingMiniBlock.GetTypeInt32())]
+		if isAllowed {
+			filteredPendingMiniBlocks = append(filteredPendingMiniBlocks, pendingMiniBlock)
+		}
+	}
+
+	return filteredPendingMiniBlocks, nil
+}
+
 // createNonceToHashMap creates a map of nonce to hash from all the given metaBlocks
 func createNonceToHashMap(unFinishedMetaBlocks map[string]data.MetaHeaderHandler) map[uint64]string {
 	nonceToHashMap := make(map[uint64]string, len(unFinishedMetaBlocks))
@@ -90,9 +167,17 @@ func computePendingMiniBlocksFromUnFinishedMetaBlocks(
 	pendingMiniBlocks := make([]data.MiniBlockHeaderHandler, 0)
 	pendingMiniBlocks = append(pendingMiniBlocks, epochStartShardData.GetPendingMiniBlockHeaderHandlers()...)
 
-	firstPendingMetaBlock, ok := unFinishedMetaBlocks[string(epochStartShardData.GetFirstPendingMetaBlock())]
+	firstPendingMetaBlockHash := epochStartShardData.GetFirstPendingMetaBlock()
+	firstPendingMetaBlock, ok := unFinishedMetaBlocks[string(firstPendingMetaBlockHash)]
 	if !ok {
-		return nil, ErrWrongUnFinishedMetaHdrsMap
+		// otherwise, the pending miniBlocks of the shard would be silently omitted
+		log.Error("first pending metaBlock not found in the unFinished metaBlocks",
+			"shard", epochStartShardData.GetShardID(),
+			"first pending metaBlock hash", firstPendingMetaBlockHash,
+		)
+
+		return nil, fmt.Errorf("%w: first pending metaBlock %x of shard %d is missing",
+			ErrWrongUnFinishedMetaHdrsMap, firstPendingMetaBlockHash, epochStartShardData.GetShardID())
 	}
 
 	firstUnFinishedMetaBlockNonce := firstPendingMetaBlock.GetNonce()
@@ -143,13 +228,20 @@ func getAllMiniBlocksWithDst(metaBlock data.MetaHeaderHandler, destShardID uint3
 
 // CreateBody will create a block body after hardfork import
 func CreateBody(args ArgsHardForkProcessor) ([]*MbInfo, error) {
+	err := checkShardIDs(args.ShardIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	shardIDs, err := getShardIDsWithBlockProcessor(args)
+	if err != nil {
+		return nil, err
+	}
+
+	args.ShardIDs = shardIDs
 	allPostMbs := make([]*MbInfo, 0)
 	for _, shardID := range args.ShardIDs {
-		hardForkBlockProcessor, ok := args.MapHardForkBlockProcessor[shardID]
-		if !ok {
-			return nil, ErrNilHardForkBlockProcessor
-		}
-
+		hardForkBlockProcessor := args.MapHardForkBlockProcessor[shardID]
 		body, postMbs, err := hardForkBlockProcessor.CreateBody()
 		if err != nil {
 			return nil, err
@@ -165,22 +257,35 @@ func CreateBody(args ArgsHardForkProcessor) ([]*MbInfo, error) {
 	}
 
 	args.PostMbs = allPostMbs
-	return CleanDuplicates(args)
+	cleanedPostMbs, stats, err := CleanDuplicatesWithStats(args)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("CreateBody",
+		"input postMbs", stats.NumInputPostMbs,
+		"cleaned postMbs", stats.NumCleanedPostMbs,
+		"miniBlocks per shard", stats.NumMiniBlocksPerShard,
+	)
+
+	return cleanedPostMbs, nil
 }
 
 // CreatePostMiniBlocks will create all the post miniBlocks after hardfork import
 func CreatePostMiniBlocks(args ArgsHardForkProcessor) error {
-	var err error
+	shardIDs, err := getShardIDsWithBlockProcessor(args)
+	if err != nil {
+		return err
+	}
+
+	args.ShardIDs = shardIDs
+	var cleaner *duplicatesCleaner
 	numPostMbs := len(args.PostMbs)
 	for numPostMbs > 0 {
 		log.Debug("CreatePostBodies", "numPostMbs", numPostMbs)
 		currentPostMbs := make([]*MbInfo, 0)
 		for _, shardID := range args.ShardIDs {
-			hardForkBlockProcessor, ok := args.MapHardForkBlockProcessor[shardID]
-			if !ok {
-				return ErrNilHardForkBlockProcessor
-			}
-
+			hardForkBlockProcessor := args.MapHardForkBlockProcessor[shardID]
 			postBody, postMbs, errCreatePostMiniBlocks := hardForkBlockProcessor.CreatePostMiniBlocks(args.PostMbs)
 			if errCreatePostMiniBlocks != nil {
 				return errCreatePostMiniBlocks
@@ -203,8 +308,15 @@ func CreatePostMiniBlocks(args ArgsHardForkProcessor) error {
 			args.MapBodies[shardID] = currentBody
 		}
 
+		if cleaner == nil {
+			cleaner, err = newDuplicatesCleaner(args.Hasher, args.Marshalizer)
+			if err != nil {
+				return err
+			}
+		}
+
 		args.PostMbs = currentPostMbs
-		args.PostMbs, err = CleanDuplicates(args)
+		args.PostMbs, err = cleaner.cleanDuplicates(args)
 		if err != nil {
 			return err
 		}
@@ -215,42 +327,131 @@ func CreatePostMiniBlocks(args ArgsHardForkProcessor) error {
 	return nil
 }
 
+// checkShardIDs verifies that the provided shard IDs are not empty and do not contain duplicates
+func checkShardIDs(shardIDs []uint32) error {
+	if len(shardIDs) == 0 {
+		return ErrEmptyShardIDs
+	}
+
+	seenShardIDs := make(map[uint32]struct{}, len(shardIDs))
+	for _, shardID := range shardIDs {
+		_, found := seenShardIDs[shardID]
+		if found {
+			return fmt.Errorf("%w: %d", ErrDuplicatedShardID, shardID)
+		}
+
+		seenShardIDs[shardID] = struct{}{}
+	}
+
+	return nil
+}
+
+// getShardIDsWithBlockProcessor returns the shard IDs that have a hardfork block processor. A missing processor is an
+// error, unless the skip missing block processors mode is activated
+func getShardIDsWithBlockProcessor(args ArgsHardForkProcessor) ([]uint32, error) {
+	shardIDs := make([]uint32, 0, len(args.ShardIDs))
+	for _, shardID := range args.ShardIDs {
+		hardForkBlockProcessor, ok := args.MapHardForkBlockProcessor[shardID]
+		if ok && !check.IfNil(hardForkBlockProcessor) {
+			shardIDs = append(shardIDs, shardID)
+			continue
+		}
+		if !args.SkipMissingBlockProcessors {
+			return nil, ErrNilHardForkBlockProcessor
+		}
+
+		log.Warn("hardfork block processor not found, skipping shard", "shard", shardID)
+	}
+
+	return shardIDs, nil
+}
+
 // CleanDuplicates cleans from the post miniBlocks map, the already existing miniBlocks in bodies map
 func CleanDuplicates(args ArgsHardForkProcessor) ([]*MbInfo, error) {
-	if check.IfNil(args.Hasher) {
-		return nil, ErrNilHasher
+	cleaner, err := newDuplicatesCleaner(args.Hasher, args.Marshalizer)
+	if err != nil {
+		return nil, err
 	}
-	if check.IfNil(args.Marshalizer) {
-		return nil, ErrNilMarshalizer
+
+	return cleaner.cleanDuplicates(args)
+}
+
+// CleanDuplicatesStats holds the diagnostics of a clean duplicates call
+type CleanDuplicatesStats struct {
+	NumInputPostMbs       int
+	NumCleanedPostMbs     int
+	NumMiniBlocksPerShard map[uint32]int
+}
+
+// CleanDuplicatesWithStats does the same as CleanDuplicates and also returns the diagnostics of the cleaning: the
+// number of input post miniBlocks, how many of them were cleaned as duplicates and the number of miniBlocks in
+// the body of each shard
+func CleanDuplicatesWithStats(args ArgsHardForkProcessor) ([]*MbInfo, *CleanDuplicatesStats, error) {
+	cleanedPostMbs, err := CleanDuplicates(args)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	mapMiniBlocksHashes := make(map[string]struct{})
+	stats := &CleanDuplicatesStats{
+		NumInputPostMbs:       len(args.PostMbs),
+		NumCleanedPostMbs:     len(args.PostMbs) - len(cleanedPostMbs),
+		NumMiniBlocksPerShard: make(map[uint32]int, len(args.ShardIDs)),
+	}
 	for _, shardID := range args.ShardIDs {
-		currentBody, ok := args.MapBodies[shardID]
-		if !ok {
-			return nil, ErrNilBlockBody
-		}
+		stats.NumMiniBlocksPerShard[shardID] = len(args.MapBodies[shardID].MiniBlocks)
+	}
+
+	return cleanedPostMbs, stats, nil
+}
+
+// VerifyTxsInfoHashes recomputes the hash of each transaction from the provided post miniBlocks and checks it against
+// the hash the transaction was imported with
+func VerifyTxsInfoHashes(postMbs []*MbInfo, marshalizer marshal.Marshalizer, hasher hashing.Hasher) error {
+	if check.IfNil(hasher) {
+		return ErrNilHasher
+	}
+	if check.IfNil(marshalizer) {
+		return ErrNilMarshalizer
+	}
+
+	for _, postMb := range postMbs {
+		for _, txInfo := range postMb.TxsInfo {
+			if check.IfNil(txInfo.Tx) {
+				return fmt.Errorf("%w for tx hash %x in miniBlock %x", ErrNilTransactionHandler, txInfo.TxHash, postMb.MbHash)
+			}
 
-		for _, miniBlock := range currentBody.MiniBlocks {
-			miniBlockHash, err := core.CalculateHash(args.Marshalizer, args.Hasher, miniBlock)
+			txHash, err := core.CalculateHash(marshalizer, hasher, txInfo.Tx)
 			if err != nil {
-				return nil, err
+				return err
 			}
 
-			mapMiniBlocksHashes[string(miniBlockHash)] = struct{}{}
+			if !bytes.Equal(txHash, txInfo.TxHash) {
+				return fmt.Errorf("%w in miniBlock %x: provided %x, computed %x",
+					ErrTxHashMismatch, postMb.MbHash, txInfo.TxHash, txHash)
+			}
 		}
 	}
 
-	cleanedPostMbs := make([]*MbInfo, 0)
-	for _, postMb := range args.PostMbs {
-		_, ok := mapMiniBlocksHashes[string(postMb.MbHash)]
-		if ok {
-			log.Debug("CleanDuplicates: found duplicated miniBlock", "hash", postMb.MbHash)
+	return nil
+}
+
+// CoveredDestinationShards returns the set of shards which are the destination of at least one miniBlock from the
+// provided bodies map
+func CoveredDestinationShards(mapBodies map[uint32]*block.Body) map[uint32]struct{} {
+	coveredShards := make(map[uint32]struct{})
+	for _, body := range mapBodies {
+		if body == nil {
 			continue
 		}
 
-		cleanedPostMbs = append(cleanedPostMbs, postMb)
+		for _, miniBlock := range body.MiniBlocks {
+			if miniBlock == nil {
+				continue
+			}
+
+			coveredShards[miniBlock.ReceiverShardID] = struct{}{}
+		}
 	}
 
-	return cleanedPostMbs, nil
+	return coveredShards
 }
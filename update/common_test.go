@@ -3,10 +3,14 @@ package update_test
 import (
 	"bytes"
 	"errors"
+	"math/big"
+	"strings"
 	"testing"
 
 	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/data"
 	"github.com/multiversx/mx-chain-core-go/data/block"
+	"github.com/multiversx/mx-chain-core-go/data/transaction"
 	"github.com/multiversx/mx-chain-go/testscommon/hashingMocks"
 	"github.com/multiversx/mx-chain-go/update"
 	"github.com/multiversx/mx-chain-go/update/mock"
@@ -26,6 +30,50 @@ func TestCreateBody_ShouldErrNilHardForkBlockProcessor(t *testing.T) {
 	assert.Equal(t, update.ErrNilHardForkBlockProcessor, err)
 }
 
+func TestCreateBody_InvalidShardIDsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	createArgs := func(shardIDs []uint32) update.ArgsHardForkProcessor {
+		hardForkBlockProcessor := &mock.HardForkBlockProcessor{
+			CreateBodyCalled: func() (*block.Body, []*update.MbInfo, error) {
+				assert.Fail(t, "should have not been called")
+				return &block.Body{}, nil, nil
+			},
+		}
+
+		return update.ArgsHardForkProcessor{
+			Hasher:      &hashingMocks.HasherMock{},
+			Marshalizer: &mock.MarshalizerMock{},
+			ShardIDs:    shardIDs,
+			MapBodies:   make(map[uint32]*block.Body),
+			MapHardForkBlockProcessor: map[uint32]update.HardForkBlockProcessor{
+				0: hardForkBlockProcessor,
+				1: hardForkBlockProcessor,
+			},
+		}
+	}
+
+	t.Run("nil shard IDs", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := update.CreateBody(createArgs(nil))
+		assert.Equal(t, update.ErrEmptyShardIDs, err)
+	})
+	t.Run("empty shard IDs", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := update.CreateBody(createArgs(make([]uint32, 0)))
+		assert.Equal(t, update.ErrEmptyShardIDs, err)
+	})
+	t.Run("duplicated shard IDs", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := update.CreateBody(createArgs([]uint32{0, 1, 0}))
+		assert.True(t, errors.Is(err, update.ErrDuplicatedShardID))
+		assert.Contains(t, err.Error(), ": 0")
+	})
+}
+
 func TestCreateBody_ShouldErrWhenCreateBodyFails(t *testing.T) {
 	shardIDs := []uint32{0, 1, 2, 3, 4}
 	errExpected := errors.New("error")
@@ -144,6 +192,98 @@ func TestCreateBody_ShouldWork(t *testing.T) {
 	assert.Equal(t, mbsInfo2[0], postMbs[1])
 }
 
+func TestCreateBody_MissingHardForkBlockProcessor(t *testing.T) {
+	t.Parallel()
+
+	createArgs := func(skipMissingBlockProcessors bool) update.ArgsHardForkProcessor {
+		hardForkBlockProcessor := &mock.HardForkBlockProcessor{
+			CreateBodyCalled: func() (*block.Body, []*update.MbInfo, error) {
+				body := &block.Body{
+					MiniBlocks: []*block.MiniBlock{{SenderShardID: 1, ReceiverShardID: 0}},
+				}
+				postMbs := []*update.MbInfo{{MbHash: []byte("hash"), SenderShardID: 0, ReceiverShardID: 1}}
+
+				return body, postMbs, nil
+			},
+		}
+
+		return update.ArgsHardForkProcessor{
+			Hasher:      &hashingMocks.HasherMock{},
+			Marshalizer: &mock.MarshalizerMock{},
+			ShardIDs:    []uint32{0, 1},
+			MapBodies:   make(map[uint32]*block.Body),
+			MapHardForkBlockProcessor: map[uint32]update.HardForkBlockProcessor{
+				0: hardForkBlockProcessor,
+			},
+			SkipMissingBlockProcessors: skipMissingBlockProcessors,
+		}
+	}
+
+	t.Run("strict mode should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createArgs(false)
+		postMbs, err := update.CreateBody(args)
+		assert.Equal(t, update.ErrNilHardForkBlockProcessor, err)
+		assert.Nil(t, postMbs)
+		assert.Empty(t, args.MapBodies)
+	})
+	t.Run("lenient mode should skip the shard", func(t *testing.T) {
+		t.Parallel()
+
+		args := createArgs(true)
+		postMbs, err := update.CreateBody(args)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(postMbs))
+		assert.Equal(t, 1, len(args.MapBodies))
+		assert.NotNil(t, args.MapBodies[0])
+	})
+}
+
+func TestCreatePostMiniBlocks_MissingHardForkBlockProcessor(t *testing.T) {
+	t.Parallel()
+
+	createArgs := func(skipMissingBlockProcessors bool, numCalls *int) update.ArgsHardForkProcessor {
+		hardForkBlockProcessor := &mock.HardForkBlockProcessor{
+			CreatePostMiniBlocksCalled: func(mbsInfo []*update.MbInfo) (*block.Body, []*update.MbInfo, error) {
+				*numCalls++
+				return &block.Body{}, nil, nil
+			},
+		}
+
+		return update.ArgsHardForkProcessor{
+			Hasher:      &hashingMocks.HasherMock{},
+			Marshalizer: &mock.MarshalizerMock{},
+			ShardIDs:    []uint32{0, 1},
+			MapBodies: map[uint32]*block.Body{
+				0: {},
+			},
+			MapHardForkBlockProcessor: map[uint32]update.HardForkBlockProcessor{
+				0: hardForkBlockProcessor,
+			},
+			PostMbs:                    []*update.MbInfo{{MbHash: []byte("hash")}},
+			SkipMissingBlockProcessors: skipMissingBlockProcessors,
+		}
+	}
+
+	t.Run("strict mode should error", func(t *testing.T) {
+		t.Parallel()
+
+		numCalls := 0
+		err := update.CreatePostMiniBlocks(createArgs(false, &numCalls))
+		assert.Equal(t, update.ErrNilHardForkBlockProcessor, err)
+		assert.Equal(t, 0, numCalls)
+	})
+	t.Run("lenient mode should skip the shard", func(t *testing.T) {
+		t.Parallel()
+
+		numCalls := 0
+		err := update.CreatePostMiniBlocks(createArgs(true, &numCalls))
+		assert.Nil(t, err)
+		assert.Equal(t, 1, numCalls)
+	})
+}
+
 func TestCreatePostMiniBlocks_ShouldErrNilHardForkBlockProcessor(t *testing.T) {
 	shardIDs := []uint32{0, 1, 2, 3, 4}
 	lastPostMbs := []*update.MbInfo{
@@ -496,3 +636,411 @@ func TestCleanDuplicates_ShouldWork(t *testing.T) {
 	assert.Equal(t, cleanedMbs[0].MbHash, []byte("hash1"))
 	assert.Equal(t, cleanedMbs[1].MbHash, []byte("hash4"))
 }
+
+func TestCleanDuplicatesWithStats(t *testing.T) {
+	t.Parallel()
+
+	t.Run("error should not return stats", func(t *testing.T) {
+		t.Parallel()
+
+		args := update.ArgsHardForkProcessor{
+			Hasher:      nil,
+			Marshalizer: &mock.MarshalizerMock{},
+			ShardIDs:    []uint32{0},
+			MapBodies:   map[uint32]*block.Body{0: {}},
+		}
+		cleanedMbs, stats, err := update.CleanDuplicatesWithStats(args)
+		assert.Equal(t, update.ErrNilHasher, err)
+		assert.Nil(t, cleanedMbs)
+		assert.Nil(t, stats)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		hasher := &hashingMocks.HasherMock{}
+		marshalizer := &mock.MarshalizerMock{}
+		mb10 := &block.MiniBlock{SenderShardID: 1, ReceiverShardID: 0}
+		mb01 := &block.MiniBlock{SenderShardID: 0, ReceiverShardID: 1}
+		mb21 := &block.MiniBlock{SenderShardID: 2, ReceiverShardID: 1}
+		mb10Hash, _ := core.CalculateHash(marshalizer, hasher, mb10)
+		mb21Hash, _ := core.CalculateHash(marshalizer, hasher, mb21)
+
+		args := update.ArgsHardForkProcessor{
+			Hasher:      hasher,
+			Marshalizer: marshalizer,
+			ShardIDs:    []uint32{0, 1, 2},
+			MapBodies: map[uint32]*block.Body{
+				0: {MiniBlocks: []*block.MiniBlock{mb10}},
+				1: {MiniBlocks: []*block.MiniBlock{mb01, mb21}},
+				2: {},
+			},
+			PostMbs: []*update.MbInfo{
+				{MbHash: []byte("hash1")},
+				{MbHash: mb10Hash},
+				{MbHash: mb21Hash},
+			},
+		}
+		cleanedMbs, stats, err := update.CleanDuplicatesWithStats(args)
+		assert.Nil(t, err)
+		require.Equal(t, 1, len(cleanedMbs))
+		assert.Equal(t, []byte("hash1"), cleanedMbs[0].MbHash)
+
+		expectedStats := &update.CleanDuplicatesStats{
+			NumInputPostMbs:   3,
+			NumCleanedPostMbs: 2,
+			NumMiniBlocksPerShard: map[uint32]int{
+				0: 1,
+				1: 2,
+				2: 0,
+			},
+		}
+		assert.Equal(t, expectedStats, stats)
+	})
+}
+
+func TestCleanDuplicates_VerifyTxsHashes(t *testing.T) {
+	t.Parallel()
+
+	hasher := &hashingMocks.HasherMock{}
+	marshalizer := &mock.MarshalizerMock{}
+	tx := &transaction.Transaction{Nonce: 1, Value: big.NewInt(10)}
+	txHash, _ := core.CalculateHash(marshalizer, hasher, tx)
+
+	t.Run("matching hashes should work", func(t *testing.T) {
+		t.Parallel()
+
+		args := update.ArgsHardForkProcessor{
+			Hasher:      hasher,
+			Marshalizer: marshalizer,
+			ShardIDs:    []uint32{0},
+			MapBodies:   map[uint32]*block.Body{0: {}},
+			PostMbs: []*update.MbInfo{
+				{MbHash: []byte("hash1"), TxsInfo: []*update.TxInfo{{TxHash: txHash, Tx: tx}}},
+			},
+			VerifyTxsHashes: true,
+		}
+		cleanedMbs, err := update.CleanDuplicates(args)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(cleanedMbs))
+	})
+	t.Run("tampered hash should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := update.ArgsHardForkProcessor{
+			Hasher:      hasher,
+			Marshalizer: marshalizer,
+			ShardIDs:    []uint32{0},
+			MapBodies:   map[uint32]*block.Body{0: {}},
+			PostMbs: []*update.MbInfo{
+				{MbHash: []byte("hash1"), TxsInfo: []*update.TxInfo{{TxHash: []byte("tampered"), Tx: tx}}},
+			},
+			VerifyTxsHashes: true,
+		}
+		cleanedMbs, err := update.CleanDuplicates(args)
+		assert.True(t, errors.Is(err, update.ErrTxHashMismatch))
+		assert.Nil(t, cleanedMbs)
+	})
+	t.Run("tampered hash should not error when verification is disabled", func(t *testing.T) {
+		t.Parallel()
+
+		args := update.ArgsHardForkProcessor{
+			Hasher:      hasher,
+			Marshalizer: marshalizer,
+			ShardIDs:    []uint32{0},
+			MapBodies:   map[uint32]*block.Body{0: {}},
+			PostMbs: []*update.MbInfo{
+				{MbHash: []byte("hash1"), TxsInfo: []*update.TxInfo{{TxHash: []byte("tampered"), Tx: tx}}},
+			},
+		}
+		cleanedMbs, err := update.CleanDuplicates(args)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(cleanedMbs))
+	})
+	t.Run("nil tx should error", func(t *testing.T) {
+		t.Parallel()
+
+		postMbs := []*update.MbInfo{
+			{MbHash: []byte("hash1"), TxsInfo: []*update.TxInfo{{TxHash: txHash}}},
+		}
+		err := update.VerifyTxsInfoHashes(postMbs, marshalizer, hasher)
+		assert.True(t, errors.Is(err, update.ErrNilTransactionHandler))
+	})
+}
+
+func TestCoveredDestinationShards(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil map should return empty set", func(t *testing.T) {
+		t.Parallel()
+
+		coveredShards := update.CoveredDestinationShards(nil)
+		assert.Equal(t, 0, len(coveredShards))
+	})
+	t.Run("should return all destination shards", func(t *testing.T) {
+		t.Parallel()
+
+		mapBodies := map[uint32]*block.Body{
+			0: {MiniBlocks: []*block.MiniBlock{
+				{SenderShardID: 1, ReceiverShardID: 0},
+				{SenderShardID: 0, ReceiverShardID: core.MetachainShardId},
+			}},
+			1: {MiniBlocks: []*block.MiniBlock{
+				{SenderShardID: 0, ReceiverShardID: 1},
+				{SenderShardID: 2, ReceiverShardID: 1},
+				nil,
+			}},
+			2:                     {},
+			core.MetachainShardId: nil,
+		}
+
+		coveredShards := update.CoveredDestinationShards(mapBodies)
+		expectedShards := map[uint32]struct{}{
+			0:                     {},
+			1:                     {},
+			core.MetachainShardId: {},
+		}
+		assert.Equal(t, expectedShards, coveredShards)
+	})
+}
+
+func TestGetNumPendingMiniBlocksPerSenderShard(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil epoch start metaBlock should error", func(t *testing.T) {
+		t.Parallel()
+
+		numPending, err := update.GetNumPendingMiniBlocksPerSenderShard(nil, make(map[string]data.MetaHeaderHandler))
+		assert.Equal(t, update.ErrNilEpochStartMetaBlock, err)
+		assert.Nil(t, numPending)
+	})
+	t.Run("should work across multiple sender shards", func(t *testing.T) {
+		t.Parallel()
+
+		epochStartMetaBlock := &block.MetaBlock{
+			Nonce: 3,
+			EpochStart: block.EpochStart{
+				LastFinalizedHeaders: []block.EpochStartShardData{
+					{
+						ShardID:               0,
+						FirstPendingMetaBlock: []byte("hash1"),
+						PendingMiniBlockHeaders: []block.MiniBlockHeader{
+							{SenderShardID: 1, ReceiverShardID: 0},
+						},
+					},
+					{
+						ShardID:               1,
+						FirstPendingMetaBlock: []byte("hash2"),
+						PendingMiniBlockHeaders: []block.MiniBlockHeader{
+							{SenderShardID: 0, ReceiverShardID: 1},
+						},
+					},
+				},
+			},
+		}
+		unFinishedMetaBlocks := map[string]data.MetaHeaderHandler{
+			"hash1": &block.MetaBlock{Nonce: 1},
+			"hash2": &block.MetaBlock{
+				Nonce: 2,
+				ShardInfo: []block.ShardData{
+					{
+						ShardID: 1,
+						ShardMiniBlockHeaders: []block.MiniBlockHeader{
+							{SenderShardID: 1, ReceiverShardID: 0},
+							{SenderShardID: 1, ReceiverShardID: 2},
+						},
+					},
+					{
+						ShardID: 2,
+						ShardMiniBlockHeaders: []block.MiniBlockHeader{
+							{SenderShardID: 2, ReceiverShardID: 0},
+						},
+					},
+				},
+			},
+			"epochStart": epochStartMetaBlock,
+		}
+
+		numPending, err := update.GetNumPendingMiniBlocksPerSenderShard(epochStartMetaBlock, unFinishedMetaBlocks)
+		assert.Nil(t, err)
+
+		expectedNumPending := map[uint32]int{
+			0: 1,
+			1: 2,
+			2: 1,
+		}
+		assert.Equal(t, expectedNumPending, numPending)
+	})
+}
+
+func TestGetPendingMiniBlocksByType(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil epoch start metaBlock should error", func(t *testing.T) {
+		t.Parallel()
+
+		pendingByType, err := update.GetPendingMiniBlocksByType(nil, make(map[string]data.MetaHeaderHandler))
+		assert.Equal(t, update.ErrNilEpochStartMetaBlock, err)
+		assert.Nil(t, pendingByType)
+	})
+	t.Run("should group mixed miniBlock types", func(t *testing.T) {
+		t.Parallel()
+
+		txMbHdr := block.MiniBlockHeader{Hash: []byte("txMb"), SenderShardID: 1, ReceiverShardID: 0, Type: block.TxBlock}
+		scrMbHdr := block.MiniBlockHeader{Hash: []byte("scrMb"), SenderShardID: 1, ReceiverShardID: 0, Type: block.SmartContractResultBlock}
+		peerMbHdr := block.MiniBlockHeader{Hash: []byte("peerMb"), SenderShardID: 2, ReceiverShardID: 0, Type: block.PeerBlock}
+		secondTxMbHdr := block.MiniBlockHeader{Hash: []byte("txMb2"), SenderShardID: 2, ReceiverShardID: 0, Type: block.TxBlock}
+
+		epochStartMetaBlock := &block.MetaBlock{
+			Nonce: 2,
+			EpochStart: block.EpochStart{
+				LastFinalizedHeaders: []block.EpochStartShardData{
+					{
+						ShardID:                 0,
+						FirstPendingMetaBlock:   []byte("hash1"),
+						PendingMiniBlockHeaders: []block.MiniBlockHeader{txMbHdr, scrMbHdr},
+					},
+				},
+			},
+		}
+		unFinishedMetaBlocks := map[string]data.MetaHeaderHandler{
+			"hash1": &block.MetaBlock{Nonce: 1},
+			"epochStart": &block.MetaBlock{
+				Nonce: 2,
+				ShardInfo: []block.ShardData{
+					{
+						ShardID:               2,
+						ShardMiniBlockHeaders: []block.MiniBlockHeader{peerMbHdr, secondTxMbHdr},
+					},
+				},
+			},
+		}
+
+		pendingByType, err := update.GetPendingMiniBlocksByType(epochStartMetaBlock, unFinishedMetaBlocks)
+		assert.Nil(t, err)
+
+		expectedPendingByType := map[block.Type][]data.MiniBlockHeaderHandler{
+			block.TxBlock:                  {&txMbHdr, &secondTxMbHdr},
+			block.SmartContractResultBlock: {&scrMbHdr},
+			block.PeerBlock:                {&peerMbHdr},
+		}
+		assert.Equal(t, expectedPendingByType, pendingByType)
+	})
+}
+
+func TestGetPendingMiniBlocksOfTypes(t *testing.T) {
+	t.Parallel()
+
+	txMbHdr := block.MiniBlockHeader{Hash: []byte("txMb"), SenderShardID: 1, ReceiverShardID: 0, Type: block.TxBlock}
+	scrMbHdr := block.MiniBlockHeader{Hash: []byte("scrMb"), SenderShardID: 1, ReceiverShardID: 0, Type: block.SmartContractResultBlock}
+	peerMbHdr := block.MiniBlockHeader{Hash: []byte("peerMb"), SenderShardID: 2, ReceiverShardID: 0, Type: block.PeerBlock}
+	rewardMbHdr := block.MiniBlockHeader{Hash: []byte("rewardMb"), SenderShardID: 2, ReceiverShardID: 0, Type: block.RewardsBlock}
+	secondTxMbHdr := block.MiniBlockHeader{Hash: []byte("txMb2"), SenderShardID: 2, ReceiverShardID: 0, Type: block.TxBlock}
+
+	epochStartMetaBlock := &block.MetaBlock{
+		Nonce: 2,
+		EpochStart: block.EpochStart{
+			LastFinalizedHeaders: []block.EpochStartShardData{
+				{
+					ShardID:                 0,
+					FirstPendingMetaBlock:   []byte("hash1"),
+					PendingMiniBlockHeaders: []block.MiniBlockHeader{txMbHdr, scrMbHdr},
+				},
+			},
+		},
+	}
+	unFinishedMetaBlocks := map[string]data.MetaHeaderHandler{
+		"hash1": &block.MetaBlock{Nonce: 1},
+		"epochStart": &block.MetaBlock{
+			Nonce: 2,
+			ShardInfo: []block.ShardData{
+				{
+					ShardID:               2,
+					ShardMiniBlockHeaders: []block.MiniBlockHeader{peerMbHdr, rewardMbHdr, secondTxMbHdr},
+				},
+			},
+		},
+	}
+
+	t.Run("nil epoch start metaBlock should error", func(t *testing.T) {
+		t.Parallel()
+
+		pendingMiniBlocks, err := update.GetPendingMiniBlocksOfTypes(nil, unFinishedMetaBlocks, block.TxBlock)
+		assert.Equal(t, update.ErrNilEpochStartMetaBlock, err)
+		assert.Nil(t, pendingMiniBlocks)
+	})
+	t.Run("no types should return all the pending miniBlocks", func(t *testing.T) {
+		t.Parallel()
+
+		pendingMiniBlocks, err := update.GetPendingMiniBlocksOfTypes(epochStartMetaBlock, unFinishedMetaBlocks)
+		assert.Nil(t, err)
+
+		expectedPendingMiniBlocks := []data.MiniBlockHeaderHandler{&txMbHdr, &scrMbHdr, &peerMbHdr, &rewardMbHdr, &secondTxMbHdr}
+		assert.Equal(t, expectedPendingMiniBlocks, pendingMiniBlocks)
+	})
+	t.Run("single type should return only the miniBlocks of that type", func(t *testing.T) {
+		t.Parallel()
+
+		pendingMiniBlocks, err := update.GetPendingMiniBlocksOfTypes(epochStartMetaBlock, unFinishedMetaBlocks, block.TxBlock)
+		assert.Nil(t, err)
+		assert.Equal(t, []data.MiniBlockHeaderHandler{&txMbHdr, &secondTxMbHdr}, pendingMiniBlocks)
+	})
+	t.Run("multiple types should return the miniBlocks of any of the types", func(t *testing.T) {
+		t.Parallel()
+
+		pendingMiniBlocks, err := update.GetPendingMiniBlocksOfTypes(epochStartMetaBlock, unFinishedMetaBlocks, block.PeerBlock, block.RewardsBlock)
+		assert.Nil(t, err)
+		assert.Equal(t, []data.MiniBlockHeaderHandler{&peerMbHdr, &rewardMbHdr}, pendingMiniBlocks)
+	})
+	t.Run("type without pending miniBlocks should return empty", func(t *testing.T) {
+		t.Parallel()
+
+		pendingMiniBlocks, err := update.GetPendingMiniBlocksOfTypes(epochStartMetaBlock, unFinishedMetaBlocks, block.InvalidBlock)
+		assert.Nil(t, err)
+		assert.Empty(t, pendingMiniBlocks)
+	})
+}
+
+func TestGetPendingMiniBlocks_ShardsCoverage(t *testing.T) {
+	t.Parallel()
+
+	unFinishedMetaBlocks := map[string]data.MetaHeaderHandler{
+		"hash1": &block.MetaBlock{Nonce: 1},
+	}
+
+	t.Run("missing first pending metaBlock of a shard should error", func(t *testing.T) {
+		t.Parallel()
+
+		epochStartMetaBlock := &block.MetaBlock{
+			Nonce: 1,
+			EpochStart: block.EpochStart{
+				LastFinalizedHeaders: []block.EpochStartShardData{
+					{ShardID: 0, FirstPendingMetaBlock: []byte("hash1")},
+					{ShardID: 1, FirstPendingMetaBlock: []byte("missing hash")},
+				},
+			},
+		}
+
+		pendingMiniBlocks, err := update.GetPendingMiniBlocks(epochStartMetaBlock, unFinishedMetaBlocks)
+		assert.Nil(t, pendingMiniBlocks)
+		assert.True(t, errors.Is(err, update.ErrWrongUnFinishedMetaHdrsMap))
+		assert.True(t, strings.Contains(err.Error(), "of shard 1"))
+	})
+	t.Run("all the shards covered should work", func(t *testing.T) {
+		t.Parallel()
+
+		mbHdr := block.MiniBlockHeader{Hash: []byte("mb"), SenderShardID: 0, ReceiverShardID: 1}
+		epochStartMetaBlock := &block.MetaBlock{
+			Nonce: 1,
+			EpochStart: block.EpochStart{
+				LastFinalizedHeaders: []block.EpochStartShardData{
+					{ShardID: 0, FirstPendingMetaBlock: []byte("hash1")},
+					{ShardID: 1, FirstPendingMetaBlock: []byte("hash1"), PendingMiniBlockHeaders: []block.MiniBlockHeader{mbHdr}},
+				},
+			},
+		}
+
+		pendingMiniBlocks, err := update.GetPendingMiniBlocks(epochStartMetaBlock, unFinishedMetaBlocks)
+		assert.Nil(t, err)
+		assert.Equal(t, []data.MiniBlockHeaderHandler{&mbHdr}, pendingMiniBlocks)
+	})
+}
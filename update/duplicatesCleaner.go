@@ -0,0 +1,119 @@
+package update
+
+import (
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data/block"
+	"github.com/multiversx/mx-chain-core-go/hashing"
+	"github.com/multiversx/mx-chain-core-go/marshal"
+)
+
+// duplicatesCleaner keeps the hashes of the miniBlocks already existing in the bodies map between calls, so that only
+// the newly appended miniBlocks have to be hashed on each cleaning iteration. The last hashed miniBlock of each shard is
+// kept as well, in order to detect the bodies that were replaced instead of being appended to
+type duplicatesCleaner struct {
+	hasher               hashing.Hasher
+	marshalizer          marshal.Marshalizer
+	mapMiniBlocksHashes  map[string]struct{}
+	numHashedMiniBlocks  map[uint32]int
+	lastHashedMiniBlocks map[uint32]*block.MiniBlock
+}
+
+func newDuplicatesCleaner(hasher hashing.Hasher, marshalizer marshal.Marshalizer) (*duplicatesCleaner, error) {
+	if check.IfNil(hasher) {
+		return nil, ErrNilHasher
+	}
+	if check.IfNil(marshalizer) {
+		return nil, ErrNilMarshalizer
+	}
+
+	return &duplicatesCleaner{
+		hasher:               hasher,
+		marshalizer:          marshalizer,
+		mapMiniBlocksHashes:  make(map[string]struct{}),
+		numHashedMiniBlocks:  make(map[uint32]int),
+		lastHashedMiniBlocks: make(map[uint32]*block.MiniBlock),
+	}, nil
+}
+
+// cleanDuplicates cleans from the post miniBlocks, the already existing miniBlocks in bodies map. The bodies are
+// expected to only grow between calls, by appending miniBlocks, as CreatePostMiniBlocks does. A body that was replaced
+// instead is detected and all the hashes are recomputed
+func (dc *duplicatesCleaner) cleanDuplicates(args ArgsHardForkProcessor) ([]*MbInfo, error) {
+	if args.VerifyTxsHashes {
+		err := VerifyTxsInfoHashes(args.PostMbs, dc.marshalizer, dc.hasher)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err := dc.updateMiniBlocksHashes(args)
+	if err != nil {
+		return nil, err
+	}
+
+	cleanedPostMbs := make([]*MbInfo, 0)
+	for _, postMb := range args.PostMbs {
+		_, ok := dc.mapMiniBlocksHashes[string(postMb.MbHash)]
+		if ok {
+			log.Debug("CleanDuplicates: found duplicated miniBlock", "hash", postMb.MbHash)
+			continue
+		}
+
+		cleanedPostMbs = append(cleanedPostMbs, postMb)
+	}
+
+	return cleanedPostMbs, nil
+}
+
+func (dc *duplicatesCleaner) updateMiniBlocksHashes(args ArgsHardForkProcessor) error {
+	for _, shardID := range args.ShardIDs {
+		currentBody, ok := args.MapBodies[shardID]
+		if !ok {
+			return ErrNilBlockBody
+		}
+
+		numHashed := dc.numHashedMiniBlocks[shardID]
+		if !dc.wasAppendedTo(shardID, currentBody) {
+			// the body was replaced instead of extended, the already computed hashes can not be trusted anymore
+			dc.reset()
+			return dc.updateMiniBlocksHashes(args)
+		}
+
+		for _, miniBlock := range currentBody.MiniBlocks[numHashed:] {
+			miniBlockHash, err := core.CalculateHash(dc.marshalizer, dc.hasher, miniBlock)
+			if err != nil {
+				return err
+			}
+
+			dc.mapMiniBlocksHashes[string(miniBlockHash)] = struct{}{}
+		}
+
+		dc.numHashedMiniBlocks[shardID] = len(currentBody.MiniBlocks)
+		if len(currentBody.MiniBlocks) > 0 {
+			dc.lastHashedMiniBlocks[shardID] = currentBody.MiniBlocks[len(currentBody.MiniBlocks)-1]
+		}
+	}
+
+	return nil
+}
+
+// wasAppendedTo returns true if the provided body still holds, on the same position, the last miniBlock hashed for the
+// shard, meaning that the body was only appended to since the previous call
+func (dc *duplicatesCleaner) wasAppendedTo(shardID uint32, body *block.Body) bool {
+	numHashed := dc.numHashedMiniBlocks[shardID]
+	if numHashed == 0 {
+		return true
+	}
+	if numHashed > len(body.MiniBlocks) {
+		return false
+	}
+
+	return body.MiniBlocks[numHashed-1] == dc.lastHashedMiniBlocks[shardID]
+}
+
+func (dc *duplicatesCleaner) reset() {
+	dc.mapMiniBlocksHashes = make(map[string]struct{})
+	dc.numHashedMiniBlocks = make(map[uint32]int)
+	dc.lastHashedMiniBlocks = make(map[uint32]*block.MiniBlock)
+}
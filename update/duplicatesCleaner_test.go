@@ -0,0 +1,203 @@
+package update
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/data/block"
+	"github.com/multiversx/mx-chain-go/testscommon/hashingMocks"
+	"github.com/multiversx/mx-chain-go/testscommon/marshallerMock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createMiniBlocks(senderShardID uint32, receiverShardID uint32, startIndex int, numMiniBlocks int) []*block.MiniBlock {
+	miniBlocks := make([]*block.MiniBlock, 0, numMiniBlocks)
+	for i := startIndex; i < startIndex+numMiniBlocks; i++ {
+		miniBlocks = append(miniBlocks, &block.MiniBlock{
+			SenderShardID:   senderShardID,
+			ReceiverShardID: receiverShardID,
+			TxHashes:        [][]byte{[]byte(fmt.Sprintf("tx%d", i))},
+		})
+	}
+
+	return miniBlocks
+}
+
+// countingHasher counts the computed hashes. The update mocks can not be used here, as they import this package
+type countingHasher struct {
+	hashingMocks.HasherMock
+	numComputed int
+}
+
+// Compute -
+func (hasher *countingHasher) Compute(s string) []byte {
+	hasher.numComputed++
+	return hasher.HasherMock.Compute(s)
+}
+
+func TestNewDuplicatesCleaner(t *testing.T) {
+	t.Parallel()
+
+	cleaner, err := newDuplicatesCleaner(nil, &marshallerMock.MarshalizerMock{})
+	assert.Nil(t, cleaner)
+	assert.Equal(t, ErrNilHasher, err)
+
+	cleaner, err = newDuplicatesCleaner(&hashingMocks.HasherMock{}, nil)
+	assert.Nil(t, cleaner)
+	assert.Equal(t, ErrNilMarshalizer, err)
+
+	cleaner, err = newDuplicatesCleaner(&hashingMocks.HasherMock{}, &marshallerMock.MarshalizerMock{})
+	assert.NotNil(t, cleaner)
+	assert.Nil(t, err)
+}
+
+func TestDuplicatesCleaner_CleanDuplicatesShouldMatchTheStatelessVersion(t *testing.T) {
+	t.Parallel()
+
+	hasher := &hashingMocks.HasherMock{}
+	marshalizer := &marshallerMock.MarshalizerMock{}
+	shardIDs := []uint32{0, 1}
+	mapBodies := map[uint32]*block.Body{
+		0: {MiniBlocks: createMiniBlocks(1, 0, 0, 3)},
+		1: {MiniBlocks: createMiniBlocks(0, 1, 3, 3)},
+	}
+	cleanerHasher := &countingHasher{}
+	cleaner, _ := newDuplicatesCleaner(cleanerHasher, marshalizer)
+
+	newMiniBlocks := createMiniBlocks(1, 0, 6, 2)
+	for iteration := 0; iteration < 3; iteration++ {
+		postMbs := make([]*MbInfo, 0)
+		for _, miniBlock := range append(mapBodies[0].MiniBlocks, newMiniBlocks...) {
+			miniBlockHash, _ := core.CalculateHash(marshalizer, hasher, miniBlock)
+			postMbs = append(postMbs, &MbInfo{MbHash: miniBlockHash})
+		}
+
+		args := ArgsHardForkProcessor{
+			Hasher:      hasher,
+			Marshalizer: marshalizer,
+			ShardIDs:    shardIDs,
+			MapBodies:   mapBodies,
+			PostMbs:     postMbs,
+		}
+		expectedMbs, err := CleanDuplicates(args)
+		require.Nil(t, err)
+
+		cleanedMbs, err := cleaner.cleanDuplicates(args)
+		require.Nil(t, err)
+		assert.Equal(t, expectedMbs, cleanedMbs)
+		assert.Equal(t, len(newMiniBlocks), len(cleanedMbs))
+
+		mapBodies[0].MiniBlocks = append(mapBodies[0].MiniBlocks, newMiniBlocks...)
+		newMiniBlocks = createMiniBlocks(1, 0, 10*(iteration+1), 2)
+	}
+
+	// each of the miniBlocks should have been hashed only once: 6 initial ones and 2 appended in each of the first 2 iterations
+	assert.Equal(t, 10, cleanerHasher.numComputed)
+}
+
+func TestDuplicatesCleaner_CleanDuplicatesReplacedBodyShouldRecompute(t *testing.T) {
+	t.Parallel()
+
+	hasher := &hashingMocks.HasherMock{}
+	marshalizer := &marshallerMock.MarshalizerMock{}
+	initialMiniBlocks := createMiniBlocks(1, 0, 0, 3)
+	mapBodies := map[uint32]*block.Body{
+		0: {MiniBlocks: initialMiniBlocks},
+	}
+	initialMbHash, _ := core.CalculateHash(marshalizer, hasher, initialMiniBlocks[0])
+	args := ArgsHardForkProcessor{
+		Hasher:      hasher,
+		Marshalizer: marshalizer,
+		ShardIDs:    []uint32{0},
+		MapBodies:   mapBodies,
+		PostMbs:     []*MbInfo{{MbHash: initialMbHash}},
+	}
+	cleaner, _ := newDuplicatesCleaner(hasher, marshalizer)
+
+	cleanedMbs, err := cleaner.cleanDuplicates(args)
+	require.Nil(t, err)
+	assert.Equal(t, 0, len(cleanedMbs))
+
+	mapBodies[0] = &block.Body{MiniBlocks: createMiniBlocks(1, 0, 5, 1)}
+	cleanedMbs, err = cleaner.cleanDuplicates(args)
+	require.Nil(t, err)
+	assert.Equal(t, 1, len(cleanedMbs))
+}
+
+func TestDuplicatesCleaner_CleanDuplicatesReplacedBodyOfSameOrGreaterLengthShouldRecompute(t *testing.T) {
+	t.Parallel()
+
+	hasher := &hashingMocks.HasherMock{}
+	marshalizer := &marshallerMock.MarshalizerMock{}
+	initialMiniBlocks := createMiniBlocks(1, 0, 0, 2)
+	mapBodies := map[uint32]*block.Body{
+		0: {MiniBlocks: initialMiniBlocks},
+	}
+	initialMbHash, _ := core.CalculateHash(marshalizer, hasher, initialMiniBlocks[0])
+	args := ArgsHardForkProcessor{
+		Hasher:      hasher,
+		Marshalizer: marshalizer,
+		ShardIDs:    []uint32{0},
+		MapBodies:   mapBodies,
+		PostMbs:     []*MbInfo{{MbHash: initialMbHash}},
+	}
+	cleaner, _ := newDuplicatesCleaner(hasher, marshalizer)
+
+	cleanedMbs, err := cleaner.cleanDuplicates(args)
+	require.Nil(t, err)
+	assert.Equal(t, 0, len(cleanedMbs))
+
+	for _, numMiniBlocks := range []int{2, 3} {
+		mapBodies[0] = &block.Body{MiniBlocks: createMiniBlocks(1, 0, 5, numMiniBlocks)}
+		cleanedMbs, err = cleaner.cleanDuplicates(args)
+		require.Nil(t, err)
+		assert.Equal(t, 1, len(cleanedMbs))
+	}
+
+	// appending should still keep the already computed hashes
+	mapBodies[0].MiniBlocks = append(mapBodies[0].MiniBlocks, initialMiniBlocks[0])
+	cleanedMbs, err = cleaner.cleanDuplicates(args)
+	require.Nil(t, err)
+	assert.Equal(t, 0, len(cleanedMbs))
+}
+
+func BenchmarkCleanDuplicates(b *testing.B) {
+	hasher := &hashingMocks.HasherMock{}
+	marshalizer := &marshallerMock.MarshalizerMock{}
+	numIterations := 20
+	numMiniBlocksPerIteration := 50
+
+	createArgs := func() ArgsHardForkProcessor {
+		return ArgsHardForkProcessor{
+			Hasher:      hasher,
+			Marshalizer: marshalizer,
+			ShardIDs:    []uint32{0},
+			MapBodies:   map[uint32]*block.Body{0: {}},
+			PostMbs:     []*MbInfo{{MbHash: []byte("hash")}},
+		}
+	}
+
+	b.Run("stateless", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			args := createArgs()
+			for iteration := 0; iteration < numIterations; iteration++ {
+				args.MapBodies[0].MiniBlocks = append(args.MapBodies[0].MiniBlocks,
+					createMiniBlocks(1, 0, iteration*numMiniBlocksPerIteration, numMiniBlocksPerIteration)...)
+				_, _ = CleanDuplicates(args)
+			}
+		}
+	})
+	b.Run("incremental", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			args := createArgs()
+			cleaner, _ := newDuplicatesCleaner(hasher, marshalizer)
+			for iteration := 0; iteration < numIterations; iteration++ {
+				args.MapBodies[0].MiniBlocks = append(args.MapBodies[0].MiniBlocks,
+					createMiniBlocks(1, 0, iteration*numMiniBlocksPerIteration, numMiniBlocksPerIteration)...)
+				_, _ = cleaner.cleanDuplicates(args)
+			}
+		}
+	})
+}
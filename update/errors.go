@@ -298,3 +298,15 @@ var ErrNilEnableEpochsHandler = errors.New("nil enable epochs handler")
 
 // ErrNilNetworkComponents signals that a nil network components instance was provided
 var ErrNilNetworkComponents = errors.New("nil network components")
+
+// ErrNilTransactionHandler signals that a nil transaction handler was provided
+var ErrNilTransactionHandler = errors.New("nil transaction handler")
+
+// ErrTxHashMismatch signals that the provided transaction hash does not match the computed one
+var ErrTxHashMismatch = errors.New("transaction hash mismatch")
+
+// ErrEmptyShardIDs signals that an empty shard IDs slice was provided
+var ErrEmptyShardIDs = errors.New("empty shard IDs")
+
+// ErrDuplicatedShardID signals that a shard ID was provided more than once
+var ErrDuplicatedShardID = errors.New("duplicated shard ID")
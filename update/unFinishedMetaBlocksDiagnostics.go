@@ -0,0 +1,75 @@
+package update
+
+import (
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data"
+)
+
+// UnFinishedMetaBlocksDiagnostic holds, for one shard from the last finalized headers of the epoch start metaBlock,
+// the metaBlocks needed when computing its pending miniBlocks and which of them are present in the unFinished map
+type UnFinishedMetaBlocksDiagnostic struct {
+	ShardID                    uint32
+	FirstPendingMetaBlockHash  []byte
+	FirstPendingMetaBlockFound bool
+	// FirstExpectedNonce and LastExpectedNonce define the expected nonces range. They are set only if the first
+	// pending metaBlock was found, as its nonce is the start of the range
+	FirstExpectedNonce uint64
+	LastExpectedNonce  uint64
+	PresentNonces      []uint64
+	MissingNonces      []uint64
+}
+
+// IsComplete returns true if all the metaBlocks needed for the shard are present in the unFinished metaBlocks map
+func (diagnostic *UnFinishedMetaBlocksDiagnostic) IsComplete() bool {
+	return diagnostic.FirstPendingMetaBlockFound && len(diagnostic.MissingNonces) == 0
+}
+
+// AnalyzeUnFinishedMetaBlocks returns, for each shard from the last finalized headers of the epoch start metaBlock, the
+// range of the metaBlock nonces needed when computing the pending miniBlocks, along with the nonces present in and the
+// ones missing from the unFinished metaBlocks map. No pending miniBlocks are computed, the result being meant for
+// troubleshooting the ErrWrongUnFinishedMetaHdrsMap errors
+func AnalyzeUnFinishedMetaBlocks(
+	epochStartMetaBlock data.MetaHeaderHandler,
+	unFinishedMetaBlocksMap map[string]data.MetaHeaderHandler,
+) ([]*UnFinishedMetaBlocksDiagnostic, error) {
+	if check.IfNil(epochStartMetaBlock) {
+		return nil, ErrNilEpochStartMetaBlock
+	}
+	if unFinishedMetaBlocksMap == nil {
+		return nil, ErrNilUnFinishedMetaBlocksMap
+	}
+
+	nonceToHashMap := createNonceToHashMap(unFinishedMetaBlocksMap)
+	lastFinalizedHeaders := epochStartMetaBlock.GetEpochStartHandler().GetLastFinalizedHeaderHandlers()
+	diagnostics := make([]*UnFinishedMetaBlocksDiagnostic, 0, len(lastFinalizedHeaders))
+	for _, shardData := range lastFinalizedHeaders {
+		diagnostic := &UnFinishedMetaBlocksDiagnostic{
+			ShardID:                   shardData.GetShardID(),
+			FirstPendingMetaBlockHash: shardData.GetFirstPendingMetaBlock(),
+			PresentNonces:             make([]uint64, 0),
+			MissingNonces:             make([]uint64, 0),
+		}
+		diagnostics = append(diagnostics, diagnostic)
+
+		firstPendingMetaBlock, found := unFinishedMetaBlocksMap[string(shardData.GetFirstPendingMetaBlock())]
+		if !found || check.IfNil(firstPendingMetaBlock) {
+			continue
+		}
+
+		diagnostic.FirstPendingMetaBlockFound = true
+		diagnostic.FirstExpectedNonce = firstPendingMetaBlock.GetNonce()
+		diagnostic.LastExpectedNonce = epochStartMetaBlock.GetNonce()
+		diagnostic.PresentNonces = append(diagnostic.PresentNonces, diagnostic.FirstExpectedNonce)
+		for nonce := diagnostic.FirstExpectedNonce + 1; nonce <= diagnostic.LastExpectedNonce; nonce++ {
+			_, exists := nonceToHashMap[nonce]
+			if !exists {
+				diagnostic.MissingNonces = append(diagnostic.MissingNonces, nonce)
+				continue
+			}
+
+			diagnostic.PresentNonces = append(diagnostic.PresentNonces, nonce)
+		}
+	}
+
+	return diagnostics, nil
+}
@@ -0,0 +1,117 @@
+package update_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/data"
+	"github.com/multiversx/mx-chain-core-go/data/block"
+	"github.com/multiversx/mx-chain-go/update"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzeUnFinishedMetaBlocks(t *testing.T) {
+	t.Parallel()
+
+	epochStartMetaBlock := &block.MetaBlock{
+		Nonce: 4,
+		EpochStart: block.EpochStart{
+			LastFinalizedHeaders: []block.EpochStartShardData{
+				{ShardID: 0, FirstPendingMetaBlock: []byte("hash1")},
+				{ShardID: 1, FirstPendingMetaBlock: []byte("hash3")},
+			},
+		},
+	}
+
+	t.Run("nil epoch start metaBlock should error", func(t *testing.T) {
+		t.Parallel()
+
+		diagnostics, err := update.AnalyzeUnFinishedMetaBlocks(nil, make(map[string]data.MetaHeaderHandler))
+		assert.Equal(t, update.ErrNilEpochStartMetaBlock, err)
+		assert.Nil(t, diagnostics)
+	})
+	t.Run("nil unFinished metaBlocks map should error", func(t *testing.T) {
+		t.Parallel()
+
+		diagnostics, err := update.AnalyzeUnFinishedMetaBlocks(epochStartMetaBlock, nil)
+		assert.Equal(t, update.ErrNilUnFinishedMetaBlocksMap, err)
+		assert.Nil(t, diagnostics)
+	})
+	t.Run("clean input should report all the nonces as present", func(t *testing.T) {
+		t.Parallel()
+
+		unFinishedMetaBlocks := map[string]data.MetaHeaderHandler{
+			"hash1": &block.MetaBlock{Nonce: 1},
+			"hash2": &block.MetaBlock{Nonce: 2},
+			"hash3": &block.MetaBlock{Nonce: 3},
+			"hash4": &block.MetaBlock{Nonce: 4},
+		}
+
+		diagnostics, err := update.AnalyzeUnFinishedMetaBlocks(epochStartMetaBlock, unFinishedMetaBlocks)
+		require.Nil(t, err)
+
+		expectedDiagnostics := []*update.UnFinishedMetaBlocksDiagnostic{
+			{
+				ShardID:                    0,
+				FirstPendingMetaBlockHash:  []byte("hash1"),
+				FirstPendingMetaBlockFound: true,
+				FirstExpectedNonce:         1,
+				LastExpectedNonce:          4,
+				PresentNonces:              []uint64{1, 2, 3, 4},
+				MissingNonces:              []uint64{},
+			},
+			{
+				ShardID:                    1,
+				FirstPendingMetaBlockHash:  []byte("hash3"),
+				FirstPendingMetaBlockFound: true,
+				FirstExpectedNonce:         3,
+				LastExpectedNonce:          4,
+				PresentNonces:              []uint64{3, 4},
+				MissingNonces:              []uint64{},
+			},
+		}
+		assert.Equal(t, expectedDiagnostics, diagnostics)
+		assert.True(t, diagnostics[0].IsComplete())
+		assert.True(t, diagnostics[1].IsComplete())
+
+		_, err = update.GetPendingMiniBlocks(epochStartMetaBlock, unFinishedMetaBlocks)
+		assert.Nil(t, err)
+	})
+	t.Run("gapped input should report the missing nonces and metaBlocks", func(t *testing.T) {
+		t.Parallel()
+
+		unFinishedMetaBlocks := map[string]data.MetaHeaderHandler{
+			"hash1": &block.MetaBlock{Nonce: 1},
+			"hash2": &block.MetaBlock{Nonce: 2},
+			"hash4": &block.MetaBlock{Nonce: 4},
+		}
+
+		diagnostics, err := update.AnalyzeUnFinishedMetaBlocks(epochStartMetaBlock, unFinishedMetaBlocks)
+		require.Nil(t, err)
+
+		expectedDiagnostics := []*update.UnFinishedMetaBlocksDiagnostic{
+			{
+				ShardID:                    0,
+				FirstPendingMetaBlockHash:  []byte("hash1"),
+				FirstPendingMetaBlockFound: true,
+				FirstExpectedNonce:         1,
+				LastExpectedNonce:          4,
+				PresentNonces:              []uint64{1, 2, 4},
+				MissingNonces:              []uint64{3},
+			},
+			{
+				ShardID:                   1,
+				FirstPendingMetaBlockHash: []byte("hash3"),
+				PresentNonces:             []uint64{},
+				MissingNonces:             []uint64{},
+			},
+		}
+		assert.Equal(t, expectedDiagnostics, diagnostics)
+		assert.False(t, diagnostics[0].IsComplete())
+		assert.False(t, diagnostics[1].IsComplete())
+
+		_, err = update.GetPendingMiniBlocks(epochStartMetaBlock, unFinishedMetaBlocks)
+		assert.True(t, errors.Is(err, update.ErrWrongUnFinishedMetaHdrsMap))
+	})
+}